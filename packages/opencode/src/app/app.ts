@@ -3,6 +3,7 @@ import { Log } from "../util/log"
 import { Context } from "../util/context"
 import { Filesystem } from "../util/filesystem"
 import { Global } from "../global"
+import { Installation } from "../installation"
 import path from "path"
 import os from "os"
 import { z } from "zod"
@@ -24,6 +25,7 @@ export namespace App {
       time: z.object({
         initialized: z.number().optional(),
       }),
+      version: z.string(),
     })
     .openapi({
       ref: "App",
@@ -81,6 +83,7 @@ export namespace App {
         root,
         cwd: input.cwd,
       },
+      version: Installation.VERSION,
     }
     const app = {
       services,
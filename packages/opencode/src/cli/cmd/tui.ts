@@ -13,6 +13,9 @@ import { Log } from "../../util/log"
 import { FileWatcher } from "../../file/watch"
 import { Mode } from "../../session/mode"
 
+// exit status the TUI uses to ask for a restart into a newly installed version
+const RESTART_EXIT_CODE = 75
+
 export const TuiCommand = cmd({
   command: "$0 [project]",
   describe: "start autoprovisioner tui",
@@ -31,6 +34,18 @@ export const TuiCommand = cmd({
         alias: ["p"],
         type: "string",
         describe: "prompt to use",
+      })
+      .option("theme", {
+        type: "string",
+        describe: "theme to use, or auto to follow the terminal background",
+      })
+      .option("profile", {
+        type: "boolean",
+        describe: "log slow renders to find performance regressions",
+      })
+      .option("debug", {
+        type: "boolean",
+        describe: "enable developer tools such as the event inspector",
       }),
   // .option("mode", {
   //   type: "string",
@@ -82,6 +97,9 @@ export const TuiCommand = cmd({
             ...cmd,
             // ...(args.model ? ["--model", args.model] : []),
             ...(args.prompt ? ["--prompt", args.prompt] : []),
+            ...(args.theme ? ["--theme", args.theme] : []),
+            ...(args.profile ? ["--profile"] : []),
+            ...(args.debug ? ["--debug"] : []),
             // ...(args.mode ? ["--mode", args.mode] : []),
           ],
           cwd,
@@ -120,9 +138,21 @@ export const TuiCommand = cmd({
         await proc.exited
         server.stop()
 
+        if (proc.exitCode === RESTART_EXIT_CODE) return "restart"
         return "done"
       })
       if (result === "done") break
+      if (result === "restart") {
+        // the running process is the previous version, start the installed one in its place
+        const exitCode = await Bun.spawn({
+          cmd: [...getOpencodeCommand(), ...process.argv.slice(2)],
+          cwd: process.cwd(),
+          stdout: "inherit",
+          stderr: "inherit",
+          stdin: "inherit",
+        }).exited
+        process.exit(exitCode)
+      }
       if (result === "needs_provider") {
         UI.empty()
         UI.println(UI.logo("   "))
@@ -108,7 +108,10 @@ export namespace Config {
       session_interrupt: z.string().optional().default("esc").describe("Interrupt current session"),
       session_compact: z.string().optional().default("<leader>c").describe("Compact the session"),
       tool_details: z.string().optional().default("<leader>d").describe("Toggle tool details"),
+      tool_details_expand: z.string().optional().describe("Expand the details of every tool call"),
+      tool_details_collapse: z.string().optional().describe("Collapse the details of every tool call"),
       model_list: z.string().optional().default("<leader>m").describe("List available models"),
+      provider_list: z.string().optional().describe("Pick a provider to list its models"),
       theme_list: z.string().optional().default("<leader>t").describe("List available themes"),
       file_list: z.string().optional().default("<leader>f").describe("List files"),
       file_close: z.string().optional().default("esc").describe("Close file"),
@@ -134,6 +137,79 @@ export namespace Config {
       messages_layout_toggle: z.string().optional().default("<leader>p").describe("Toggle layout"),
       messages_copy: z.string().optional().default("<leader>y").describe("Copy message"),
       messages_revert: z.string().optional().default("<leader>r").describe("Revert message"),
+      input_file_insert: z.string().optional().default("<leader>a").describe("Insert file contents inline"),
+      file_next: z.string().optional().default("<leader>]").describe("Next file tab"),
+      file_previous: z.string().optional().default("<leader>[").describe("Previous file tab"),
+      messages_toc: z.string().optional().default("<leader>o").describe("Show table of contents for the current message"),
+      tool_output_wrap: z.string().optional().default("<leader>w").describe("Toggle tool output wrap"),
+      tool_output_left: z.string().optional().default("<leader>left").describe("Scroll tool output left"),
+      tool_output_right: z.string().optional().default("<leader>right").describe("Scroll tool output right"),
+      prompt_cancel: z.string().optional().default("<leader>k").describe("Cancel a sent message before the server accepts it"),
+      prompt_new_session: z.string().optional().describe("Move the editor content into a new session"),
+      messages_model_badges: z.string().optional().default("<leader>b").describe("Toggle model badges on assistant messages"),
+      session_curl: z.string().optional().default("<leader>g").describe("Copy the last chat request as a curl command"),
+      session_copy_id: z.string().optional().describe("Copy the current session ID"),
+      session_abort_all: z.string().optional().describe("Abort every session with a response in progress"),
+      session_child: z.string().optional().describe("Open the latest sub-agent session"),
+      session_parent: z.string().optional().describe("Return to the parent session"),
+      session_note: z
+        .string()
+        .optional()
+        .describe("Add the input as a note to the conversation, or a separator when empty"),
+      session_web: z.string().optional().describe("Open the shared session or server dashboard in a browser"),
+      focus_toggle: z.string().optional().default("<leader>tab").describe("Cycle focus between the editor, messages and file viewer"),
+      messages_line_up: z.string().optional().default("alt+up").describe("Scroll messages up a few lines"),
+      messages_line_down: z.string().optional().default("alt+down").describe("Scroll messages down a few lines"),
+      file_attach: z.string().optional().describe("Attach the file open in the viewer to the input"),
+      file_change_next: z.string().optional().default("<leader>.").describe("Jump to the next change in the file diff"),
+      file_change_previous: z.string().optional().default("<leader>,").describe("Jump to the previous change in the file diff"),
+      file_copy_hunk: z.string().optional().default("<leader>j").describe("Copy the diff hunk in view as a patch"),
+      file_goto_edit: z.string().optional().describe("Jump to the message whose edit changed the diff line in view"),
+      file_viewer_grow: z.string().optional().default("<leader>=").describe("Widen the file viewer beside the messages"),
+      file_viewer_shrink: z.string().optional().default("<leader>-").describe("Narrow the file viewer beside the messages"),
+      input_clear_attachments: z.string().optional().default("<leader>z").describe("Remove attachments from the input, keeping the text"),
+      input_git_context: z.string().optional().describe("Attach the git status and diff of the working tree"),
+      input_fence: z.string().optional().default("<leader>`").describe("Wrap the selection or input in a code fence"),
+      input_date: z.string().optional().describe("Insert the current date at the cursor"),
+      input_time: z.string().optional().describe("Insert the current time at the cursor"),
+      input_cwd: z.string().optional().describe("Insert the session working directory at the cursor"),
+      input_line_numbers: z.string().optional().describe("Cycle editor line numbers between off, absolute and relative"),
+      input_file_completion: z
+        .string()
+        .optional()
+        .default("ctrl+alt+f")
+        .describe("Open or close file completion at the cursor"),
+      messages_raw: z.string().optional().describe("Toggle between rendered and raw markdown in messages"),
+      messages_synthetic: z.string().optional().describe("Show or hide context injected into messages, for debugging"),
+      messages_short_paths: z.string().optional().describe("Shorten file paths in messages to their last segments"),
+      messages_compare: z.string().optional().describe("Mark two responses and diff their text"),
+      session_save: z.string().optional().describe("Save the conversation as markdown within the project"),
+      session_import: z
+        .string()
+        .optional()
+        .describe("Replay the user messages of an exported conversation into a new session"),
+      hints_toggle: z.string().optional().describe("Show or hide the keybind hints row below the status bar"),
+      messages_density: z.string().optional().describe("Cycle message density between comfortable and compact"),
+      toast_expand: z.string().optional().describe("Expand the newest truncated notification"),
+      permission_list: z.string().optional().describe("Review and revoke permissions allowed for the session"),
+      file_edited: z.string().optional().describe("List files edited in the session"),
+      file_open_edited: z.string().optional().describe("Open all files edited in the session as tabs"),
+      log_filter: z.string().optional().describe("Choose which event types are forwarded to the debug log"),
+      event_inspector: z.string().optional().describe("Toggle the live event inspector, requires --debug"),
+      debug_clear_caches: z.string().optional().describe("Clear cached message renders and completions"),
+      theme_background: z.string().optional().describe("Switch between a light and dark terminal background"),
+      theme_reload: z.string().optional().describe("Reload themes from disk"),
+      layout_padding: z.string().optional().describe("Cycle the padding around the main content"),
+      messages_tool_output: z.string().optional().describe("Open the full output of the tool call in view"),
+      messages_last_error: z.string().optional().describe("Scroll to the most recent error in the session"),
+      focus_auto: z.string().optional().describe("Toggle focusing the editor when a session loads"),
+      messages_copy_tool_input: z
+        .string()
+        .optional()
+        .describe("Copy the input arguments of the latest tool call as JSON"),
+      messages_copy_code: z.string().optional().describe("Copy the code blocks of the message in view"),
+      modal_cycle: z.string().optional().default("ctrl+o").describe("Bring the next open dialog to the front"),
+      modal_close_all: z.string().optional().default("ctrl+q").describe("Close every open dialog"),
       app_exit: z.string().optional().default("ctrl+c,<leader>q").describe("Exit the application"),
     })
     .strict()
@@ -149,7 +225,10 @@ export namespace Config {
   export const Info = z
     .object({
       $schema: z.string().optional().describe("JSON schema reference for configuration validation"),
-      theme: z.string().optional().describe("Theme name to use for the interface"),
+      theme: z
+        .string()
+        .optional()
+        .describe("Theme name to use for the interface, or auto to follow the terminal background"),
       keybinds: Keybinds.optional().describe("Custom keybind configurations"),
       share: z
         .enum(["manual", "auto", "disabled"])
@@ -162,6 +241,10 @@ export namespace Config {
         .optional()
         .describe("@deprecated Use 'share' field instead. Share newly created sessions automatically"),
       autoupdate: z.boolean().optional().describe("Automatically update to the latest version"),
+      permission_prompt: z
+        .boolean()
+        .optional()
+        .describe("Ask before tools edit or write files, answered from the TUI"),
       disabled_providers: z.array(z.string()).optional().describe("Disable providers that are loaded automatically"),
       model: z.string().describe("Model to use in the format of provider/model, eg anthropic/claude-2").optional(),
       defaultModel: z.string().describe("Default model from server config in the format of provider/model").optional(),
@@ -195,6 +278,187 @@ export namespace Config {
       mcp: z.record(z.string(), Mcp).optional().describe("MCP (Model Context Protocol) server configurations"),
       instructions: z.array(z.string()).optional().describe("Additional instruction files or patterns to include"),
       layout: Layout.optional().describe("@deprecated Always uses stretch layout."),
+      tui: z
+        .object({
+          diff_line_numbers: z
+            .enum(["adaptive", "fixed", "none"])
+            .optional()
+            .describe("Line numbers in diffs: sized to the largest line number, a fixed width, or hidden"),
+          diff_preset: z
+            .enum(["theme", "github", "high-contrast", "colorblind"])
+            .optional()
+            .describe("Diff color scheme, independent of the UI theme"),
+          diff_symbols: z
+            .boolean()
+            .optional()
+            .describe("Mark changed text in diffs with underline and strikethrough so they read without relying on color"),
+          diff_width: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe(
+              "Pin diffs to this many columns, centered, instead of filling the terminal. Side-by-side diffs use this width for each side",
+            ),
+          editor_auto_pair: z
+            .boolean()
+            .optional()
+            .describe("Close brackets and quotes typed in the editor, defaults to false"),
+          editor_char_limit: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Maximum number of characters the editor accepts, further input is blocked"),
+          editor_char_warning: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Show the character count in a warning color once the editor holds this many characters"),
+          editor_max_height: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Maximum number of lines the editor grows to before scrolling internally"),
+          empty_enter: z
+            .enum(["ignore", "newline"])
+            .optional()
+            .describe("What submitting an empty editor does: nothing, or start a new line. Defaults to ignore"),
+          paste_long: z
+            .enum(["attach", "ask", "inline"])
+            .optional()
+            .describe(
+              "What pasting long text does: store it as a single attachment, ask which to do, or insert it into the editor. Defaults to attach",
+            ),
+          paste_long_lines: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Pasted text with more lines than this, or over 150 characters, counts as long. Defaults to 3"),
+          auto_attach_file: z
+            .boolean()
+            .optional()
+            .describe("Attach the file open in the viewer to each message that does not already mention it"),
+          git_context: z
+            .boolean()
+            .optional()
+            .describe("Attach the git status and diff of the working tree to the first message of each session"),
+          languages: z
+            .record(z.string(), z.string())
+            .optional()
+            .describe(
+              "Map file extensions or code fence languages to syntax highlighting languages, eg { \"h\": \"cpp\" }",
+            ),
+          busy_indicator: z
+            .enum(["spinner", "text"])
+            .optional()
+            .describe("Show activity in the status bar as an animated spinner or static text"),
+          change_gutter: z
+            .boolean()
+            .optional()
+            .describe(
+              "Mark added, modified and removed lines in a gutter when showing a modified file's contents instead of its diff, defaults to true",
+            ),
+          confirm_prompt_tokens: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Ask for confirmation before sending prompts estimated above this many tokens"),
+          confirm_share: z
+            .boolean()
+            .optional()
+            .describe("Explain what sharing exposes and ask before sharing a session, defaults to true"),
+          cursor_blink: z.boolean().optional().describe("Blink the editor cursor, defaults to true"),
+          cursor_shape: z.enum(["block", "bar", "underline"]).optional().describe("Editor cursor shape"),
+          logo: z.string().optional().describe("Custom text or ASCII art shown in place of the logo on the home screen"),
+          snippets: z
+            .record(z.string(), z.string())
+            .optional()
+            .describe(
+              "Named prompt snippets, inserted by typing : followed by the name. Tab moves between ${1:default} placeholders, ending at $0",
+            ),
+          spinner: z
+            .enum(["dots", "line", "bounce"])
+            .optional()
+            .describe("Spinner style used for activity indicators"),
+          tagline: z.string().optional().describe("Text shown below the logo on the home screen"),
+          toast_max_width: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Maximum notification width in columns, defaults to a third of the screen"),
+          toast_max_lines: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Message lines a notification shows before truncating, defaults to 6"),
+          toast_max_stack: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Notifications shown at once, older ones are counted below the newest, defaults to 3"),
+          tool_output_max_lines: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Lines of tool output shown before truncating, defaults to 10"),
+          tool_output_ansi: z
+            .boolean()
+            .optional()
+            .describe("Render colors in tool output, such as from test runners, instead of stripping them"),
+          update_restart: z
+            .enum(["prompt", "silent"])
+            .optional()
+            .describe("When an update is installed, offer to restart into it or only show a notification, defaults to silent"),
+          version_mismatch: z
+            .enum(["banner", "toast", "ignore"])
+            .optional()
+            .describe("How to warn when the server runs a different version than the TUI, defaults to banner"),
+          reduced_motion: z
+            .boolean()
+            .optional()
+            .describe("Disable cursor blink and spinners, showing static indicators instead"),
+          render_concurrency: z
+            .number()
+            .int()
+            .positive()
+            .optional()
+            .describe("Maximum number of diff lines rendered in parallel, defaults to the number of CPUs"),
+          render_throttle: z
+            .number()
+            .int()
+            .nonnegative()
+            .optional()
+            .describe("Minimum milliseconds between re-renders of a streaming message, for slow terminals. 0 disables"),
+          log_exclude: z
+            .array(z.string())
+            .optional()
+            .describe("Event types or log messages not forwarded to the debug log, eg message.part.updated"),
+          redact: z
+            .array(z.string())
+            .optional()
+            .describe("Additional regex patterns to redact from logs and exported conversations"),
+          save_path: z
+            .string()
+            .optional()
+            .describe(
+              "Path conversations are saved to, relative to the project root. {id} is replaced with the session ID",
+            ),
+          scroll_on_focus: z
+            .boolean()
+            .optional()
+            .describe("Scroll to the latest message when the messages regain focus"),
+        })
+        .optional()
+        .describe("TUI specific settings"),
       experimental: z
         .object({
           hook: z
@@ -2,6 +2,7 @@ import { App } from "../app/app"
 import { z } from "zod"
 import { Bus } from "../bus"
 import { Log } from "../util/log"
+import { Config } from "../config/config"
 
 export namespace Permission {
   const log = Log.create({ service: "permission" })
@@ -58,13 +59,16 @@ export namespace Permission {
     },
   )
 
-  export function ask(input: {
+  // ask waits for the user to answer the request when permission_prompt is
+  // enabled, and allows it straight away otherwise
+  export async function ask(input: {
     id: Info["id"]
     sessionID: Info["sessionID"]
     title: Info["title"]
     metadata: Info["metadata"]
   }) {
-    return
+    const cfg = await Config.get()
+    if (!cfg.permission_prompt) return
     const { pending, approved } = state()
     log.info("asking", {
       sessionID: input.sessionID,
@@ -93,17 +97,11 @@ export namespace Permission {
         resolve,
         reject,
       }
-      setTimeout(() => {
-        respond({
-          sessionID: input.sessionID,
-          permissionID: input.id,
-          response: "always",
-        })
-      }, 1000)
       Bus.publish(Event.Updated, info)
     })
   }
 
+  // respond answers a pending request, returning false when none matched
   export function respond(input: {
     sessionID: Info["sessionID"]
     permissionID: Info["id"]
@@ -112,17 +110,18 @@ export namespace Permission {
     log.info("response", input)
     const { pending, approved } = state()
     const match = pending[input.sessionID]?.[input.permissionID]
-    if (!match) return
+    if (!match) return false
     delete pending[input.sessionID][input.permissionID]
     if (input.response === "reject") {
       match.reject(new RejectedError(input.sessionID, input.permissionID))
-      return
+      return true
     }
     match.resolve()
     if (input.response === "always") {
       approved[input.sessionID] = approved[input.sessionID] || {}
       approved[input.sessionID][input.permissionID] = match.info
     }
+    return true
   }
 
   export class RejectedError extends Error {
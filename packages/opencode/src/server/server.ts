@@ -8,6 +8,7 @@ import { resolver, validator as zValidator } from "hono-openapi/zod"
 import { z } from "zod"
 import { Provider } from "../provider/provider"
 import { App } from "../app/app"
+import { Permission } from "../permission"
 import { mapValues } from "remeda"
 import { NamedError } from "../util/error"
 import { ModelsDev } from "../provider/models"
@@ -306,6 +307,54 @@ export namespace Server {
           return c.json(Session.abort(c.req.valid("param").id))
         },
       )
+      .post(
+        "/session/:id/permissions/:permissionID",
+        describeRoute({
+          description: "Respond to a permission request",
+          responses: {
+            200: {
+              description: "Permission processed successfully",
+              content: {
+                "application/json": {
+                  schema: resolver(z.boolean()),
+                },
+              },
+            },
+            404: {
+              description: "No pending permission request matched",
+              content: {
+                "application/json": {
+                  schema: resolver(z.boolean()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "param",
+          z.object({
+            id: z.string(),
+            permissionID: z.string(),
+          }),
+        ),
+        zValidator(
+          "json",
+          z.object({
+            response: z.enum(["once", "always", "reject"]),
+          }),
+        ),
+        async (c) => {
+          const params = c.req.valid("param")
+          const body = c.req.valid("json")
+          const matched = Permission.respond({
+            sessionID: params.id,
+            permissionID: params.permissionID,
+            response: body.response,
+          })
+          if (!matched) return c.json(false, 404)
+          return c.json(true)
+        },
+      )
       .post(
         "/session/:id/share",
         describeRoute({
@@ -397,6 +446,35 @@ export namespace Server {
           return c.json(true)
         },
       )
+      .post(
+        "/session/:id/note",
+        describeRoute({
+          description: "Add a note to a session without prompting the model",
+          responses: {
+            200: {
+              description: "Created note message",
+              content: {
+                "application/json": {
+                  schema: resolver(MessageV2.User),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "param",
+          z.object({
+            id: z.string().openapi({ description: "Session ID" }),
+          }),
+        ),
+        zValidator("json", Session.NoteInput.omit({ sessionID: true })),
+        async (c) => {
+          const sessionID = c.req.valid("param").id
+          const body = c.req.valid("json")
+          const msg = await Session.note({ ...body, sessionID })
+          return c.json(msg)
+        },
+      )
       .get(
         "/session/:id/message",
         describeRoute({
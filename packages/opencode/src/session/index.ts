@@ -319,6 +319,36 @@ export namespace Session {
     return part
   }
 
+  export const NoteInput = z.object({
+    sessionID: Identifier.schema("session"),
+    text: z.string().min(1),
+  })
+
+  // Notes are user messages flagged as notes with a single synthetic text
+  // part, written without prompting the model and left out of its context so
+  // they only mark a point in the conversation
+  export async function note(input: z.infer<typeof NoteInput>) {
+    const msg: MessageV2.User = {
+      id: Identifier.ascending("message"),
+      role: "user",
+      sessionID: input.sessionID,
+      time: {
+        created: Date.now(),
+      },
+      note: true,
+    }
+    await updateMessage(msg)
+    await updatePart({
+      id: Identifier.ascending("part"),
+      messageID: msg.id,
+      sessionID: input.sessionID,
+      type: "text",
+      text: input.text,
+      synthetic: true,
+    })
+    return msg
+  }
+
   export const ChatInput = z.object({
     sessionID: Identifier.schema("session"),
     messageID: Identifier.schema("message").optional(),
@@ -544,7 +574,7 @@ export namespace Session {
         synthetic: true,
       })
 
-    if (msgs.length === 0 && !session.parentID) {
+    if (!msgs.some((msg) => !(msg.info.role === "user" && msg.info.note)) && !session.parentID) {
       const small = (await Provider.getSmallModel(input.providerID)) ?? model
       generateText({
         maxOutputTokens: small.info.reasoning ? 1024 : 20,
@@ -219,6 +219,7 @@ export namespace MessageV2 {
     time: z.object({
       created: z.number(),
     }),
+    note: z.boolean().optional(),
   }).openapi({
     ref: "UserMessage",
   })
@@ -449,6 +450,8 @@ export namespace MessageV2 {
 
     for (const msg of input) {
       if (msg.parts.length === 0) continue
+      // notes only mark a point in the conversation for the user
+      if (msg.info.role === "user" && msg.info.note) continue
 
       if (msg.info.role === "user") {
         result.push({
@@ -28,6 +28,7 @@ export const TaskTool = Tool.define({
       ctx.metadata({
         title: params.description,
         metadata: {
+          sessionId: session.id,
           summary: Object.values(parts).sort((a, b) => a.id?.localeCompare(b.id)),
         },
       })
@@ -57,6 +58,7 @@ export const TaskTool = Tool.define({
     return {
       title: params.description,
       metadata: {
+        sessionId: session.id,
         summary: result.parts.filter((x) => x.type === "tool"),
       },
       output: result.parts.findLast((x) => x.type === "text")!.text,
@@ -2,11 +2,14 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"log/slog"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/sst/opencode-sdk-go"
 	"github.com/sst/opencode/internal/clipboard"
 	"github.com/sst/opencode/internal/commands"
+	"github.com/sst/opencode/internal/components/diff"
 	"github.com/sst/opencode/internal/components/toast"
 	"github.com/sst/opencode/internal/id"
 	"github.com/sst/opencode/internal/styles"
@@ -31,6 +35,7 @@ type App struct {
 	Modes            []opencode.Mode
 	Providers        []opencode.Provider
 	Version          string
+	ServerURL        string
 	StatePath        string
 	Config           *opencode.Config
 	Client           *opencode.Client
@@ -46,7 +51,37 @@ type App struct {
 	InitialPrompt    *string
 	IntitialMode     *string
 	compactCancel    context.CancelFunc
+	pendingSend      *pendingSend
+	lastRequest      *capturedRequest
+	requestMu        sync.Mutex
 	IsLeaderSequence bool
+	// ThemePinned is set when the theme comes from config, env or flag, so
+	// terminal background detection doesn't override it
+	ThemePinned bool
+	// Debug enables developer tools such as the event inspector
+	Debug bool
+	// permissionGrants holds the permission titles allowed for the rest of
+	// each session, keyed by session ID
+	permissionGrants map[string]map[string]bool
+	// editedFiles holds the files edited in each session, keyed by session ID
+	editedFiles map[string][]EditedFile
+	// busySessions holds the sessions with a response in progress, tracked
+	// from message events across all sessions
+	busySessions map[string]bool
+	// RestartRequested is set when the TUI quits to restart into an update
+	RestartRequested bool
+}
+
+// RestartExitCode is the exit status asking the CLI that launched the TUI to
+// start it again, running the newly installed version
+const RestartExitCode = 75
+
+// pendingSend tracks a prompt that has been sent but not yet acknowledged by
+// the server, so it can still be withdrawn
+type pendingSend struct {
+	messageID string
+	prompt    Prompt
+	cancel    context.CancelFunc
 }
 
 type SessionCreatedMsg = struct {
@@ -61,6 +96,7 @@ type ModelSelectedMsg struct {
 type SessionClearedMsg struct{}
 type CompactSessionMsg struct{}
 type SendPrompt = Prompt
+type PromptCanceledMsg struct{}
 type SetEditorContentMsg struct {
 	Text string
 }
@@ -77,6 +113,8 @@ func New(
 	initialModel *string,
 	initialPrompt *string,
 	initialMode *string,
+	initialTheme *string,
+	debug bool,
 ) (*App, error) {
 	util.RootPath = appInfo.Path.Root
 	util.CwdPath = appInfo.Path.Cwd
@@ -86,6 +124,17 @@ func New(
 		return nil, err
 	}
 
+	util.SetLanguages(configInfo.Tui.Languages)
+	diff.SetPreset(string(configInfo.Tui.DiffPreset))
+	diff.SetLineNumbers(string(configInfo.Tui.DiffLineNumbers))
+	diff.SetSymbols(configInfo.Tui.DiffSymbols)
+	diff.SetWidth(int(configInfo.Tui.DiffWidth))
+	util.SetMaxConcurrency(int(configInfo.Tui.RenderConcurrency))
+	if err := util.AddRedactPatterns(configInfo.Tui.Redact); err != nil {
+		slog.Warn("Ignoring redact pattern", "error", err)
+	}
+	util.SetLogExcluded(configInfo.Tui.LogExclude)
+
 	if configInfo.Keybinds.Leader == "" {
 		configInfo.Keybinds.Leader = "ctrl+x"
 	}
@@ -100,6 +149,10 @@ func New(
 	if appState.ModeModel == nil {
 		appState.ModeModel = make(map[string]ModeModel)
 	}
+	util.ShortPaths = appState.ShortPaths
+	if appState.ModeProvider == nil {
+		appState.ModeProvider = make(map[string]string)
+	}
 
 	if configInfo.Theme != "" {
 		appState.Theme = configInfo.Theme
@@ -110,6 +163,19 @@ func New(
 		appState.Theme = themeEnv
 	}
 
+	if initialTheme != nil && *initialTheme != "" {
+		appState.Theme = *initialTheme
+	}
+
+	// a theme set by config, env or flag is kept regardless of the terminal
+	// background unless it is "auto", which follows the system theme
+	themePinned := configInfo.Theme != "" || themeEnv != "" ||
+		(initialTheme != nil && *initialTheme != "")
+	if appState.Theme == "auto" {
+		appState.Theme = "system"
+		themePinned = false
+	}
+
 	var modeIndex int
 	var mode *opencode.Mode
 	modeName := "build"
@@ -142,6 +208,11 @@ func New(
 		slog.Warn("Failed to load themes from directories", "error", err)
 	}
 
+	if appState.Background != "" {
+		isDark := appState.Background != "light"
+		styles.SetTerminalBackground(styles.DefaultBackground(isDark), isDark)
+	}
+
 	if appState.Theme != "" {
 		if appState.Theme == "system" && styles.Terminal != nil {
 			theme.UpdateSystemTheme(
@@ -158,6 +229,7 @@ func New(
 		Info:          appInfo,
 		Modes:         modes,
 		Version:       version,
+		ServerURL:     os.Getenv("OPENCODE_SERVER"),
 		StatePath:     appStatePath,
 		Config:        configInfo,
 		State:         appState,
@@ -170,6 +242,11 @@ func New(
 		InitialModel:  initialModel,
 		InitialPrompt: initialPrompt,
 		IntitialMode:  initialMode,
+		ThemePinned:   themePinned,
+		Debug:         debug,
+	}
+	if !debug {
+		delete(app.Commands, commands.EventInspectorCommand)
 	}
 
 	return app, nil
@@ -184,12 +261,22 @@ func (a *App) Key(commandName commands.CommandName) string {
 		Faint(true).
 		Render
 	command := a.Commands[commandName]
+	key, _ := a.KeyText(commandName)
+	return base(key) + muted(" "+command.Description)
+}
+
+// KeyText returns the first keybinding of a command as typed, including the
+// leader, or false when the command is unbound
+func (a *App) KeyText(commandName commands.CommandName) (string, bool) {
+	command, ok := a.Commands[commandName]
+	if !ok || len(command.Keybindings) == 0 {
+		return "", false
+	}
 	kb := command.Keybindings[0]
-	key := kb.Key
 	if kb.RequiresLeader {
-		key = a.Config.Keybinds.Leader + " " + kb.Key
+		return a.Config.Keybinds.Leader + " " + kb.Key, true
 	}
-	return base(key) + muted(" "+command.Description)
+	return kb.Key, true
 }
 
 func SetClipboard(text string) tea.Cmd {
@@ -399,6 +486,40 @@ func (a *App) IsBusy() bool {
 	return false
 }
 
+// Cwd returns the working directory of the session, as reported by its most
+// recent response, falling back to the directory the app was started in
+func (a *App) Cwd() string {
+	for i := len(a.Messages) - 1; i >= 0; i-- {
+		if assistant, ok := a.Messages[i].Info.(opencode.AssistantMessage); ok && assistant.Path.Cwd != "" {
+			return assistant.Path.Cwd
+		}
+	}
+	return a.Info.Path.Cwd
+}
+
+// ContextTokens returns the tokens currently used in the model context, as
+// reported by the most recent assistant message
+func (a *App) ContextTokens() float64 {
+	tokens := float64(0)
+	for _, message := range a.Messages {
+		if assistant, ok := message.Info.(opencode.AssistantMessage); ok {
+			usage := assistant.Tokens
+			if usage.Output > 0 {
+				if assistant.Summary {
+					tokens = usage.Output
+					continue
+				}
+				tokens = (usage.Input +
+					usage.Cache.Write +
+					usage.Cache.Read +
+					usage.Output +
+					usage.Reasoning)
+			}
+		}
+	}
+	return tokens
+}
+
 func (a *App) SaveState() tea.Cmd {
 	return func() tea.Msg {
 		err := SaveState(a.StatePath, a.State)
@@ -499,15 +620,28 @@ func (a *App) SendPrompt(ctx context.Context, prompt Prompt) (*App, tea.Cmd) {
 
 	a.Messages = append(a.Messages, message)
 
+	ctx, cancel := context.WithCancel(ctx)
+	a.pendingSend = &pendingSend{
+		messageID: messageID,
+		prompt:    prompt,
+		cancel:    cancel,
+	}
+
 	cmds = append(cmds, func() tea.Msg {
+		// release the context once the request ends, whether or not the
+		// prompt was withdrawn
+		defer cancel()
 		_, err := a.Client.Session.Chat(ctx, a.Session.ID, opencode.SessionChatParams{
 			ProviderID: opencode.F(a.Provider.ID),
 			ModelID:    opencode.F(a.Model.ID),
 			Mode:       opencode.F(a.Mode.Name),
 			MessageID:  opencode.F(messageID),
 			Parts:      opencode.F(message.ToSessionChatParams()),
-		})
+		}, a.captureRequest())
 		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
 			errormsg := fmt.Sprintf("failed to send message: %v", err)
 			slog.Error(errormsg)
 			return toast.NewErrorToast(errormsg)()
@@ -520,6 +654,30 @@ func (a *App) SendPrompt(ctx context.Context, prompt Prompt) (*App, tea.Cmd) {
 	return a, tea.Batch(cmds...)
 }
 
+// CancelPendingSend withdraws the last sent prompt if the server has not yet
+// acknowledged it, returning the prompt so it can be restored to the editor
+func (a *App) CancelPendingSend() *Prompt {
+	if a.pendingSend == nil {
+		return nil
+	}
+	pending := a.pendingSend
+	a.pendingSend = nil
+	pending.cancel()
+	a.Messages = slices.DeleteFunc(a.Messages, func(m Message) bool {
+		casted, ok := m.Info.(opencode.UserMessage)
+		return ok && casted.ID == pending.messageID
+	})
+	return &pending.prompt
+}
+
+// AcknowledgeSend marks a pending prompt as accepted once the server reports
+// the message, after which it can only be interrupted
+func (a *App) AcknowledgeSend(messageID string) {
+	if a.pendingSend != nil && a.pendingSend.messageID == messageID {
+		a.pendingSend = nil
+	}
+}
+
 func (a *App) Cancel(ctx context.Context, sessionID string) error {
 	// Cancel any running compact operation
 	if a.compactCancel != nil {
@@ -527,14 +685,20 @@ func (a *App) Cancel(ctx context.Context, sessionID string) error {
 		a.compactCancel = nil
 	}
 
-	_, err := a.Client.Session.Abort(ctx, sessionID)
-	if err != nil {
+	if err := a.Abort(ctx, sessionID); err != nil {
 		slog.Error("Failed to cancel session", "error", err)
 		return err
 	}
 	return nil
 }
 
+// Abort stops the session's response on the server. It leaves local state
+// alone, so it is safe to call from a background command.
+func (a *App) Abort(ctx context.Context, sessionID string) error {
+	_, err := a.Client.Session.Abort(ctx, sessionID)
+	return err
+}
+
 func (a *App) ListSessions(ctx context.Context) ([]opencode.Session, error) {
 	response, err := a.Client.Session.List(ctx)
 	if err != nil {
@@ -550,6 +714,20 @@ func (a *App) ListSessions(ctx context.Context) ([]opencode.Session, error) {
 	return sessions, nil
 }
 
+// FindSession looks up a session by ID among the listed sessions
+func (a *App) FindSession(ctx context.Context, sessionID string) (*opencode.Session, error) {
+	sessions, err := a.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			return &session, nil
+		}
+	}
+	return nil, fmt.Errorf("session %s not found", sessionID)
+}
+
 func (a *App) DeleteSession(ctx context.Context, sessionID string) error {
 	_, err := a.Client.Session.Delete(ctx, sessionID)
 	if err != nil {
@@ -597,3 +775,13 @@ func (a *App) ListProviders(ctx context.Context) ([]opencode.Provider, error) {
 // func (a *App) loadCustomKeybinds() {
 //
 // }
+
+// ReloadThemes rescans the built-in and custom theme directories so edited
+// themes apply without restarting
+func (a *App) ReloadThemes() error {
+	return theme.ReloadThemesFromDirectories(
+		a.Info.Path.Config,
+		a.Info.Path.Root,
+		a.Info.Path.Cwd,
+	)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// authHints suggest how to fix authentication for providers that don't sign in
+// with an API key
+var authHints = map[string]string{
+	"amazon-bedrock": "Configure AWS credentials with AWS_PROFILE, AWS_ACCESS_KEY_ID or AWS_BEARER_TOKEN_BEDROCK.",
+	"github-copilot": "Run `autoprovisioner auth login` and choose GitHub Copilot to sign in again.",
+	"zerosync":       "Run `autoprovisioner auth login` and choose zerosync to sign in again.",
+}
+
+// AuthHint suggests how to fix a failed authentication with the given
+// provider, naming the login choice and environment variables it accepts
+func (a *App) AuthHint(providerID string) string {
+	if hint, ok := authHints[providerID]; ok {
+		return hint
+	}
+	for _, provider := range a.Providers {
+		if provider.ID != providerID {
+			continue
+		}
+		hint := fmt.Sprintf("Run `autoprovisioner auth login` and choose %s", provider.Name)
+		if len(provider.Env) > 0 {
+			hint += ", or set " + strings.Join(provider.Env, " or ")
+		}
+		return hint + "."
+	}
+	if providerID == "" {
+		return "Run `autoprovisioner auth login` to add a credential."
+	}
+	return fmt.Sprintf("Run `autoprovisioner auth login` and choose Other, entering %s, to add a credential.", providerID)
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestAuthHint(t *testing.T) {
+	a := &App{Providers: []opencode.Provider{
+		{ID: "openai", Name: "OpenAI", Env: []string{"OPENAI_API_KEY"}},
+		{ID: "local", Name: "Local"},
+	}}
+	cases := []struct {
+		providerID string
+		expected   string
+	}{
+		{"openai", "Run `autoprovisioner auth login` and choose OpenAI, or set OPENAI_API_KEY."},
+		{"local", "Run `autoprovisioner auth login` and choose Local."},
+		{"github-copilot", "Run `autoprovisioner auth login` and choose GitHub Copilot to sign in again."},
+		{"custom", "Run `autoprovisioner auth login` and choose Other, entering custom, to add a credential."},
+	}
+	for _, tc := range cases {
+		if got := a.AuthHint(tc.providerID); got != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.providerID, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"maps"
+	"slices"
+)
+
+// SetSessionBusy records whether a session has a response in progress, so
+// sessions running in the background can be found again
+func (a *App) SetSessionBusy(sessionID string, busy bool) {
+	if !busy {
+		delete(a.busySessions, sessionID)
+		return
+	}
+	if a.busySessions == nil {
+		a.busySessions = make(map[string]bool)
+	}
+	a.busySessions[sessionID] = true
+}
+
+// BusySessions returns the IDs of the sessions with a response in progress,
+// including the current one
+func (a *App) BusySessions() []string {
+	busy := maps.Clone(a.busySessions)
+	if a.Session != nil && a.Session.ID != "" && a.IsBusy() {
+		if busy == nil {
+			busy = make(map[string]bool)
+		}
+		busy[a.Session.ID] = true
+	}
+	return slices.Sorted(maps.Keys(busy))
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestBusySessions(t *testing.T) {
+	a := &App{Session: &opencode.Session{ID: "ses_current"}}
+	if got := a.BusySessions(); len(got) != 0 {
+		t.Fatalf("expected no busy sessions, got %v", got)
+	}
+
+	a.SetSessionBusy("ses_b", true)
+	a.SetSessionBusy("ses_a", true)
+	a.SetSessionBusy("ses_c", true)
+	a.SetSessionBusy("ses_c", false)
+	a.Messages = []Message{{Info: opencode.AssistantMessage{}}}
+
+	want := []string{"ses_a", "ses_b", "ses_current"}
+	if got := a.BusySessions(); !slices.Equal(got, want) {
+		t.Errorf("BusySessions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sst/opencode-sdk-go/option"
+	"github.com/sst/opencode/internal/util"
+)
+
+// capturedRequest is a copy of an outgoing request kept for debugging
+type capturedRequest struct {
+	method string
+	url    string
+	header http.Header
+	body   []byte
+}
+
+// sensitiveHeaders are left out of generated curl commands
+var sensitiveHeaders = []string{"authorization", "cookie", "proxy-authorization"}
+
+// captureRequest returns a request option that records the request it is
+// applied to, so it can later be reproduced with LastRequestAsCurl
+func (a *App) captureRequest() option.RequestOption {
+	return option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		captured := &capturedRequest{
+			method: req.Method,
+			url:    req.URL.String(),
+			header: req.Header.Clone(),
+		}
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				captured.body, _ = io.ReadAll(body)
+				body.Close()
+			}
+		}
+		a.requestMu.Lock()
+		a.lastRequest = captured
+		a.requestMu.Unlock()
+		return next(req)
+	})
+}
+
+// LastRequestAsCurl formats the last chat request as a curl command, leaving
+// out headers that may carry credentials. It reports false if no request
+// has been sent yet.
+func (a *App) LastRequestAsCurl() (string, bool) {
+	a.requestMu.Lock()
+	req := a.lastRequest
+	a.requestMu.Unlock()
+	if req == nil {
+		return "", false
+	}
+
+	names := make([]string, 0, len(req.header))
+	for name := range req.header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	lines := []string{fmt.Sprintf("curl -X %s %s", req.method, shellQuote(req.url))}
+	for _, name := range names {
+		if isSensitiveHeader(name) {
+			continue
+		}
+		for _, value := range req.header[name] {
+			lines = append(lines, "-H "+shellQuote(fmt.Sprintf("%s: %s", name, value)))
+		}
+	}
+	if len(req.body) > 0 {
+		lines = append(lines, "--data-raw "+shellQuote(util.Redact(string(req.body))))
+	}
+	return strings.Join(lines, " \\\n  "), true
+}
+
+func isSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	if slices.Contains(sensitiveHeaders, name) {
+		return true
+	}
+	return strings.Contains(name, "key") ||
+		strings.Contains(name, "token") ||
+		strings.Contains(name, "secret")
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,28 @@
+package app
+
+import "slices"
+
+// EditedFile is a file the agent edited during a session and how many times
+type EditedFile struct {
+	Path  string
+	Edits int
+}
+
+// RecordEdit counts an edit to the file in the session, keeping files in the
+// order they were first edited
+func (a *App) RecordEdit(sessionID, path string) {
+	if a.editedFiles == nil {
+		a.editedFiles = make(map[string][]EditedFile)
+	}
+	files := a.editedFiles[sessionID]
+	if i := slices.IndexFunc(files, func(f EditedFile) bool { return f.Path == path }); i >= 0 {
+		files[i].Edits++
+		return
+	}
+	a.editedFiles[sessionID] = append(files, EditedFile{Path: path, Edits: 1})
+}
+
+// EditedFiles returns the files edited in the session
+func (a *App) EditedFiles(sessionID string) []EditedFile {
+	return slices.Clone(a.editedFiles[sessionID])
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sst/opencode/internal/attachment"
+)
+
+// FileAttachment builds a reference to a project file, which the server reads
+// when the prompt is sent. Paths inside the project are shown relative to it.
+func (a *App) FileAttachment(path string) *attachment.Attachment {
+	absolutePath := path
+	if !filepath.IsAbs(path) {
+		absolutePath = filepath.Join(a.Info.Path.Cwd, path)
+	}
+	display := path
+	if rel, err := filepath.Rel(a.Info.Path.Cwd, absolutePath); err == nil && !strings.HasPrefix(rel, "..") {
+		display = rel
+	}
+
+	att := attachment.NewAttachment()
+	att.Type = "file"
+	att.Display = "@" + display
+	att.URL = fmt.Sprintf("file://./%s", url.PathEscape(display))
+	att.Filename = display
+	att.MediaType = "text/plain"
+	att.Source = &attachment.FileSource{
+		Path: absolutePath,
+		Mime: att.MediaType,
+	}
+	return att
+}
+
+// HasFile reports whether the prompt already attaches the file at the
+// absolute path
+func (p Prompt) HasFile(absolutePath string) bool {
+	return slices.ContainsFunc(p.Attachments, func(att *attachment.Attachment) bool {
+		source, ok := att.GetFileSource()
+		return ok && source.Path == absolutePath
+	})
+}
+
+// WithViewedFile appends a reference to the file open in the viewer to the end
+// of the prompt, unless the prompt already attaches it
+func (a *App) WithViewedFile(prompt Prompt, path string) Prompt {
+	att := a.FileAttachment(path)
+	source, _ := att.GetFileSource()
+	if prompt.HasFile(source.Path) {
+		return prompt
+	}
+	att.StartIndex = len(prompt.Text)
+	att.EndIndex = len(prompt.Text)
+	prompt.Attachments = append(slices.Clone(prompt.Attachments), att)
+	return prompt
+}
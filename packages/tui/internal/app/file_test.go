@@ -0,0 +1,32 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestWithViewedFile(t *testing.T) {
+	a := &App{Info: opencode.App{Path: opencode.AppPath{Cwd: "/project"}}}
+
+	prompt := a.WithViewedFile(Prompt{Text: "explain this"}, "/project/src/main.go")
+	if len(prompt.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %d", len(prompt.Attachments))
+	}
+	att := prompt.Attachments[0]
+	if att.Display != "@src/main.go" || att.StartIndex != len("explain this") {
+		t.Errorf("unexpected attachment %+v", att)
+	}
+	if source, ok := att.GetFileSource(); !ok || source.Path != "/project/src/main.go" {
+		t.Errorf("unexpected source %+v", att.Source)
+	}
+
+	again := a.WithViewedFile(prompt, "src/main.go")
+	if len(again.Attachments) != 1 {
+		t.Errorf("expected the file not to be attached twice, got %d", len(again.Attachments))
+	}
+
+	if typed := prompt.Typed(); typed.Text != "explain this" || len(typed.Attachments) != 0 {
+		t.Errorf("expected the viewed file to be left out of the typed prompt, got %+v", typed)
+	}
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sst/opencode/internal/attachment"
+)
+
+// maxGitContextChars caps how much of the working tree diff is attached
+const maxGitContextChars = 16 * 1024
+
+// maxGitStatusChars caps how much of the status is attached, so a tree with
+// many untracked files cannot crowd out the diff
+const maxGitStatusChars = 4 * 1024
+
+// gitTimeout bounds each git command so a huge repository cannot stall a send
+const gitTimeout = 3 * time.Second
+
+// errNotGitRepo is returned when the project is not tracked by git
+var errNotGitRepo = errors.New("project is not a git repository")
+
+// GitContext builds an attachment with the git status and diff of the working
+// tree, so the agent sees uncommitted changes. It returns nil when the working
+// tree is clean.
+func (a *App) GitContext(ctx context.Context) (*attachment.Attachment, error) {
+	if !a.Info.Git {
+		return nil, errNotGitRepo
+	}
+
+	status, err := a.git(ctx, "status", "--short")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(status) == "" {
+		return nil, nil
+	}
+	status = truncateGitOutput(status, "status", maxGitStatusChars)
+	// compare against HEAD to include staged changes, falling back for
+	// repositories without commits
+	diff, err := a.git(ctx, "diff", "HEAD")
+	if err != nil {
+		if diff, err = a.git(ctx, "diff"); err != nil {
+			return nil, err
+		}
+	}
+	diff = truncateGitOutput(diff, "diff", maxGitContextChars)
+
+	att := attachment.NewAttachment()
+	att.Type = "text"
+	att.Display = "@git"
+	att.URL = attachment.GitURL
+	att.Filename = "git"
+	att.MediaType = attachment.GitMediaType
+	att.Source = &attachment.TextSource{
+		Value: fmt.Sprintf(
+			"<git-status>\n%s\n</git-status>\n<git-diff>\n%s\n</git-diff>",
+			strings.TrimRight(status, "\n"),
+			strings.TrimRight(diff, "\n"),
+		),
+	}
+	return att, nil
+}
+
+// git runs a git command in the project directory and returns its output
+func (a *App) git(ctx context.Context, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = a.Info.Path.Cwd
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+// truncateGitOutput keeps whole lines of the named git output up to limit
+// characters, noting how much was left out
+func truncateGitOutput(output, name string, limit int) string {
+	if len(output) <= limit {
+		return output
+	}
+	cut := strings.LastIndex(output[:limit], "\n") + 1
+	return output[:cut] + fmt.Sprintf("[%s truncated, %d more characters]\n", name, len(output)-cut)
+}
+
+// HasGitContext reports whether the attachments already include git context
+func HasGitContext(attachments []*attachment.Attachment) bool {
+	for _, att := range attachments {
+		if att.MediaType == attachment.GitMediaType {
+			return true
+		}
+	}
+	return false
+}
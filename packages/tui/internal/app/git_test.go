@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/attachment"
+)
+
+func TestTruncateGitOutput(t *testing.T) {
+	diff := "line one\nline two\nline three\n"
+	if got := truncateGitOutput(diff, "diff", 100); got != diff {
+		t.Errorf("short diff was changed: %q", got)
+	}
+	want := "line one\n[diff truncated, 20 more characters]\n"
+	if got := truncateGitOutput(diff, "diff", 14); got != want {
+		t.Errorf("truncateGitOutput() = %q, want %q", got, want)
+	}
+	want = "line one\n[status truncated, 20 more characters]\n"
+	if got := truncateGitOutput(diff, "status", 14); got != want {
+		t.Errorf("truncateGitOutput() = %q, want %q", got, want)
+	}
+}
+
+func TestGitContext(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q")
+	write("main.go", "package main\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "init")
+
+	a := &App{Info: opencode.App{Git: true, Path: opencode.AppPath{Cwd: dir}}}
+	att, err := a.GitContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if att != nil {
+		t.Fatal("expected no attachment for a clean working tree")
+	}
+
+	write("main.go", "package main\n\nfunc main() {}\n")
+	att, err = a.GitContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, ok := att.GetTextSource()
+	if !ok || att.MediaType != attachment.GitMediaType {
+		t.Fatalf("unexpected attachment %+v", att)
+	}
+	for _, want := range []string{"M main.go", "+func main() {}"} {
+		if !strings.Contains(source.Value, want) {
+			t.Errorf("git context is missing %q:\n%s", want, source.Value)
+		}
+	}
+	if !HasGitContext([]*attachment.Attachment{att}) {
+		t.Error("expected HasGitContext to find the attachment")
+	}
+
+	a.Info.Git = false
+	if _, err := a.GitContext(context.Background()); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
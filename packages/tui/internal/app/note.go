@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/components/toast"
+)
+
+// NoteSeparator is the text of a note that only separates the conversation,
+// added when there is no text to label it with
+const NoteSeparator = "---"
+
+// Note returns the text of a note, a user message the server stored without
+// prompting the model and flagged so it stays out of the model's context
+func (m Message) Note() (string, bool) {
+	if user, ok := m.Info.(opencode.UserMessage); !ok || !user.Note || len(m.Parts) != 1 {
+		return "", false
+	}
+	text, ok := m.Parts[0].(opencode.TextPart)
+	if !ok || !text.Synthetic {
+		return "", false
+	}
+	return text.Text, true
+}
+
+// AddNote stores a note in the loaded session. It reaches the conversation
+// through the message events like any other message, so it survives a
+// resync.
+func (a *App) AddNote(ctx context.Context, text string) tea.Cmd {
+	if text == "" {
+		text = NoteSeparator
+	}
+	sessionID := a.Session.ID
+	return func() tea.Msg {
+		_, err := a.Client.Session.Note(ctx, sessionID, opencode.SessionNoteParams{
+			Text: opencode.F(text),
+		})
+		if err != nil {
+			errormsg := fmt.Sprintf("failed to add note: %v", err)
+			slog.Error(errormsg)
+			return toast.NewErrorToast(errormsg)()
+		}
+		return nil
+	}
+}
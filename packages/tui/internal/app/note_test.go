@@ -0,0 +1,34 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestMessageNote(t *testing.T) {
+	user := opencode.UserMessage{ID: "msg", Role: opencode.UserMessageRoleUser, Note: true}
+	prompter := opencode.UserMessage{ID: "msg", Role: opencode.UserMessageRoleUser}
+	note := opencode.TextPart{Text: "deploy", Synthetic: true}
+	prompt := opencode.TextPart{Text: "read this"}
+
+	cases := []struct {
+		name    string
+		message Message
+		want    string
+		ok      bool
+	}{
+		{"note", Message{Info: user, Parts: []opencode.PartUnion{note}}, "deploy", true},
+		{"prompt", Message{Info: user, Parts: []opencode.PartUnion{prompt}}, "", false},
+		{"synthetic prompt", Message{Info: prompter, Parts: []opencode.PartUnion{note}}, "", false},
+		{"prompt with file contents", Message{Info: user, Parts: []opencode.PartUnion{note, prompt}}, "", false},
+		{"assistant", Message{Info: opencode.AssistantMessage{}, Parts: []opencode.PartUnion{note}}, "", false},
+		{"empty", Message{Info: user}, "", false},
+	}
+	for _, tc := range cases {
+		got, ok := tc.message.Note()
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%s: Note() = %q, %v, want %q, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
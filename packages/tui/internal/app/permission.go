@@ -0,0 +1,34 @@
+package app
+
+import (
+	"maps"
+	"slices"
+)
+
+// GrantPermission remembers that permission requests with the given title are
+// allowed for the rest of the session, so they no longer prompt
+func (a *App) GrantPermission(sessionID, title string) {
+	if a.permissionGrants == nil {
+		a.permissionGrants = make(map[string]map[string]bool)
+	}
+	if a.permissionGrants[sessionID] == nil {
+		a.permissionGrants[sessionID] = make(map[string]bool)
+	}
+	a.permissionGrants[sessionID][title] = true
+}
+
+// PermissionGranted reports whether requests with the title were allowed for
+// the session
+func (a *App) PermissionGranted(sessionID, title string) bool {
+	return a.permissionGrants[sessionID][title]
+}
+
+// RevokePermission forgets a grant, so requests with the title prompt again
+func (a *App) RevokePermission(sessionID, title string) {
+	delete(a.permissionGrants[sessionID], title)
+}
+
+// GrantedPermissions returns the titles allowed for the session, sorted
+func (a *App) GrantedPermissions(sessionID string) []string {
+	return slices.Sorted(maps.Keys(a.permissionGrants[sessionID]))
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"strings"
 	"time"
 
 	"github.com/sst/opencode-sdk-go"
@@ -13,6 +14,30 @@ type Prompt struct {
 	Attachments []*attachment.Attachment `toml:"attachments"`
 }
 
+// EstimateTokens roughly estimates the tokens the prompt will use, assuming
+// about four characters per token across the text and any pasted text
+func (p Prompt) EstimateTokens() int {
+	chars := len(p.Text)
+	for _, attachment := range p.Attachments {
+		if source, ok := attachment.GetTextSource(); ok {
+			chars += len(source.Value)
+		}
+	}
+	return (chars + 3) / 4
+}
+
+// Typed returns the prompt as the user typed it, leaving out the git context
+// and viewed file attached on send, which take up none of the text
+func (p Prompt) Typed() Prompt {
+	typed := Prompt{Text: p.Text}
+	for _, att := range p.Attachments {
+		if att.EndIndex > att.StartIndex {
+			typed.Attachments = append(typed.Attachments, att)
+		}
+	}
+	return typed
+}
+
 func (p Prompt) ToMessage(
 	messageID string,
 	sessionID string,
@@ -106,6 +131,17 @@ func (p Prompt) ToMessage(
 	}
 }
 
+// Text joins the text parts of the message, leaving out synthetic ones
+func (m Message) Text() string {
+	var texts []string
+	for _, part := range m.Parts {
+		if p, ok := part.(opencode.TextPart); ok && !p.Synthetic {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
 func (m Message) ToSessionChatParams() []opencode.SessionChatParamsPartUnion {
 	parts := []opencode.SessionChatParamsPartUnion{}
 	for _, part := range m.Parts {
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/attachment"
+)
+
+// maxSessionReferenceChars caps how much of a referenced session's
+// conversation is inlined, keeping the most recent messages
+const maxSessionReferenceChars = 16 * 1024
+
+// SessionReference builds an attachment that inlines the conversation of
+// another session into the prompt. It is a text attachment so it is sent like
+// pasted text, with the session media type and a session:// URL identifying
+// what it refers to.
+func (a *App) SessionReference(ctx context.Context, session opencode.Session) (*attachment.Attachment, error) {
+	messages, err := a.ListMessages(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []string{}
+	for _, message := range messages {
+		var role string
+		switch message.Info.(type) {
+		case opencode.UserMessage:
+			role = "User"
+		case opencode.AssistantMessage:
+			role = "Assistant"
+		default:
+			continue
+		}
+		for _, part := range message.Parts {
+			if text, ok := part.(opencode.TextPart); ok && !text.Synthetic && strings.TrimSpace(text.Text) != "" {
+				blocks = append(blocks, role+": "+strings.TrimSpace(text.Text))
+			}
+		}
+	}
+
+	// drop the oldest messages until the transcript fits
+	size := 0
+	start := len(blocks)
+	for start > 0 && size+len(blocks[start-1]) <= maxSessionReferenceChars {
+		start--
+		size += len(blocks[start])
+	}
+	transcript := strings.Join(blocks[start:], "\n\n")
+	if start > 0 {
+		transcript = fmt.Sprintf("[%d earlier messages omitted]\n\n", start) + transcript
+	}
+
+	att := attachment.NewAttachment()
+	att.Type = "text"
+	att.Display = "@session:" + session.Title
+	att.URL = attachment.SessionURL(session.ID)
+	att.Filename = session.Title
+	att.MediaType = attachment.SessionMediaType
+	att.Source = &attachment.TextSource{
+		Value: fmt.Sprintf(
+			"<session id=%q title=%q>\n%s\n</session>",
+			session.ID,
+			session.Title,
+			transcript,
+		),
+	}
+	return att, nil
+}
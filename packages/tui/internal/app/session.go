@@ -0,0 +1,20 @@
+package app
+
+import "github.com/sst/opencode-sdk-go"
+
+// UpdateSession applies a session.updated event to the loaded session,
+// reporting whether it did. Echoes for other sessions are ignored, including
+// any that arrive while no session is loaded, such as those for sub-agent
+// sessions or a session created elsewhere, so the empty placeholder is only
+// replaced by the session the TUI itself created. Events can arrive out of
+// order, so updates older than the loaded session are dropped too.
+func (a *App) UpdateSession(info opencode.Session) bool {
+	if a.Session == nil || a.Session.ID == "" || info.ID != a.Session.ID {
+		return false
+	}
+	if info.Time.Updated > 0 && info.Time.Updated < a.Session.Time.Updated {
+		return false
+	}
+	a.Session = &info
+	return true
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestUpdateSession(t *testing.T) {
+	a := &App{Session: &opencode.Session{}}
+	if a.UpdateSession(opencode.Session{ID: "ses_other", Title: "other"}) || a.Session.ID != "" {
+		t.Fatal("expected an echo to leave the empty session in place")
+	}
+	if a.UpdateSession(opencode.Session{}) {
+		t.Fatal("expected an empty echo to be ignored")
+	}
+
+	a.Session = &opencode.Session{ID: "ses_new", Time: opencode.SessionTime{Updated: 10}}
+	if !a.UpdateSession(opencode.Session{ID: "ses_new", Title: "titled", Time: opencode.SessionTime{Updated: 20}}) {
+		t.Fatal("expected the update to apply")
+	}
+	if a.UpdateSession(opencode.Session{ID: "ses_new", Title: "stale", Time: opencode.SessionTime{Updated: 15}}) {
+		t.Error("expected a stale update to be dropped")
+	}
+	if a.Session.Title != "titled" {
+		t.Errorf("session title = %q, want titled", a.Session.Title)
+	}
+}
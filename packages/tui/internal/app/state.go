@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"time"
 
@@ -21,6 +22,85 @@ type ModeModel struct {
 	ModelID    string `toml:"model_id"`
 }
 
+// MessageDensity controls the spacing, padding and metadata of messages
+type MessageDensity string
+
+const (
+	MessageDensityComfortable MessageDensity = "comfortable"
+	MessageDensityCompact     MessageDensity = "compact"
+)
+
+// messageDensities is the order densities are cycled through
+var messageDensities = []MessageDensity{MessageDensityComfortable, MessageDensityCompact}
+
+// Next returns the density after d, treating an unset density as comfortable
+func (d MessageDensity) Next() MessageDensity {
+	for i, density := range messageDensities {
+		if density == d {
+			return messageDensities[(i+1)%len(messageDensities)]
+		}
+	}
+	return messageDensities[1%len(messageDensities)]
+}
+
+// LineNumbers controls the line numbers shown in the editor
+type LineNumbers string
+
+const (
+	LineNumbersOff      LineNumbers = "off"
+	LineNumbersAbsolute LineNumbers = "absolute"
+	LineNumbersRelative LineNumbers = "relative"
+)
+
+// lineNumberModes is the order line number modes are cycled through
+var lineNumberModes = []LineNumbers{LineNumbersOff, LineNumbersAbsolute, LineNumbersRelative}
+
+// Next returns the mode after l, treating an unset mode as off
+func (l LineNumbers) Next() LineNumbers {
+	for i, mode := range lineNumberModes {
+		if mode == l {
+			return lineNumberModes[(i+1)%len(lineNumberModes)]
+		}
+	}
+	return lineNumberModes[1]
+}
+
+// Padding is the space kept around the main content, in columns and rows
+type Padding struct {
+	Horizontal int `toml:"horizontal"`
+	Vertical   int `toml:"vertical"`
+}
+
+// DefaultPadding is used until a padding is chosen
+var DefaultPadding = Padding{Horizontal: 2, Vertical: 0}
+
+// paddings is the order paddings are cycled through
+var paddings = []Padding{
+	DefaultPadding,
+	{Horizontal: 0, Vertical: 0},
+	{Horizontal: 4, Vertical: 1},
+}
+
+// Next returns the padding after p, continuing from the default when p is
+// not one of the presets
+func (p Padding) Next() Padding {
+	for i, padding := range paddings {
+		if padding == p {
+			return paddings[(i+1)%len(paddings)]
+		}
+	}
+	return paddings[1%len(paddings)]
+}
+
+const (
+	// DefaultSplitRatio gives the file viewer half of the content width
+	DefaultSplitRatio = 0.5
+	// SplitRatioStep is how much each resize moves the split
+	SplitRatioStep = 0.1
+	minSplitRatio  = 0.2
+	maxSplitRatio  = 0.8
+)
+
 type State struct {
 	Theme              string               `toml:"theme"`
 	ModeModel          map[string]ModeModel `toml:"mode_model"`
@@ -30,7 +110,29 @@ type State struct {
 	RecentlyUsedModels []ModelUsage         `toml:"recently_used_models"`
 	MessagesRight      bool                 `toml:"messages_right"`
 	SplitDiff          bool                 `toml:"split_diff"`
+	InlineDiff         bool                 `toml:"inline_diff"`
+	GutterDiff         bool                 `toml:"gutter_diff"`
+	HideModelBadges    bool                 `toml:"hide_model_badges"`
+	HideHints          bool                 `toml:"hide_hints"`
+	MessageDensity     MessageDensity       `toml:"message_density"`
 	MessageHistory     []Prompt             `toml:"message_history"`
+	// Background overrides terminal background detection with "light" or "dark"
+	Background string `toml:"background"`
+	// Padding overrides the space around the main content
+	Padding *Padding `toml:"padding,omitempty"`
+	// NoAutoFocus leaves focus where it is when a session loads, instead of
+	// moving it to the editor
+	NoAutoFocus bool `toml:"no_auto_focus"`
+	// ModeProvider is the provider last picked for each mode
+	ModeProvider map[string]string `toml:"mode_provider"`
+	// SplitRatio is the fraction of the content width given to the file
+	// viewer when it is open beside the messages
+	SplitRatio float64 `toml:"split_ratio,omitempty"`
+	// ShortPaths shortens the file paths shown in messages to their last
+	// segments
+	ShortPaths bool `toml:"short_paths"`
+	// EditorLineNumbers shows absolute or relative line numbers in the editor
+	EditorLineNumbers LineNumbers `toml:"editor_line_numbers"`
 }
 
 func NewState() *State {
@@ -38,6 +140,7 @@ func NewState() *State {
 		Theme:              "opencode",
 		Mode:               "build",
 		ModeModel:          make(map[string]ModeModel),
+		ModeProvider:       make(map[string]string),
 		RecentlyUsedModels: make([]ModelUsage, 0),
 		MessageHistory:     make([]Prompt, 0),
 	}
@@ -80,6 +183,42 @@ func (s *State) RemoveModelFromRecentlyUsed(providerID, modelID string) {
 	}
 }
 
+// CompactMessages reports whether messages are shown in the compact density
+func (s *State) CompactMessages() bool {
+	return s.MessageDensity == MessageDensityCompact
+}
+
+// ContentPadding returns the space around the main content
+func (s *State) ContentPadding() Padding {
+	if s.Padding == nil {
+		return DefaultPadding
+	}
+	return *s.Padding
+}
+
+// FileViewerRatio returns the fraction of the content width given to the file
+// viewer, falling back to the default when unset or out of range
+func (s *State) FileViewerRatio() float64 {
+	if s.SplitRatio < minSplitRatio || s.SplitRatio > maxSplitRatio {
+		return DefaultSplitRatio
+	}
+	return s.SplitRatio
+}
+
+// ResizeSplit grows the file viewer by delta, keeping both panes usable. It
+// reports whether the split moved
+func (s *State) ResizeSplit(delta float64) bool {
+	ratio := s.FileViewerRatio() + delta
+	// round to the step so repeated resizes do not drift
+	ratio = math.Round(ratio/SplitRatioStep) * SplitRatioStep
+	ratio = min(max(ratio, minSplitRatio), maxSplitRatio)
+	if ratio == s.FileViewerRatio() {
+		return false
+	}
+	s.SplitRatio = ratio
+	return true
+}
+
 func (s *State) AddPromptToHistory(prompt Prompt) {
 	s.MessageHistory = append([]Prompt{prompt}, s.MessageHistory...)
 	if len(s.MessageHistory) > 50 {
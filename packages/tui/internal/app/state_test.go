@@ -0,0 +1,32 @@
+package app
+
+import "testing"
+
+func TestResizeSplit(t *testing.T) {
+	s := NewState()
+	if got := s.FileViewerRatio(); got != DefaultSplitRatio {
+		t.Fatalf("unset ratio = %v, want %v", got, DefaultSplitRatio)
+	}
+
+	for range 10 {
+		s.ResizeSplit(SplitRatioStep)
+	}
+	if got := s.FileViewerRatio(); got != maxSplitRatio {
+		t.Errorf("ratio after growing = %v, want %v", got, maxSplitRatio)
+	}
+	if s.ResizeSplit(SplitRatioStep) {
+		t.Error("growing past the maximum reported a move")
+	}
+
+	for range 10 {
+		s.ResizeSplit(-SplitRatioStep)
+	}
+	if got := s.FileViewerRatio(); got != minSplitRatio {
+		t.Errorf("ratio after shrinking = %v, want %v", got, minSplitRatio)
+	}
+
+	s.SplitRatio = 3
+	if got := s.FileViewerRatio(); got != DefaultSplitRatio {
+		t.Errorf("out of range ratio = %v, want %v", got, DefaultSplitRatio)
+	}
+}
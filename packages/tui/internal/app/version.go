@@ -0,0 +1,26 @@
+package app
+
+import "github.com/sst/opencode-sdk-go"
+
+// VersionMismatch reports whether the server runs a different version than
+// this client, which can change the shape of events and API responses. The
+// server always reports its version, so a missing one means it isn't known
+// yet, and it is assumed to match until it is.
+func (a *App) VersionMismatch() bool {
+	if a.Config != nil && a.Config.Tui.VersionMismatch == opencode.ConfigTuiVersionMismatchIgnore {
+		return false
+	}
+	return a.Info.Version != "" && a.Version != "" && a.Info.Version != a.Version
+}
+
+// ObserveServerVersion records the server version reported in a response,
+// such as a newly created session, since the server may have restarted into
+// another version. It reports whether the versions newly differ.
+func (a *App) ObserveServerVersion(version string) bool {
+	if version == "" || version == a.Info.Version {
+		return false
+	}
+	mismatched := a.VersionMismatch()
+	a.Info.Version = version
+	return a.VersionMismatch() && !mismatched
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestVersionMismatch(t *testing.T) {
+	a := &App{Version: "0.3.1", Config: &opencode.Config{}}
+	if a.VersionMismatch() {
+		t.Error("expected an unknown server version to match")
+	}
+	if a.ObserveServerVersion("0.3.1") || a.VersionMismatch() {
+		t.Error("expected the same version to match")
+	}
+	if !a.ObserveServerVersion("0.4.0") || !a.VersionMismatch() {
+		t.Error("expected a newer server to mismatch")
+	}
+	if a.ObserveServerVersion("0.4.0") {
+		t.Error("expected a known mismatch not to be reported again")
+	}
+
+	a.Config.Tui.VersionMismatch = opencode.ConfigTuiVersionMismatchIgnore
+	if a.VersionMismatch() {
+		t.Error("expected the mismatch to be ignored")
+	}
+}
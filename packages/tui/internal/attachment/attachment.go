@@ -43,6 +43,22 @@ type Attachment struct {
 	Source     any    `toml:"source,omitempty"`
 }
 
+// SessionMediaType marks a text attachment that inlines the conversation of
+// another session, whose ID is given by its session:// URL
+const SessionMediaType = "text/x-opencode-session"
+
+// SessionURL returns the URL identifying a referenced session
+func SessionURL(sessionID string) string {
+	return "session://" + sessionID
+}
+
+// GitMediaType marks a text attachment that inlines the git status and diff
+// of the working tree
+const GitMediaType = "text/x-opencode-git"
+
+// GitURL identifies the working tree state in a git attachment
+const GitURL = "git://working-tree"
+
 // NewAttachment creates a new attachment with a unique ID
 func NewAttachment() *Attachment {
 	return &Attachment{
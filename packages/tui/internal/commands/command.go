@@ -85,41 +85,104 @@ func (r CommandRegistry) Matches(msg tea.KeyPressMsg, leader bool) []Command {
 }
 
 const (
-	AppHelpCommand              CommandName = "app_help"
-	SwitchModeCommand           CommandName = "switch_mode"
-	SwitchModeReverseCommand    CommandName = "switch_mode_reverse"
-	EditorOpenCommand           CommandName = "editor_open"
-	SessionNewCommand           CommandName = "session_new"
-	SessionListCommand          CommandName = "session_list"
-	SessionShareCommand         CommandName = "session_share"
-	SessionUnshareCommand       CommandName = "session_unshare"
-	SessionInterruptCommand     CommandName = "session_interrupt"
-	SessionCompactCommand       CommandName = "session_compact"
-	SessionExportCommand        CommandName = "session_export"
-	ToolDetailsCommand          CommandName = "tool_details"
-	ModelListCommand            CommandName = "model_list"
-	ThemeListCommand            CommandName = "theme_list"
-	FileListCommand             CommandName = "file_list"
-	FileCloseCommand            CommandName = "file_close"
-	FileSearchCommand           CommandName = "file_search"
-	FileDiffToggleCommand       CommandName = "file_diff_toggle"
-	ProjectInitCommand          CommandName = "project_init"
-	InputClearCommand           CommandName = "input_clear"
-	InputPasteCommand           CommandName = "input_paste"
-	InputSubmitCommand          CommandName = "input_submit"
-	InputNewlineCommand         CommandName = "input_newline"
-	MessagesPageUpCommand       CommandName = "messages_page_up"
-	MessagesPageDownCommand     CommandName = "messages_page_down"
-	MessagesHalfPageUpCommand   CommandName = "messages_half_page_up"
-	MessagesHalfPageDownCommand CommandName = "messages_half_page_down"
-	MessagesPreviousCommand     CommandName = "messages_previous"
-	MessagesNextCommand         CommandName = "messages_next"
-	MessagesFirstCommand        CommandName = "messages_first"
-	MessagesLastCommand         CommandName = "messages_last"
-	MessagesLayoutToggleCommand CommandName = "messages_layout_toggle"
-	MessagesCopyCommand         CommandName = "messages_copy"
-	MessagesRevertCommand       CommandName = "messages_revert"
-	AppExitCommand              CommandName = "app_exit"
+	AppHelpCommand               CommandName = "app_help"
+	SwitchModeCommand            CommandName = "switch_mode"
+	SwitchModeReverseCommand     CommandName = "switch_mode_reverse"
+	EditorOpenCommand            CommandName = "editor_open"
+	SessionNewCommand            CommandName = "session_new"
+	SessionListCommand           CommandName = "session_list"
+	SessionShareCommand          CommandName = "session_share"
+	SessionUnshareCommand        CommandName = "session_unshare"
+	SessionInterruptCommand      CommandName = "session_interrupt"
+	SessionAbortAllCommand       CommandName = "session_abort_all"
+	PromptCancelCommand          CommandName = "prompt_cancel"
+	SessionCompactCommand        CommandName = "session_compact"
+	SessionExportCommand         CommandName = "session_export"
+	SessionSaveCommand           CommandName = "session_save"
+	SessionImportCommand         CommandName = "session_import"
+	SessionCurlCommand           CommandName = "session_curl"
+	SessionCopyIDCommand         CommandName = "session_copy_id"
+	PromptNewSessionCommand      CommandName = "prompt_new_session"
+	SessionWebCommand            CommandName = "session_web"
+	SessionChildCommand          CommandName = "session_child"
+	SessionParentCommand         CommandName = "session_parent"
+	SessionNoteCommand           CommandName = "session_note"
+	ToolDetailsCommand           CommandName = "tool_details"
+	ToolDetailsExpandCommand     CommandName = "tool_details_expand"
+	ToolDetailsCollapseCommand   CommandName = "tool_details_collapse"
+	ToolOutputWrapCommand        CommandName = "tool_output_wrap"
+	ToolOutputLeftCommand        CommandName = "tool_output_left"
+	ToolOutputRightCommand       CommandName = "tool_output_right"
+	ModelListCommand             CommandName = "model_list"
+	ProviderListCommand          CommandName = "provider_list"
+	ThemeListCommand             CommandName = "theme_list"
+	FileListCommand              CommandName = "file_list"
+	FileCloseCommand             CommandName = "file_close"
+	FileSearchCommand            CommandName = "file_search"
+	FileDiffToggleCommand        CommandName = "file_diff_toggle"
+	FileNextCommand              CommandName = "file_next"
+	FilePreviousCommand          CommandName = "file_previous"
+	FileChangeNextCommand        CommandName = "file_change_next"
+	FileChangePreviousCommand    CommandName = "file_change_previous"
+	FileCopyHunkCommand          CommandName = "file_copy_hunk"
+	FileAttachCommand            CommandName = "file_attach"
+	FileGotoEditCommand          CommandName = "file_goto_edit"
+	FileViewerGrowCommand        CommandName = "file_viewer_grow"
+	FileViewerShrinkCommand      CommandName = "file_viewer_shrink"
+	FileEditedCommand            CommandName = "file_edited"
+	FileOpenEditedCommand        CommandName = "file_open_edited"
+	LogFilterCommand             CommandName = "log_filter"
+	EventInspectorCommand        CommandName = "event_inspector"
+	DebugClearCachesCommand      CommandName = "debug_clear_caches"
+	ThemeBackgroundCommand       CommandName = "theme_background"
+	ThemeReloadCommand           CommandName = "theme_reload"
+	ProjectInitCommand           CommandName = "project_init"
+	InputClearCommand            CommandName = "input_clear"
+	InputClearAttachmentsCommand CommandName = "input_clear_attachments"
+	InputGitContextCommand       CommandName = "input_git_context"
+	InputFenceCommand            CommandName = "input_fence"
+	InputDateCommand             CommandName = "input_date"
+	InputTimeCommand             CommandName = "input_time"
+	InputCwdCommand              CommandName = "input_cwd"
+	InputLineNumbersCommand      CommandName = "input_line_numbers"
+	InputFileCompletionCommand   CommandName = "input_file_completion"
+	InputPasteCommand            CommandName = "input_paste"
+	InputSubmitCommand           CommandName = "input_submit"
+	InputNewlineCommand          CommandName = "input_newline"
+	InputFileInsertCommand       CommandName = "input_file_insert"
+	MessagesLineUpCommand        CommandName = "messages_line_up"
+	MessagesLineDownCommand      CommandName = "messages_line_down"
+	MessagesPageUpCommand        CommandName = "messages_page_up"
+	MessagesPageDownCommand      CommandName = "messages_page_down"
+	MessagesHalfPageUpCommand    CommandName = "messages_half_page_up"
+	MessagesHalfPageDownCommand  CommandName = "messages_half_page_down"
+	MessagesPreviousCommand      CommandName = "messages_previous"
+	MessagesNextCommand          CommandName = "messages_next"
+	MessagesFirstCommand         CommandName = "messages_first"
+	MessagesLastCommand          CommandName = "messages_last"
+	MessagesLastErrorCommand     CommandName = "messages_last_error"
+	MessagesLayoutToggleCommand  CommandName = "messages_layout_toggle"
+	MessagesCopyCommand          CommandName = "messages_copy"
+	MessagesCopyToolInputCommand CommandName = "messages_copy_tool_input"
+	MessagesCopyCodeCommand      CommandName = "messages_copy_code"
+	MessagesRevertCommand        CommandName = "messages_revert"
+	MessagesTocCommand           CommandName = "messages_toc"
+	MessagesModelBadgesCommand   CommandName = "messages_model_badges"
+	MessagesRawCommand           CommandName = "messages_raw"
+	MessagesSyntheticCommand     CommandName = "messages_synthetic"
+	MessagesShortPathsCommand    CommandName = "messages_short_paths"
+	MessagesDensityCommand       CommandName = "messages_density"
+	MessagesToolOutputCommand    CommandName = "messages_tool_output"
+	MessagesCompareCommand       CommandName = "messages_compare"
+	FocusToggleCommand           CommandName = "focus_toggle"
+	FocusAutoCommand             CommandName = "focus_auto"
+	HintsToggleCommand           CommandName = "hints_toggle"
+	LayoutPaddingCommand         CommandName = "layout_padding"
+	PermissionListCommand        CommandName = "permission_list"
+	ModalCycleCommand            CommandName = "modal_cycle"
+	ModalCloseAllCommand         CommandName = "modal_close_all"
+	ToastExpandCommand           CommandName = "toast_expand"
+	AppExitCommand               CommandName = "app_exit"
 )
 
 func (k Command) Matches(msg tea.KeyPressMsg, leader bool) bool {
@@ -177,6 +240,51 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 			Keybindings: parseBindings("<leader>x"),
 			// Trigger:     []string{"export"},
 		},
+		{
+			Name:        SessionSaveCommand,
+			Description: "save conversation to project",
+			Trigger:     []string{"save"},
+		},
+		{
+			Name:        SessionImportCommand,
+			Description: "import conversation",
+			Trigger:     []string{"import"},
+		},
+		{
+			Name:        SessionCurlCommand,
+			Description: "copy last request as curl",
+			Keybindings: parseBindings("<leader>g"),
+		},
+		{
+			Name:        SessionCopyIDCommand,
+			Description: "copy session id",
+			Trigger:     []string{"copy-id"},
+		},
+		{
+			Name:        PromptNewSessionCommand,
+			Description: "move prompt to a new session",
+			Trigger:     []string{"new-with-prompt"},
+		},
+		{
+			Name:        SessionChildCommand,
+			Description: "open the latest sub-agent session",
+			Trigger:     []string{"subagent"},
+		},
+		{
+			Name:        SessionParentCommand,
+			Description: "return to the parent session",
+			Trigger:     []string{"parent"},
+		},
+		{
+			Name:        SessionNoteCommand,
+			Description: "add a note to the conversation",
+			Trigger:     []string{"note"},
+		},
+		{
+			Name:        SessionWebCommand,
+			Description: "open in browser",
+			Trigger:     []string{"web"},
+		},
 		{
 			Name:        SessionNewCommand,
 			Description: "new session",
@@ -206,6 +314,16 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 			Description: "interrupt session",
 			Keybindings: parseBindings("esc"),
 		},
+		{
+			Name:        SessionAbortAllCommand,
+			Description: "abort all busy sessions",
+			Trigger:     []string{"abort-all"},
+		},
+		{
+			Name:        PromptCancelCommand,
+			Description: "cancel pending send",
+			Keybindings: parseBindings("<leader>k"),
+		},
 		{
 			Name:        SessionCompactCommand,
 			Description: "compact the session",
@@ -218,12 +336,42 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 			Keybindings: parseBindings("<leader>d"),
 			// Trigger:     []string{"details"},
 		},
+		{
+			Name:        ToolDetailsExpandCommand,
+			Description: "expand all tool details",
+			Trigger:     []string{"expand"},
+		},
+		{
+			Name:        ToolDetailsCollapseCommand,
+			Description: "collapse all tool details",
+			Trigger:     []string{"collapse"},
+		},
+		{
+			Name:        ToolOutputWrapCommand,
+			Description: "toggle tool output wrap",
+			Keybindings: parseBindings("<leader>w"),
+		},
+		{
+			Name:        ToolOutputLeftCommand,
+			Description: "scroll tool output left",
+			Keybindings: parseBindings("<leader>left"),
+		},
+		{
+			Name:        ToolOutputRightCommand,
+			Description: "scroll tool output right",
+			Keybindings: parseBindings("<leader>right"),
+		},
 		{
 			Name:        ModelListCommand,
 			Description: "list models",
 			Keybindings: parseBindings("<leader>m"),
 			Trigger:     []string{"models"},
 		},
+		{
+			Name:        ProviderListCommand,
+			Description: "list models by provider",
+			Trigger:     []string{"providers"},
+		},
 		{
 			Name:        ThemeListCommand,
 			Description: "list themes",
@@ -248,9 +396,89 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 		},
 		{
 			Name:        FileDiffToggleCommand,
-			Description: "split/unified diff",
+			Description: "split/word/unified diff",
 			Keybindings: parseBindings("<leader>v"),
 		},
+		{
+			Name:        FileNextCommand,
+			Description: "next file",
+			Keybindings: parseBindings("<leader>]"),
+		},
+		{
+			Name:        FilePreviousCommand,
+			Description: "previous file",
+			Keybindings: parseBindings("<leader>["),
+		},
+		{
+			Name:        FileChangeNextCommand,
+			Description: "next change",
+			Keybindings: parseBindings("<leader>."),
+		},
+		{
+			Name:        FileChangePreviousCommand,
+			Description: "previous change",
+			Keybindings: parseBindings("<leader>,"),
+		},
+		{
+			Name:        FileCopyHunkCommand,
+			Description: "copy diff hunk",
+			Keybindings: parseBindings("<leader>j"),
+		},
+		{
+			Name:        FileAttachCommand,
+			Description: "attach viewed file",
+			Trigger:     []string{"attach"},
+		},
+		{
+			Name:        FileGotoEditCommand,
+			Description: "jump to the edit of this line",
+			Trigger:     []string{"goto-edit"},
+		},
+		{
+			Name:        FileViewerGrowCommand,
+			Description: "widen file viewer",
+			Keybindings: parseBindings("<leader>="),
+		},
+		{
+			Name:        FileViewerShrinkCommand,
+			Description: "narrow file viewer",
+			Keybindings: parseBindings("<leader>-"),
+		},
+		{
+			Name:        FileEditedCommand,
+			Description: "list edited files",
+			Trigger:     []string{"edited"},
+		},
+		{
+			Name:        FileOpenEditedCommand,
+			Description: "open all edited files",
+			Trigger:     []string{"open-edited"},
+		},
+		{
+			Name:        LogFilterCommand,
+			Description: "filter debug log",
+			Trigger:     []string{"log-filter"},
+		},
+		{
+			Name:        EventInspectorCommand,
+			Description: "toggle event inspector",
+			Trigger:     []string{"events"},
+		},
+		{
+			Name:        DebugClearCachesCommand,
+			Description: "clear cached renders and completions",
+			Trigger:     []string{"clear-caches"},
+		},
+		{
+			Name:        ThemeBackgroundCommand,
+			Description: "toggle light/dark background",
+			Trigger:     []string{"background"},
+		},
+		{
+			Name:        ThemeReloadCommand,
+			Description: "reload themes from disk",
+			Trigger:     []string{"reload-themes"},
+		},
 		{
 			Name:        ProjectInitCommand,
 			Description: "create/update AGENTS.md",
@@ -262,6 +490,46 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 			Description: "clear input",
 			Keybindings: parseBindings("ctrl+c"),
 		},
+		{
+			Name:        InputClearAttachmentsCommand,
+			Description: "clear attachments",
+			Keybindings: parseBindings("<leader>z"),
+		},
+		{
+			Name:        InputGitContextCommand,
+			Description: "attach git status and diff",
+			Trigger:     []string{"git"},
+		},
+		{
+			Name:        InputFenceCommand,
+			Description: "wrap input in a code fence",
+			Keybindings: parseBindings("<leader>`"),
+		},
+		{
+			Name:        InputDateCommand,
+			Description: "insert today's date",
+			Trigger:     []string{"date"},
+		},
+		{
+			Name:        InputTimeCommand,
+			Description: "insert the current time",
+			Trigger:     []string{"time"},
+		},
+		{
+			Name:        InputCwdCommand,
+			Description: "insert the working directory",
+			Trigger:     []string{"cwd"},
+		},
+		{
+			Name:        InputLineNumbersCommand,
+			Description: "cycle editor line numbers",
+			Trigger:     []string{"line-numbers"},
+		},
+		{
+			Name:        InputFileCompletionCommand,
+			Description: "toggle file completion",
+			Keybindings: parseBindings("ctrl+alt+f"),
+		},
 		{
 			Name:        InputPasteCommand,
 			Description: "paste content",
@@ -277,6 +545,21 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 			Description: "insert newline",
 			Keybindings: parseBindings("shift+enter", "ctrl+j"),
 		},
+		{
+			Name:        InputFileInsertCommand,
+			Description: "insert file contents",
+			Keybindings: parseBindings("<leader>a"),
+		},
+		{
+			Name:        MessagesLineUpCommand,
+			Description: "scroll up",
+			Keybindings: parseBindings("alt+up"),
+		},
+		{
+			Name:        MessagesLineDownCommand,
+			Description: "scroll down",
+			Keybindings: parseBindings("alt+down"),
+		},
 		{
 			Name:        MessagesPageUpCommand,
 			Description: "page up",
@@ -327,11 +610,106 @@ func LoadFromConfig(config *opencode.Config) CommandRegistry {
 			Description: "copy message",
 			Keybindings: parseBindings("<leader>y"),
 		},
+		{
+			Name:        MessagesLastErrorCommand,
+			Description: "jump to last error",
+			Trigger:     []string{"last-error"},
+		},
+		{
+			Name:        MessagesCopyToolInputCommand,
+			Description: "copy tool input",
+			Trigger:     []string{"copy-input"},
+		},
+		{
+			Name:        MessagesCopyCodeCommand,
+			Description: "copy code blocks",
+			Trigger:     []string{"copy-code"},
+		},
 		{
 			Name:        MessagesRevertCommand,
 			Description: "revert message",
 			Keybindings: parseBindings("<leader>r"),
 		},
+		{
+			Name:        MessagesTocCommand,
+			Description: "table of contents",
+			Keybindings: parseBindings("<leader>o"),
+		},
+		{
+			Name:        MessagesModelBadgesCommand,
+			Description: "toggle model badges",
+			Keybindings: parseBindings("<leader>b"),
+		},
+		{
+			Name:        MessagesRawCommand,
+			Description: "toggle raw markdown",
+			Trigger:     []string{"raw"},
+		},
+		{
+			Name:        MessagesSyntheticCommand,
+			Description: "toggle injected context",
+			Trigger:     []string{"synthetic"},
+		},
+		{
+			Name:        MessagesShortPathsCommand,
+			Description: "toggle short file paths",
+			Trigger:     []string{"short-paths"},
+		},
+		{
+			Name:        MessagesDensityCommand,
+			Description: "cycle message density",
+			Trigger:     []string{"density"},
+		},
+		{
+			Name:        MessagesToolOutputCommand,
+			Description: "show full tool output",
+			Trigger:     []string{"output"},
+		},
+		{
+			Name:        MessagesCompareCommand,
+			Description: "compare two responses",
+			Trigger:     []string{"compare"},
+		},
+		{
+			Name:        FocusToggleCommand,
+			Description: "cycle focus",
+			Keybindings: parseBindings("<leader>tab"),
+		},
+		{
+			Name:        FocusAutoCommand,
+			Description: "toggle editor focus on session load",
+			Trigger:     []string{"autofocus"},
+		},
+		{
+			Name:        HintsToggleCommand,
+			Description: "toggle keybind hints",
+			Trigger:     []string{"hints"},
+		},
+		{
+			Name:        LayoutPaddingCommand,
+			Description: "cycle content padding",
+			Trigger:     []string{"padding"},
+		},
+		{
+			Name:        PermissionListCommand,
+			Description: "review granted permissions",
+			Trigger:     []string{"permissions"},
+		},
+		{
+			Name:        ModalCycleCommand,
+			Description: "next open dialog",
+			Keybindings: parseBindings("ctrl+o"),
+		},
+		{
+			Name:        ModalCloseAllCommand,
+			Description: "close all dialogs",
+			Keybindings: parseBindings("ctrl+q"),
+		},
+		{
+			Name:        ToastExpandCommand,
+			Description: "expand notification",
+			Trigger:     []string{"expand"},
+		},
 		{
 			Name:        AppExitCommand,
 			Description: "exit the app",
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/sst/opencode-sdk-go"
 	"github.com/sst/opencode/internal/app"
@@ -14,7 +15,9 @@ import (
 )
 
 type filesContextGroup struct {
-	app      *app.App
+	app *app.App
+	// mu guards gitFiles, which Refresh replaces in the background
+	mu       sync.RWMutex
 	gitFiles []CompletionSuggestion
 }
 
@@ -69,8 +72,14 @@ func (cg *filesContextGroup) GetChildEntries(
 	items := make([]CompletionSuggestion, 0)
 
 	query = strings.TrimSpace(query)
+	if strings.HasPrefix(query, SessionMentionPrefix) {
+		return items, nil
+	}
+	cg.mu.RLock()
+	gitFiles := cg.gitFiles
+	cg.mu.RUnlock()
 	if query == "" {
-		items = append(items, cg.gitFiles...)
+		items = append(items, gitFiles...)
 	}
 
 	files, err := cg.app.Client.Find.Files(
@@ -87,7 +96,7 @@ func (cg *filesContextGroup) GetChildEntries(
 
 	for _, file := range *files {
 		exists := false
-		for _, existing := range cg.gitFiles {
+		for _, existing := range gitFiles {
 			if existing.Value == file {
 				if query != "" {
 					items = append(items, existing)
@@ -115,12 +124,20 @@ func (cg *filesContextGroup) GetChildEntries(
 	return items, nil
 }
 
+// Refresh reloads the modified files suggested before any query
+func (cg *filesContextGroup) Refresh() {
+	go func() {
+		gitFiles := cg.getGitFiles()
+		cg.mu.Lock()
+		cg.gitFiles = gitFiles
+		cg.mu.Unlock()
+	}()
+}
+
 func NewFileContextGroup(app *app.App) CompletionProvider {
 	cg := &filesContextGroup{
 		app: app,
 	}
-	go func() {
-		cg.gitFiles = cg.getGitFiles()
-	}()
+	cg.Refresh()
 	return cg
 }
@@ -6,3 +6,10 @@ type CompletionProvider interface {
 	GetChildEntries(query string) ([]CompletionSuggestion, error)
 	GetEmptyMessage() string
 }
+
+// RefreshableProvider is a completion provider holding cached suggestions
+// that can be reloaded
+type RefreshableProvider interface {
+	CompletionProvider
+	Refresh()
+}
@@ -0,0 +1,78 @@
+package completions
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// SessionMentionPrefix starts an @ mention that references another session,
+// eg @session:refactor
+const SessionMentionPrefix = "session:"
+
+type sessionsContextGroup struct {
+	app *app.App
+}
+
+func (cg *sessionsContextGroup) GetId() string {
+	return "sessions"
+}
+
+func (cg *sessionsContextGroup) GetEmptyMessage() string {
+	return "no matching sessions"
+}
+
+func (cg *sessionsContextGroup) GetChildEntries(
+	query string,
+) ([]CompletionSuggestion, error) {
+	items := make([]CompletionSuggestion, 0)
+
+	query = strings.TrimSpace(query)
+	if !strings.HasPrefix(query, SessionMentionPrefix) {
+		return items, nil
+	}
+	query = strings.ToLower(strings.TrimPrefix(query, SessionMentionPrefix))
+
+	sessions, err := cg.app.ListSessions(context.Background())
+	if err != nil {
+		slog.Error("Failed to get session completion items", "error", err)
+		return items, err
+	}
+
+	for _, session := range sessions {
+		if session.ID == cg.app.Session.ID {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(session.Title), query) {
+			continue
+		}
+
+		created := time.UnixMilli(int64(session.Time.Created))
+		displayFunc := func(s styles.Style) string {
+			t := theme.CurrentTheme()
+			base := s.Foreground(t.Text()).Render
+			muted := s.Foreground(t.TextMuted()).Render
+			return base(session.Title) + muted(" "+created.Format("Jan 2 15:04"))
+		}
+
+		items = append(items, CompletionSuggestion{
+			Display:    displayFunc,
+			Value:      session.ID,
+			ProviderID: cg.GetId(),
+			RawData:    session,
+		})
+	}
+
+	return items, nil
+}
+
+func NewSessionsContextGroup(app *app.App) CompletionProvider {
+	return &sessionsContextGroup{
+		app: app,
+	}
+}
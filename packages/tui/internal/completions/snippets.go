@@ -0,0 +1,62 @@
+package completions
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+type snippetsContextGroup struct {
+	app *app.App
+}
+
+func (cg *snippetsContextGroup) GetId() string {
+	return "snippets"
+}
+
+func (cg *snippetsContextGroup) GetEmptyMessage() string {
+	return "no matching snippets"
+}
+
+func (cg *snippetsContextGroup) GetChildEntries(
+	query string,
+) ([]CompletionSuggestion, error) {
+	items := make([]CompletionSuggestion, 0)
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	names := make([]string, 0, len(cg.app.Config.Tui.Snippets))
+	for name := range cg.app.Config.Tui.Snippets {
+		if strings.Contains(strings.ToLower(name), query) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		template := cg.app.Config.Tui.Snippets[name]
+		preview, _, _ := strings.Cut(strings.TrimSpace(template), "\n")
+		displayFunc := func(s styles.Style) string {
+			t := theme.CurrentTheme()
+			base := s.Foreground(t.Text()).Render
+			muted := s.Foreground(t.TextMuted()).Render
+			return base(":"+name) + muted(" "+preview)
+		}
+		items = append(items, CompletionSuggestion{
+			Display:    displayFunc,
+			Value:      name,
+			ProviderID: cg.GetId(),
+			RawData:    template,
+		})
+	}
+
+	return items, nil
+}
+
+func NewSnippetsContextGroup(app *app.App) CompletionProvider {
+	return &snippetsContextGroup{
+		app: app,
+	}
+}
@@ -61,7 +61,7 @@ func (cg *symbolsContextGroup) GetChildEntries(
 	items := make([]CompletionSuggestion, 0)
 
 	query = strings.TrimSpace(query)
-	if query == "" {
+	if query == "" || strings.HasPrefix(query, SessionMentionPrefix) {
 		return items, nil
 	}
 
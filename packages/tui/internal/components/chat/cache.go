@@ -29,8 +29,11 @@ func (c *PartCache) GenerateKey(params ...any) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// Get retrieves a cached rendered message
+// Get retrieves a cached rendered message, always missing on a nil cache
 func (c *PartCache) Get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -38,8 +41,11 @@ func (c *PartCache) Get(key string) (string, bool) {
 	return content, exists
 }
 
-// Set stores a rendered message in the cache
+// Set stores a rendered message in the cache, doing nothing on a nil cache
 func (c *PartCache) Set(key string, content string) {
+	if c == nil {
+		return
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.cache[key] = content
@@ -0,0 +1,52 @@
+package chat
+
+import "strings"
+
+// parseCodeBlocks returns the contents of the fenced code blocks in markdown,
+// without the fences. A fence left open, as in a streaming message, runs to
+// the end of the text.
+func parseCodeBlocks(text string) []string {
+	blocks := []string{}
+	var fence string
+	var indent int
+	var lines []string
+	for line := range strings.SplitSeq(text, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence == "" {
+			if marker := fenceMarker(trimmed); marker != "" {
+				fence = marker
+				indent = len(line) - len(trimmed)
+				lines = []string{}
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]+" \t") == "" {
+			blocks = append(blocks, strings.Join(lines, "\n"))
+			fence = ""
+			continue
+		}
+		// drop the indentation of the fence, as for code blocks in lists
+		strip := min(indent, len(line)-len(trimmed))
+		lines = append(lines, line[strip:])
+	}
+	if fence != "" {
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return blocks
+}
+
+// fenceMarker returns the run of backticks or tildes opening a code fence, or
+// an empty string when the line doesn't open one
+func fenceMarker(line string) string {
+	for _, char := range []string{"`", "~"} {
+		marker := line[:len(line)-len(strings.TrimLeft(line, char))]
+		if len(marker) >= 3 {
+			// backtick fences can't have backticks in the info string
+			if char == "`" && strings.Contains(line[len(marker):], "`") {
+				return ""
+			}
+			return marker
+		}
+	}
+	return ""
+}
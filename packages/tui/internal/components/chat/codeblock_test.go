@@ -0,0 +1,24 @@
+package chat
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseCodeBlocks(t *testing.T) {
+	text := "Run this:\n\n```bash\nnpm install\nnpm test\n```\n\nThen:\n\n" +
+		"1. edit the file\n   ~~~go\n   func main() {\n   \tfmt.Println(\"```\")\n   }\n   ~~~\n\n" +
+		"Inline ```code``` is not a block.\n\n````md\n```\nnested\n```\n````\n\n```\nstreaming"
+	expected := []string{
+		"npm install\nnpm test",
+		"func main() {\n\tfmt.Println(\"```\")\n}",
+		"```\nnested\n```",
+		"streaming",
+	}
+	if blocks := parseCodeBlocks(text); !slices.Equal(blocks, expected) {
+		t.Errorf("parseCodeBlocks() = %q, want %q", blocks, expected)
+	}
+	if blocks := parseCodeBlocks("no code here"); len(blocks) != 0 {
+		t.Errorf("expected no blocks, got %q", blocks)
+	}
+}
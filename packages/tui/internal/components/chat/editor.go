@@ -1,18 +1,21 @@
 package chat
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
 	"github.com/google/uuid"
 	"github.com/sst/opencode-sdk-go"
 	"github.com/sst/opencode/internal/app"
@@ -21,6 +24,7 @@ import (
 	"github.com/sst/opencode/internal/commands"
 	"github.com/sst/opencode/internal/components/dialog"
 	"github.com/sst/opencode/internal/components/textarea"
+	"github.com/sst/opencode/internal/components/toast"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
 	"github.com/sst/opencode/internal/util"
@@ -38,13 +42,42 @@ type EditorComponent interface {
 	Blur()
 	Submit() (tea.Model, tea.Cmd)
 	Clear() (tea.Model, tea.Cmd)
+	ClearAttachments() int
 	Paste() (tea.Model, tea.Cmd)
 	Newline() (tea.Model, tea.Cmd)
+	InsertText(text string)
+	RemoveFileTrigger()
+	WrapInFence(language string)
 	SetValue(value string)
 	SetValueWithAttachments(value string)
+	SetPrompt(prompt app.Prompt)
 	SetInterruptKeyInDebounce(inDebounce bool)
 	SetExitKeyInDebounce(inDebounce bool)
+	InSnippet() bool
+	NextSnippetField()
 	RestoreFromHistory(index int)
+	AttachGitContext() tea.Cmd
+	AttachFile(path string) bool
+	HasSelection() bool
+	SetWidth(width int)
+}
+
+// sessionReferenceMsg carries the attachment for a referenced session once
+// its conversation has been loaded
+type sessionReferenceMsg struct {
+	attachment *attachment.Attachment
+}
+
+// LongPasteMsg asks to choose whether long pasted text is attached or
+// inserted into the editor
+type LongPasteMsg struct {
+	Text string
+}
+
+// gitContextMsg carries the attachment with the working tree state once git
+// has been run
+type gitContextMsg struct {
+	attachment *attachment.Attachment
 }
 
 type editorComponent struct {
@@ -52,15 +85,22 @@ type editorComponent struct {
 	width                  int
 	textarea               textarea.Model
 	spinner                spinner.Model
+	spinnerTicking         bool // a spinner tick is scheduled, only while busy
 	interruptKeyInDebounce bool
 	exitKeyInDebounce      bool
 	historyIndex           int    // -1 means current (not in history)
 	currentText            string // Store current text when navigating history
 	pasteCounter           int
+	scrollOffset           int // first visible line when the editor is capped
+	snippet                *snippetSession
 }
 
+// defaultEditorMaxHeight is the number of lines the editor grows to before
+// scrolling when tui.editor_max_height isn't configured
+const defaultEditorMaxHeight = 20
+
 func (m *editorComponent) Init() tea.Cmd {
-	return tea.Batch(m.textarea.Focus(), m.spinner.Tick, tea.EnableReportFocus)
+	return tea.Batch(m.textarea.Focus(), tea.EnableReportFocus)
 }
 
 func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -69,9 +109,16 @@ func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width - 4
+		m.width = msg.Width - 2*m.app.State.ContentPadding().Horizontal
 		return m, nil
 	case spinner.TickMsg:
+		if msg.ID != m.spinner.ID() {
+			return m, nil
+		}
+		if !m.app.IsBusy() {
+			m.spinnerTicking = false
+			return m, nil
+		}
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 	case tea.KeyPressMsg:
@@ -120,60 +167,67 @@ func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Maximize editor responsiveness for printable characters
 		if msg.Text != "" {
+			m.replaceSnippetDefault()
 			m.textarea, cmd = m.textarea.Update(msg)
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...)
 		}
 	case tea.PasteMsg:
+		// Pasting replaces the selection
+		m.textarea.DeleteSelection()
 		text := string(msg)
 		text = strings.ReplaceAll(text, "\\", "")
 		text, err := strconv.Unquote(`"` + text + `"`)
 		if err != nil {
 			slog.Error("Failed to unquote text", "error", err)
-			text := string(msg)
-			if m.shouldSummarizePastedText(text) {
-				m.handleLongPaste(text)
-			} else {
-				m.textarea.InsertRunesFromUserInput([]rune(msg))
-			}
-			return m, nil
+			return m, m.pasteText(string(msg))
 		}
 		if _, err := os.Stat(text); err != nil {
 			slog.Error("Failed to paste file", "error", err)
-			text := string(msg)
-			if m.shouldSummarizePastedText(text) {
-				m.handleLongPaste(text)
-			} else {
-				m.textarea.InsertRunesFromUserInput([]rune(msg))
-			}
-			return m, nil
+			return m, m.pasteText(string(msg))
 		}
 
 		filePath := text
 
 		attachment := m.createAttachmentFromFile(filePath)
 		if attachment == nil {
-			if m.shouldSummarizePastedText(text) {
-				m.handleLongPaste(text)
-			} else {
-				m.textarea.InsertRunesFromUserInput([]rune(msg))
-			}
-			return m, nil
+			return m, m.pasteText(string(msg))
 		}
 
 		m.textarea.InsertAttachment(attachment)
 		m.textarea.InsertString(" ")
 	case tea.ClipboardMsg:
-		text := string(msg)
-		// Check if the pasted text is long and should be summarized
-		if m.shouldSummarizePastedText(text) {
-			m.handleLongPaste(text)
-		} else {
-			m.textarea.InsertRunesFromUserInput([]rune(text))
+		m.textarea.DeleteSelection()
+		cmds = append(cmds, m.pasteText(string(msg)))
+	case dialog.PasteChosenMsg:
+		if msg.Attach {
+			m.handleLongPaste(msg.Text)
+			return m, nil
+		}
+		return m, m.textarea.PasteRunes([]rune(msg.Text))
+	case textarea.LineLimitReachedMsg:
+		return m, toast.NewWarningToast(
+			fmt.Sprintf("Pasted text was cut short, %d lines over the limit were dropped", msg.Dropped),
+		)
+	case sessionReferenceMsg:
+		m.textarea.InsertAttachment(msg.attachment)
+		m.textarea.InsertString(" ")
+		return m, nil
+	case gitContextMsg:
+		if app.HasGitContext(m.textarea.GetAttachments()) {
+			return m, toast.NewInfoToast("Git context is already attached")
 		}
+		m.textarea.InsertAttachment(msg.attachment)
+		m.textarea.InsertString(" ")
+		return m, nil
+	case ClearCacheMsg:
+		m.textarea.ClearCache()
+		return m, nil
 	case dialog.ThemeSelectedMsg:
 		m.textarea = updateTextareaStyles(m.textarea)
-		m.spinner = createSpinner()
+		m.spinner = createSpinner(string(m.app.Config.Tui.Spinner))
+		// ticks scheduled for the old spinner are ignored
+		m.spinnerTicking = false
 		return m, m.textarea.Focus()
 	case dialog.CompletionSelectedMsg:
 		switch msg.Item.ProviderID {
@@ -244,14 +298,42 @@ func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.textarea.InsertAttachment(attachment)
 			m.textarea.InsertString(" ")
 			return m, nil
+		case "snippets":
+			colonIndex := m.textarea.LastRuneIndex(':')
+			if colonIndex != -1 {
+				cursorCol := m.textarea.CursorColumn()
+				m.textarea.ReplaceRange(colonIndex, cursorCol, "")
+			}
+			m.insertSnippet(msg.Item.RawData.(string))
+			return m, nil
+		case "sessions":
+			atIndex := m.textarea.LastRuneIndex('@')
+			if atIndex != -1 {
+				cursorCol := m.textarea.CursorColumn()
+				m.textarea.ReplaceRange(atIndex, cursorCol, "")
+			}
+
+			// load the conversation in the background, inserting the
+			// reference wherever the cursor is once it arrives
+			session := msg.Item.RawData.(opencode.Session)
+			return m, func() tea.Msg {
+				attachment, err := m.app.SessionReference(context.Background(), session)
+				if err != nil {
+					slog.Error("Failed to load referenced session", "error", err)
+					return toast.NewErrorToast("Failed to load session " + session.Title)()
+				}
+				return sessionReferenceMsg{attachment: attachment}
+			}
 		default:
 			slog.Debug("Unknown provider", "provider", msg.Item.ProviderID)
 			return m, nil
 		}
 	}
 
-	m.spinner, cmd = m.spinner.Update(msg)
-	cmds = append(cmds, cmd)
+	if !m.spinnerTicking && !m.app.Config.Tui.ReducedMotion && m.app.IsBusy() {
+		m.spinnerTicking = true
+		cmds = append(cmds, m.spinner.Tick)
+	}
 
 	m.textarea, cmd = m.textarea.Update(msg)
 	cmds = append(cmds, cmd)
@@ -259,6 +341,12 @@ func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// SetWidth sets the width of the editor pane, which narrows beside the file
+// viewer
+func (m *editorComponent) SetWidth(width int) {
+	m.width = width
+}
+
 func (m *editorComponent) Content() string {
 	width := m.width
 	if m.app.Session.ID == "" {
@@ -268,32 +356,56 @@ func (m *editorComponent) Content() string {
 	t := theme.CurrentTheme()
 	base := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Render
 	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Render
-	promptStyle := styles.NewStyle().Foreground(t.Primary()).
+	promptColor := t.Primary()
+	if !m.Focused() {
+		promptColor = t.TextMuted()
+	}
+	promptStyle := styles.NewStyle().Foreground(promptColor).
 		Padding(0, 0, 0, 1).
 		Bold(true)
 	prompt := promptStyle.Render(">")
 
+	lineNumbers := m.app.State.EditorLineNumbers
+	m.textarea.ShowLineNumbers = lineNumbers == app.LineNumbersAbsolute || lineNumbers == app.LineNumbersRelative
+	m.textarea.RelativeLineNumbers = lineNumbers == app.LineNumbersRelative
 	m.textarea.SetWidth(width - 6)
+	view, above, below := m.visibleLines()
 	textarea := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		prompt,
-		m.textarea.View(),
+		view,
 	)
+	// the padding rows above and below the text show how much is scrolled
+	// out of view when the editor is capped
+	indicator := styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundElement()).
+		Width(width).
+		PaddingLeft(3)
+	top, bottom := indicator.Render(""), indicator.Render("")
+	if above > 0 {
+		top = indicator.Render(fmt.Sprintf("↑ %d more", above))
+	}
+	if below > 0 {
+		bottom = indicator.Render(fmt.Sprintf("↓ %d more", below))
+	}
+	charCount, charColor, showCharCount := m.charCount()
 	borderForeground := t.Border()
+	if showCharCount {
+		borderForeground = charColor
+	}
 	if m.app.IsLeaderSequence {
 		borderForeground = t.Accent()
 	}
 	textarea = styles.NewStyle().
 		Background(t.BackgroundElement()).
 		Width(width).
-		PaddingTop(1).
-		PaddingBottom(1).
 		BorderStyle(lipgloss.ThickBorder()).
 		BorderForeground(borderForeground).
 		BorderBackground(t.Background()).
 		BorderLeft(true).
 		BorderRight(true).
-		Render(textarea)
+		Render(top + "\n" + textarea + "\n" + bottom)
 
 	hint := base(m.getSubmitKeyText()) + muted(" send   ")
 	if m.exitKeyInDebounce {
@@ -304,7 +416,7 @@ func (m *editorComponent) Content() string {
 		if m.interruptKeyInDebounce {
 			hint = muted(
 				"working",
-			) + m.spinner.View() + muted(
+			) + m.workingIndicator() + muted(
 				"  ",
 			) + base(
 				keyText+" again",
@@ -312,7 +424,7 @@ func (m *editorComponent) Content() string {
 				" interrupt",
 			)
 		} else {
-			hint = muted("working") + m.spinner.View() + muted("  ") + base(keyText) + muted(" interrupt")
+			hint = muted("working") + m.workingIndicator() + muted("  ") + base(keyText) + muted(" interrupt")
 		}
 	}
 
@@ -320,6 +432,10 @@ func (m *editorComponent) Content() string {
 	if m.app.Model != nil {
 		model = muted(m.app.Provider.Name) + base(" "+m.app.Model.Name)
 	}
+	if showCharCount {
+		count := styles.NewStyle().Foreground(charColor).Background(t.Background()).Render(charCount)
+		model = count + muted("  ") + model
+	}
 
 	space := width - 2 - lipgloss.Width(model) - lipgloss.Width(hint)
 	spacer := styles.NewStyle().Background(t.Background()).Width(space).Render("")
@@ -331,6 +447,30 @@ func (m *editorComponent) Content() string {
 	return content
 }
 
+// charCount returns the character count of the editor and its color once it
+// nears the configured limits, and false while it is below them
+func (m *editorComponent) charCount() (string, compat.AdaptiveColor, bool) {
+	t := theme.CurrentTheme()
+	length := m.textarea.Length()
+	limit := int(m.app.Config.Tui.EditorCharLimit)
+	warning := int(m.app.Config.Tui.EditorCharWarning)
+	if limit > 0 && warning == 0 {
+		// warn over the last tenth when only the hard limit is set
+		warning = limit - limit/10
+	}
+	if warning <= 0 || length < warning {
+		return "", compat.AdaptiveColor{}, false
+	}
+	if limit <= 0 {
+		return fmt.Sprintf("%d chars", length), t.Warning(), true
+	}
+	color := t.Warning()
+	if length >= limit {
+		color = t.Error()
+	}
+	return fmt.Sprintf("%d/%d", length, limit), color, true
+}
+
 func (m *editorComponent) View() string {
 	width := m.width
 	if m.app.Session.ID == "" {
@@ -363,7 +503,38 @@ func (m *editorComponent) Blur() {
 }
 
 func (m *editorComponent) Lines() int {
-	return m.textarea.LineCount()
+	return min(m.textarea.LineCount(), m.maxHeight())
+}
+
+func (m *editorComponent) maxHeight() int {
+	if m.app.Config.Tui.EditorMaxHeight > 0 {
+		return int(m.app.Config.Tui.EditorMaxHeight)
+	}
+	return defaultEditorMaxHeight
+}
+
+// visibleLines returns the textarea lines that fit within the max height,
+// scrolled to keep the cursor in view, and how many lines are hidden above
+// and below
+func (m *editorComponent) visibleLines() (string, int, int) {
+	view := m.textarea.View()
+	height := m.maxHeight()
+	lines := strings.Split(view, "\n")
+	if len(lines) <= height {
+		m.scrollOffset = 0
+		return view, 0, 0
+	}
+
+	cursor := m.textarea.CursorDisplayLine()
+	if cursor < m.scrollOffset {
+		m.scrollOffset = cursor
+	} else if cursor >= m.scrollOffset+height {
+		m.scrollOffset = cursor - height + 1
+	}
+	m.scrollOffset = max(0, min(m.scrollOffset, len(lines)-height))
+
+	end := m.scrollOffset + height
+	return strings.Join(lines[m.scrollOffset:end], "\n"), m.scrollOffset, len(lines) - end
 }
 
 func (m *editorComponent) Value() string {
@@ -377,6 +548,10 @@ func (m *editorComponent) Length() int {
 func (m *editorComponent) Submit() (tea.Model, tea.Cmd) {
 	value := strings.TrimSpace(m.Value())
 	if value == "" {
+		// the editor stays empty by default so that / still opens commands
+		if m.app.Config.Tui.EmptyEnter == opencode.ConfigTuiEmptyEnterNewline {
+			return m.Newline()
+		}
 		return m, nil
 	}
 
@@ -404,12 +579,83 @@ func (m *editorComponent) Submit() (tea.Model, tea.Cmd) {
 	m = updated.(*editorComponent)
 	cmds = append(cmds, cmd)
 
-	cmds = append(cmds, util.CmdHandler(app.SendPrompt(prompt)))
+	// the automatic context is left out of the history so that it is fetched
+	// again rather than restored stale
+	if m.app.Config.Tui.GitContext && len(m.app.Messages) == 0 && !app.HasGitContext(attachments) {
+		cmds = append(cmds, m.sendWithGitContext(prompt))
+	} else {
+		cmds = append(cmds, util.CmdHandler(app.SendPrompt(prompt)))
+	}
 	return m, tea.Batch(cmds...)
 }
 
+// sendWithGitContext runs git in the background and sends the prompt with the
+// working tree state appended, sending it unchanged when git fails or the
+// tree is clean
+func (m *editorComponent) sendWithGitContext(prompt app.Prompt) tea.Cmd {
+	return func() tea.Msg {
+		att, err := m.app.GitContext(context.Background())
+		if err != nil {
+			slog.Warn("Failed to attach git context", "error", err)
+			return app.SendPrompt(prompt)
+		}
+		if att == nil {
+			return app.SendPrompt(prompt)
+		}
+		source, _ := att.GetTextSource()
+		source.Value = "\n\n" + source.Value
+		att.StartIndex = len(prompt.Text)
+		att.EndIndex = len(prompt.Text)
+		prompt.Attachments = append(slices.Clone(prompt.Attachments), att)
+		return app.SendPrompt(prompt)
+	}
+}
+
+// AttachGitContext runs git in the background and inserts the working tree
+// state at the cursor once it arrives
+func (m *editorComponent) AttachGitContext() tea.Cmd {
+	return func() tea.Msg {
+		att, err := m.app.GitContext(context.Background())
+		if err != nil {
+			slog.Error("Failed to attach git context", "error", err)
+			return toast.NewErrorToast("Failed to read git status")()
+		}
+		if att == nil {
+			return toast.NewInfoToast("Working tree is clean")()
+		}
+		return gitContextMsg{attachment: att}
+	}
+}
+
+// AttachFile inserts a reference to the file at the cursor, returning false
+// when the editor already attaches it
+func (m *editorComponent) AttachFile(path string) bool {
+	att := m.app.FileAttachment(path)
+	source, _ := att.GetFileSource()
+	prompt := app.Prompt{Attachments: m.textarea.GetAttachments()}
+	if prompt.HasFile(source.Path) {
+		return false
+	}
+	m.textarea.InsertAttachment(att)
+	m.textarea.InsertString(" ")
+	return true
+}
+
+// ClearAttachments removes every attachment from the editor, keeping the
+// typed text, and returns how many were removed
+func (m *editorComponent) ClearAttachments() int {
+	removed := 0
+	for _, att := range m.textarea.GetAttachments() {
+		if m.textarea.RemoveAttachment(att.ID) {
+			removed++
+		}
+	}
+	return removed
+}
+
 func (m *editorComponent) Clear() (tea.Model, tea.Cmd) {
 	m.textarea.Reset()
+	m.snippet = nil
 	m.historyIndex = -1
 	m.currentText = ""
 	m.pasteCounter = 0
@@ -419,6 +665,7 @@ func (m *editorComponent) Clear() (tea.Model, tea.Cmd) {
 func (m *editorComponent) Paste() (tea.Model, tea.Cmd) {
 	imageBytes := clipboard.Read(clipboard.FmtImage)
 	if imageBytes != nil {
+		m.textarea.DeleteSelection()
 		attachmentCount := len(m.textarea.GetAttachments())
 		attachmentIndex := attachmentCount + 1
 		base64EncodedFile := base64.StdEncoding.EncodeToString(imageBytes)
@@ -442,25 +689,68 @@ func (m *editorComponent) Paste() (tea.Model, tea.Cmd) {
 
 	textBytes := clipboard.Read(clipboard.FmtText)
 	if textBytes != nil {
-		text := string(textBytes)
-		// Check if the pasted text is long and should be summarized
-		if m.shouldSummarizePastedText(text) {
-			m.handleLongPaste(text)
-		} else {
-			m.textarea.InsertRunesFromUserInput([]rune(text))
-		}
-		return m, nil
+		m.textarea.DeleteSelection()
+		return m, m.pasteText(string(textBytes))
 	}
 
 	// fallback to reading the clipboard using OSC52
 	return m, tea.ReadClipboard
 }
 
+// HasSelection reports whether text is selected in the editor
+func (m *editorComponent) HasSelection() bool {
+	return m.textarea.HasSelection()
+}
+
 func (m *editorComponent) Newline() (tea.Model, tea.Cmd) {
 	m.textarea.Newline()
 	return m, nil
 }
 
+func (m *editorComponent) InsertText(text string) {
+	m.textarea.InsertRunesFromUserInput([]rune(text))
+}
+
+// RemoveFileTrigger removes the @ that opened file completion before the
+// cursor, keeping any query typed after it
+func (m *editorComponent) RemoveFileTrigger() {
+	atIndex := m.textarea.LastRuneIndex('@')
+	if atIndex == -1 {
+		return
+	}
+	cursorCol := m.textarea.CursorColumn()
+	m.textarea.ReplaceRange(atIndex, atIndex+1, "")
+	m.textarea.SetCursorColumn(cursorCol - 1)
+}
+
+// WrapInFence wraps the selection, or the editor content when nothing is
+// selected, in a markdown code fence with the given language, which may be
+// empty. Attachments stay where they are.
+func (m *editorComponent) WrapInFence(language string) {
+	m.snippet = nil
+	if m.textarea.HasSelection() {
+		fence := codeFence(m.textarea.SelectedText())
+		m.textarea.WrapSelection(fence+language, fence)
+		return
+	}
+	fence := codeFence(m.textarea.Value())
+	m.textarea.MoveToBegin()
+	m.textarea.InsertString(fence + language + "\n")
+	m.textarea.MoveToEnd()
+	m.textarea.InsertString("\n" + fence)
+}
+
+// codeFence returns a backtick fence longer than any backtick run starting a
+// line of text, so fences within the text don't close it early
+func codeFence(text string) string {
+	longest := 0
+	for line := range strings.SplitSeq(text, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		longest = max(longest, len(trimmed)-len(strings.TrimLeft(trimmed, "`")))
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
 func (m *editorComponent) SetInterruptKeyInDebounce(inDebounce bool) {
 	m.interruptKeyInDebounce = inDebounce
 }
@@ -517,14 +807,38 @@ func (m *editorComponent) getExitKeyText() string {
 	return m.app.Commands[commands.AppExitCommand].Keys()[0]
 }
 
+// defaultPasteLongLines is the number of pasted lines above which text counts
+// as long
+const defaultPasteLongLines = 3
+
 // shouldSummarizePastedText determines if pasted text should be summarized
 func (m *editorComponent) shouldSummarizePastedText(text string) bool {
 	lines := strings.Split(text, "\n")
 	lineCount := len(lines)
 	charCount := len(text)
 
-	// Consider text long if it has more than 3 lines or more than 150 characters
-	return lineCount > 3 || charCount > 150
+	maxLines := defaultPasteLongLines
+	if m.app.Config.Tui.PasteLongLines > 0 {
+		maxLines = int(m.app.Config.Tui.PasteLongLines)
+	}
+	// Consider text long if it has more lines than that or more than 150 characters
+	return lineCount > maxLines || charCount > 150
+}
+
+// pasteText inserts pasted text, storing long text as an attachment, inserting
+// it as is, or asking which to do, as configured
+func (m *editorComponent) pasteText(text string) tea.Cmd {
+	if !m.shouldSummarizePastedText(text) {
+		return m.textarea.PasteRunes([]rune(text))
+	}
+	switch m.app.Config.Tui.PasteLong {
+	case opencode.ConfigTuiPasteLongInline:
+		return m.textarea.PasteRunes([]rune(text))
+	case opencode.ConfigTuiPasteLongAsk:
+		return util.CmdHandler(LongPasteMsg{Text: text})
+	}
+	m.handleLongPaste(text)
+	return nil
 }
 
 // handleLongPaste handles long pasted text by creating a summary attachment
@@ -587,14 +901,36 @@ func updateTextareaStyles(ta textarea.Model) textarea.Model {
 		Foreground(t.Text()).
 		Background(t.Secondary()).
 		Lipgloss()
+	ta.Styles.Selection = styles.NewStyle().
+		Foreground(t.Background()).
+		Background(t.Primary()).
+		Lipgloss()
 	ta.Styles.Cursor.Color = t.Primary()
 	return ta
 }
 
-func createSpinner() spinner.Model {
+// workingIndicator is the spinner shown while busy, or a static ellipsis when
+// motion is reduced
+func (m *editorComponent) workingIndicator() string {
+	if m.app.Config.Tui.ReducedMotion {
+		return m.spinner.Style.Render("...")
+	}
+	return m.spinner.View()
+}
+
+// cursorBlink reports whether the editor cursor should blink, which it does
+// unless turned off in config or motion is reduced
+func cursorBlink(cfg opencode.ConfigTui) bool {
+	if cfg.ReducedMotion {
+		return false
+	}
+	return cfg.JSON.CursorBlink.IsNull() || cfg.CursorBlink
+}
+
+func createSpinner(style string) spinner.Model {
 	t := theme.CurrentTheme()
 	return spinner.New(
-		spinner.WithSpinner(spinner.Ellipsis),
+		spinner.WithSpinner(util.Spinner(style, spinner.Ellipsis)),
 		spinner.WithStyle(
 			styles.NewStyle().
 				Background(t.Background()).
@@ -606,13 +942,19 @@ func createSpinner() spinner.Model {
 }
 
 func NewEditorComponent(app *app.App) EditorComponent {
-	s := createSpinner()
+	s := createSpinner(string(app.Config.Tui.Spinner))
 
 	ta := textarea.New()
 	ta.Prompt = " "
 	ta.ShowLineNumbers = false
 	ta.CharLimit = -1
+	if app.Config.Tui.EditorCharLimit > 0 {
+		ta.CharLimit = int(app.Config.Tui.EditorCharLimit)
+	}
+	ta.AutoPair = app.Config.Tui.EditorAutoPair
 	ta = updateTextareaStyles(ta)
+	ta.Styles.Cursor.Shape = util.CursorShape(string(app.Config.Tui.CursorShape))
+	ta.Styles.Cursor.Blink = cursorBlink(app.Config.Tui)
 
 	m := &editorComponent{
 		app:                    app,
@@ -632,15 +974,19 @@ func (m *editorComponent) RestoreFromHistory(index int) {
 		return
 	}
 
-	entry := m.app.State.MessageHistory[index]
+	m.SetPrompt(m.app.State.MessageHistory[index])
+}
 
+// SetPrompt replaces the editor content with the prompt's text and attachments
+func (m *editorComponent) SetPrompt(prompt app.Prompt) {
 	m.textarea.Reset()
-	m.textarea.SetValue(entry.Text)
+	m.snippet = nil
+	m.textarea.SetValue(prompt.Text)
 
 	// Sort attachments by start index in reverse order (process from end to beginning)
 	// This prevents index shifting issues
-	attachmentsCopy := make([]*attachment.Attachment, len(entry.Attachments))
-	copy(attachmentsCopy, entry.Attachments)
+	attachmentsCopy := make([]*attachment.Attachment, len(prompt.Attachments))
+	copy(attachmentsCopy, prompt.Attachments)
 
 	for i := 0; i < len(attachmentsCopy)-1; i++ {
 		for j := i + 1; j < len(attachmentsCopy); j++ {
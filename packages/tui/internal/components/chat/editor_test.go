@@ -0,0 +1,155 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/textarea"
+	"github.com/sst/opencode/internal/theme"
+)
+
+func TestSubmitEmpty(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	cases := []struct {
+		emptyEnter opencode.ConfigTuiEmptyEnter
+		want       string
+	}{
+		{"", ""},
+		{opencode.ConfigTuiEmptyEnterIgnore, ""},
+		{opencode.ConfigTuiEmptyEnterNewline, "\n"},
+	}
+	for _, tc := range cases {
+		config := &opencode.Config{}
+		config.Tui.EmptyEnter = tc.emptyEnter
+		editor := NewEditorComponent(&app.App{Config: config, State: app.NewState()})
+
+		updated, cmd := editor.Submit()
+		if cmd != nil {
+			t.Errorf("%q: expected an empty editor not to send", tc.emptyEnter)
+		}
+		if got := updated.(EditorComponent).Value(); got != tc.want {
+			t.Errorf("%q: editor value = %q, want %q", tc.emptyEnter, got, tc.want)
+		}
+	}
+}
+
+func TestCharLimit(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	config := &opencode.Config{}
+	config.Tui.EditorCharLimit = 10
+	config.Tui.EditorCharWarning = 5
+	editor := NewEditorComponent(&app.App{Config: config, State: app.NewState()}).(*editorComponent)
+
+	editor.textarea.InsertString("abcd")
+	if _, _, ok := editor.charCount(); ok {
+		t.Error("expected no count below the warning")
+	}
+	editor.textarea.InsertString("efg")
+	if count, _, ok := editor.charCount(); !ok || count != "7/10" {
+		t.Errorf("charCount() = %q, %v, want 7/10", count, ok)
+	}
+	editor.textarea.InsertString("hijklmn")
+	if got := editor.Value(); got != "abcdefghij" {
+		t.Errorf("expected input past the limit to be blocked, got %q", got)
+	}
+}
+
+func TestWrapInFence(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	cases := []struct {
+		value    string
+		language string
+		want     string
+	}{
+		{"fmt.Println(1)", "go", "```go\nfmt.Println(1)\n```"},
+		{"a\nb", "", "```\na\nb\n```"},
+		{"```js\nx\n```", "md", "````md\n```js\nx\n```\n````"},
+	}
+	for _, tc := range cases {
+		editor := NewEditorComponent(&app.App{Config: &opencode.Config{}, State: app.NewState()})
+		editor.SetValue(tc.value)
+		editor.WrapInFence(tc.language)
+		if got := editor.Value(); got != tc.want {
+			t.Errorf("WrapInFence(%q) on %q = %q, want %q", tc.language, tc.value, got, tc.want)
+		}
+	}
+
+	// the selection runs from start to the end of the line holding x := 1
+	selections := []struct {
+		value string
+		start textarea.Position
+		want  string
+	}{
+		{"see x := 1", textarea.Position{Row: 0, Col: 4}, "see \n```go\nx := 1\n```"},
+		{"before\nx := 1", textarea.Position{Row: 1, Col: 0}, "before\n```go\nx := 1\n```"},
+		{"a\nx := 1\nb", textarea.Position{Row: 1, Col: 0}, "a\n```go\nx := 1\n```\nb"},
+	}
+	for _, tc := range selections {
+		editor := NewEditorComponent(&app.App{Config: &opencode.Config{}, State: app.NewState()})
+		editor.SetValue(tc.value)
+		ta := &editor.(*editorComponent).textarea
+		ta.MoveToBegin()
+		for range tc.start.Row {
+			ta.CursorDown()
+		}
+		ta.CursorEnd()
+		ta.SelectionStart = &tc.start
+		editor.WrapInFence("go")
+		if got := editor.Value(); got != tc.want {
+			t.Errorf("WrapInFence(go) on %q selected from %v = %q, want %q", tc.value, tc.start, got, tc.want)
+		}
+	}
+}
+
+func TestPasteLong(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	long := "one\ntwo\nthree\nfour"
+	cases := []struct {
+		pasteLong opencode.ConfigTuiPasteLong
+		lines     int64
+		text      string
+		want      string
+		ask       bool
+	}{
+		{"", 0, "one\ntwo", "one\ntwo", false},
+		{"", 0, long, "[pasted #1 4+ lines] ", false},
+		{opencode.ConfigTuiPasteLongInline, 0, long, long, false},
+		{opencode.ConfigTuiPasteLongAsk, 0, long, "", true},
+		{opencode.ConfigTuiPasteLongAsk, 5, long, long, false},
+	}
+	for _, tc := range cases {
+		config := &opencode.Config{}
+		config.Tui.PasteLong = tc.pasteLong
+		config.Tui.PasteLongLines = tc.lines
+		editor := NewEditorComponent(&app.App{Config: config, State: app.NewState()}).(*editorComponent)
+
+		cmd := editor.pasteText(tc.text)
+		if got := editor.Value(); got != tc.want {
+			t.Errorf("%q with %d lines: editor value = %q, want %q", tc.pasteLong, tc.lines, got, tc.want)
+		}
+		var msg any
+		if cmd != nil {
+			msg = cmd()
+		}
+		if _, asked := msg.(LongPasteMsg); asked != tc.ask {
+			t.Errorf("%q with %d lines: asked = %v, want %v", tc.pasteLong, tc.lines, asked, tc.ask)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/diff"
+	"github.com/sst/opencode/internal/util"
+)
+
+// editProximity is how many lines away from a change a line can be and still
+// be attributed to it, matching the context git shows around changes
+const editProximity = 3
+
+// editLocation is the tool call that changed a line of a file, with the index
+// of its message in the session messages
+type editLocation struct {
+	message int
+	partID  string
+}
+
+// findEdit returns the most recent tool call that changed the file near the
+// given line. Writes replace the whole file, so they match any line.
+func findEdit(messages []app.Message, path string, line int) (editLocation, bool) {
+	path = util.Relative(path)
+	for i := len(messages) - 1; i >= 0; i-- {
+		parts := messages[i].Parts
+		for j := len(parts) - 1; j >= 0; j-- {
+			part, ok := parts[j].(opencode.ToolPart)
+			if !ok || part.State.Status != opencode.ToolPartStateStatusCompleted {
+				continue
+			}
+			input, _ := part.State.Input.(map[string]any)
+			filePath, _ := input["filePath"].(string)
+			if filePath == "" || util.Relative(filePath) != path {
+				continue
+			}
+			if part.Tool == "write" || editTouches(part, line) {
+				return editLocation{message: i, partID: part.ID}, true
+			}
+		}
+	}
+	return editLocation{}, false
+}
+
+// editTouches reports whether the diffs an edit tool call produced change the
+// file near the line
+func editTouches(part opencode.ToolPart, line int) bool {
+	metadata, _ := part.State.Metadata.(map[string]any)
+	var patches []string
+	switch part.Tool {
+	case "edit":
+		if patch, ok := metadata["diff"].(string); ok {
+			patches = append(patches, patch)
+		}
+	case "multiedit":
+		results, _ := metadata["results"].([]any)
+		for _, result := range results {
+			result, _ := result.(map[string]any)
+			if patch, ok := result["diff"].(string); ok {
+				patches = append(patches, patch)
+			}
+		}
+	}
+	for _, patch := range patches {
+		changed, err := diff.ChangedLines(patch)
+		if err != nil {
+			continue
+		}
+		for _, changedLine := range changed {
+			if changedLine >= line-editProximity && changedLine <= line+editProximity {
+				return true
+			}
+		}
+	}
+	return false
+}
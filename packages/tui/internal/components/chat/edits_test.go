@@ -0,0 +1,54 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+)
+
+func TestFindEdit(t *testing.T) {
+	editPart := func(id, tool, filePath string, metadata map[string]any) opencode.ToolPart {
+		return opencode.ToolPart{
+			ID:   id,
+			Tool: tool,
+			State: opencode.ToolPartState{
+				Status:   opencode.ToolPartStateStatusCompleted,
+				Input:    map[string]any{"filePath": filePath},
+				Metadata: metadata,
+			},
+		}
+	}
+	messages := []app.Message{
+		{Parts: []opencode.PartUnion{
+			editPart("write", "write", "main.go", nil),
+		}},
+		{Parts: []opencode.PartUnion{
+			editPart("edit", "edit", "main.go", map[string]any{
+				"diff": "--- main.go\n+++ main.go\n@@ -20,3 +20,3 @@\n a\n-b\n+c\n d\n",
+			}),
+			editPart("other", "edit", "other.go", map[string]any{
+				"diff": "--- other.go\n+++ other.go\n@@ -1,1 +1,1 @@\n-x\n+y\n",
+			}),
+		}},
+	}
+
+	cases := []struct {
+		path string
+		line int
+		want editLocation
+		ok   bool
+	}{
+		{"main.go", 21, editLocation{message: 1, partID: "edit"}, true},
+		{"main.go", 23, editLocation{message: 1, partID: "edit"}, true},
+		{"main.go", 5, editLocation{message: 0, partID: "write"}, true},
+		{"other.go", 50, editLocation{}, false},
+		{"missing.go", 1, editLocation{}, false},
+	}
+	for _, tc := range cases {
+		got, ok := findEdit(messages, tc.path, tc.line)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("findEdit(%q, %d) = %+v, %v, want %+v, %v", tc.path, tc.line, got, ok, tc.want, tc.ok)
+		}
+	}
+}
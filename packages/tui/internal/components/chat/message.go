@@ -121,6 +121,10 @@ func renderContentBlock(
 		paddingLeft:   2,
 		paddingRight:  2,
 	}
+	if app.State.CompactMessages() {
+		renderer.paddingTop = 0
+		renderer.paddingBottom = 0
+	}
 	for _, option := range options {
 		option(renderer)
 	}
@@ -180,6 +184,7 @@ func renderText(
 	text string,
 	author string,
 	showToolDetails bool,
+	raw bool,
 	width int,
 	extra string,
 	toolCalls ...opencode.ToolPart,
@@ -192,7 +197,17 @@ func renderText(
 	switch casted := message.(type) {
 	case opencode.AssistantMessage:
 		ts = time.UnixMilli(int64(casted.Time.Created))
-		content = util.ToMarkdown(text, width, backgroundColor)
+		if raw {
+			// show the markdown source as is, hard wrapped so indentation
+			// and code survive for copying
+			content = styles.NewStyle().
+				Foreground(t.Text()).
+				Background(backgroundColor).
+				Width(width - 6).
+				Render(ansi.Wrap(text, width-6, ""))
+		} else {
+			content = util.ToMarkdown(text, width, backgroundColor)
+		}
 	case opencode.UserMessage:
 		ts = time.UnixMilli(int64(casted.Time.Created))
 		base := styles.NewStyle().Foreground(t.Text()).Background(backgroundColor)
@@ -222,6 +237,11 @@ func renderText(
 	}
 	info := fmt.Sprintf("%s (%s)", author, timestamp)
 	info = styles.NewStyle().Foreground(t.TextMuted()).Render(info)
+	if casted, ok := message.(opencode.AssistantMessage); ok && !app.State.HideModelBadges {
+		info = renderModelBadge(casted) + styles.NewStyle().
+			Foreground(t.TextMuted()).
+			Render(fmt.Sprintf(" (%s)", timestamp))
+	}
 
 	if !showToolDetails && toolCalls != nil && len(toolCalls) > 0 {
 		content = content + "\n\n"
@@ -237,7 +257,11 @@ func renderText(
 		}
 	}
 
-	sections := []string{content, info}
+	sections := []string{content}
+	// compact density drops the author and timestamp line
+	if !app.State.CompactMessages() {
+		sections = append(sections, info)
+	}
 	if extra != "" {
 		sections = append(sections, "\n"+extra)
 	}
@@ -263,10 +287,60 @@ func renderText(
 	return ""
 }
 
+// renderModelBadge renders the provider and model that produced a message
+func renderModelBadge(message opencode.AssistantMessage) string {
+	t := theme.CurrentTheme()
+	providerStyle := styles.NewStyle().
+		Background(t.Secondary()).
+		Foreground(t.BackgroundPanel()).
+		Padding(0, 1)
+	modelStyle := styles.NewStyle().
+		Background(t.BackgroundElement()).
+		Foreground(t.TextMuted()).
+		Padding(0, 1)
+	return providerStyle.Render(message.ProviderID) + modelStyle.Render(message.ModelID)
+}
+
+// defaultToolOutputMaxLines is how many lines of tool output are shown unless
+// configured otherwise
+const defaultToolOutputMaxLines = 10
+
+func toolOutputMaxLines(app *app.App) int {
+	if app.Config.Tui.ToolOutputMaxLines > 0 {
+		return int(app.Config.Tui.ToolOutputMaxLines)
+	}
+	return defaultToolOutputMaxLines
+}
+
+// ToolOutput returns the full output of a tool call, the captured stdout for
+// completed bash commands and the result otherwise
+func ToolOutput(toolCall opencode.ToolPart) string {
+	if toolCall.Tool != "bash" || toolCall.State.Status == opencode.ToolPartStateStatusStreaming {
+		return toolCall.State.Output
+	}
+	metadata, ok := toolCall.State.Metadata.(map[string]any)
+	if !ok || metadata["stdout"] == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s", metadata["stdout"])
+}
+
+// truncateOutput keeps the first maxLines lines of output, also returning how
+// many lines were left out
+func truncateOutput(output string, maxLines int) (string, int) {
+	lines := strings.Split(output, "\n")
+	if len(lines) <= maxLines {
+		return output, 0
+	}
+	return strings.Join(lines[:maxLines], "\n"), len(lines) - maxLines
+}
+
 func renderToolDetails(
 	app *app.App,
 	toolCall opencode.ToolPart,
 	width int,
+	wrap bool,
+	offset int,
 ) string {
 	measure := util.Measure("chat.renderToolDetails")
 	defer measure("tool", toolCall.Tool)
@@ -303,6 +377,21 @@ func renderToolDetails(
 	backgroundColor := t.BackgroundPanel()
 	borderColor := t.BackgroundPanel()
 	defaultStyle := styles.NewStyle().Background(backgroundColor).Width(width - 6).Render
+	// when wrapping is off, long lines are cut to the block width instead,
+	// starting at the horizontal scroll offset
+	truncate := func(text string, width int) string {
+		if wrap {
+			return text
+		}
+		return truncateLines(text, offset, width)
+	}
+	// long output is cut to maxLines, noting how many lines were hidden
+	maxLines := toolOutputMaxLines(app)
+	hidden := 0
+	// colored output is kept as is when enabled, instead of being stripped
+	keepANSI := func(text string) bool {
+		return app.Config.Tui.ToolOutputAnsi && util.HasANSI(text)
+	}
 
 	if toolCall.State.Metadata != nil {
 		metadata := toolCall.State.Metadata.(map[string]any)
@@ -377,25 +466,24 @@ func renderToolDetails(
 			command := toolInputMap["command"].(string)
 			body = fmt.Sprintf("```console\n$ %s\n", command)
 
-			// For streaming tools, use State.Output; for completed tools, use metadata["stdout"]
-			var stdout interface{}
-			if toolCall.State.Status == opencode.ToolPartStateStatusStreaming {
-				stdout = toolCall.State.Output
-			} else {
-				stdout = metadata["stdout"]
-			}
-
-			if stdout != nil {
-				body += ansi.Strip(fmt.Sprintf("%s", stdout))
+			output := ToolOutput(toolCall)
+			output, hidden = truncateOutput(output, maxLines)
+			if keepANSI(output) {
+				body += "```"
+				body = util.ToMarkdown(body, width, backgroundColor)
+				body += "\n" + util.RenderANSI(output, width-6, wrap, offset, backgroundColor)
+				break
 			}
+			body += truncate(ansi.Strip(output), width-8)
 			body += "```"
 			body = util.ToMarkdown(body, width, backgroundColor)
 		case "webfetch":
 			if format, ok := toolInputMap["format"].(string); ok && result != nil {
-				body = *result
-				body = util.TruncateHeight(body, 10)
+				body, hidden = truncateOutput(*result, maxLines)
 				if format == "html" || format == "markdown" {
 					body = util.ToMarkdown(body, width, backgroundColor)
+				} else {
+					body = truncate(body, width-6)
 				}
 			}
 		case "todowrite":
@@ -435,14 +523,24 @@ func renderToolDetails(
 				body = strings.Join(steps, "\n")
 			}
 			body = defaultStyle(body)
+			if ChildSessionID(toolCall) != "" {
+				body += "\n" + styles.NewStyle().
+					Width(width-6).
+					Foreground(t.TextMuted()).
+					Background(backgroundColor).
+					Render("↳ ran in a sub-agent session, /subagent opens it")
+			}
 		default:
 			if result == nil {
 				empty := ""
 				result = &empty
 			}
-			body = *result
-			body = util.TruncateHeight(body, 10)
-			body = defaultStyle(body)
+			body, hidden = truncateOutput(*result, maxLines)
+			if keepANSI(body) {
+				body = util.RenderANSI(body, width-6, wrap, offset, backgroundColor)
+			} else {
+				body = defaultStyle(truncate(body, width-6))
+			}
 		}
 	}
 
@@ -460,20 +558,64 @@ func renderToolDetails(
 	}
 
 	if body == "" && error == "" && result != nil {
-		body = *result
-		body = util.TruncateHeight(body, 10)
-		body = defaultStyle(body)
+		body, hidden = truncateOutput(*result, maxLines)
+		if keepANSI(body) {
+			body = util.RenderANSI(body, width-6, wrap, offset, backgroundColor)
+		} else {
+			body = defaultStyle(truncate(body, width-6))
+		}
 	}
 
 	if body == "" {
 		body = defaultStyle("")
 	}
 
+	if hidden > 0 && error == "" {
+		body += "\n" + styles.NewStyle().
+			Width(width-6).
+			Foreground(t.TextMuted()).
+			Background(backgroundColor).
+			Render(fmt.Sprintf("… %d more lines, /output shows the full output", hidden))
+	}
+
 	title := renderToolTitle(toolCall, width)
 	content := title + "\n\n" + body
 	return renderContentBlock(app, content, width, WithBorderColor(borderColor))
 }
 
+// ChildSessionID returns the ID of the sub-agent session a task tool call ran
+// in, or an empty string for other tools
+func ChildSessionID(toolCall opencode.ToolPart) string {
+	if toolCall.Tool != "task" {
+		return ""
+	}
+	metadata, ok := toolCall.State.Metadata.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if id, ok := metadata["sessionId"].(string); ok {
+		return id
+	}
+	// older servers only report the session through the steps it ran
+	if summary, ok := metadata["summary"].([]any); ok && len(summary) > 0 {
+		if step, ok := summary[0].(map[string]any); ok {
+			id, _ := step["sessionID"].(string)
+			return id
+		}
+	}
+	return ""
+}
+
+// truncateLines cuts every line to width columns, starting offset columns in
+func truncateLines(text string, offset, width int) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\t", "  ")
+		lines[i] = ansi.Cut(line, offset, offset+width)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func renderToolName(name string) string {
 	switch name {
 	case "webfetch":
@@ -559,7 +701,7 @@ func renderToolTitle(
 		title = fmt.Sprintf("%s %s", title, toolArgs)
 	case "edit", "write":
 		if filename, ok := toolArgsMap["filePath"].(string); ok {
-			title = fmt.Sprintf("%s %s", title, util.Relative(filename))
+			title = fmt.Sprintf("%s %s", title, util.DisplayPath(filename))
 		}
 	case "bash", "task":
 		if description, ok := toolArgsMap["description"].(string); ok {
@@ -628,7 +770,7 @@ func renderArgs(args *map[string]any, titleKey string) string {
 			continue
 		}
 		if key == "filePath" || key == "path" {
-			value = util.Relative(value.(string))
+			value = util.DisplayPath(value.(string))
 		}
 		if key == titleKey {
 			title = fmt.Sprintf("%s", value)
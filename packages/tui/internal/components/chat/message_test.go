@@ -0,0 +1,36 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+func TestChildSessionID(t *testing.T) {
+	cases := []struct {
+		name     string
+		tool     string
+		metadata any
+		want     string
+	}{
+		{"session id", "task", map[string]any{"sessionId": "ses_child"}, "ses_child"},
+		{
+			"summary fallback",
+			"task",
+			map[string]any{"summary": []any{map[string]any{"sessionID": "ses_steps"}}},
+			"ses_steps",
+		},
+		{"empty summary", "task", map[string]any{"summary": []any{}}, ""},
+		{"no metadata", "task", nil, ""},
+		{"other tool", "bash", map[string]any{"sessionId": "ses_child"}, ""},
+	}
+	for _, tc := range cases {
+		toolCall := opencode.ToolPart{
+			Tool:  tc.tool,
+			State: opencode.ToolPartState{Metadata: tc.metadata},
+		}
+		if got := ChildSessionID(toolCall); got != tc.want {
+			t.Errorf("%s: ChildSessionID() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
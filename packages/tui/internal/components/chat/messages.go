@@ -1,9 +1,11 @@
 package chat
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
@@ -27,9 +29,22 @@ type MessagesComponent interface {
 	HalfPageUp() (tea.Model, tea.Cmd)
 	HalfPageDown() (tea.Model, tea.Cmd)
 	ToolDetailsVisible() bool
+	ToolOutputWrapped() bool
+	RawMarkdown() bool
+	SyntheticVisible() bool
 	GotoTop() (tea.Model, tea.Cmd)
 	GotoBottom() (tea.Model, tea.Cmd)
+	GotoLastError() (tea.Model, tea.Cmd)
 	CopyLastMessage() (tea.Model, tea.Cmd)
+	CopyToolInput() (tea.Model, tea.Cmd)
+	CopyCodeBlocks() (tea.Model, tea.Cmd)
+	GotoEdit(path string, line int) (tea.Model, tea.Cmd)
+	Headings() []dialog.TocHeading
+	ToolInView() (opencode.ToolPart, bool)
+	ScrollUp(lines int)
+	ScrollDown(lines int)
+	SetFocused(focused bool)
+	SetWidth(width int) (tea.Model, tea.Cmd)
 }
 
 type messagesComponent struct {
@@ -41,12 +56,36 @@ type messagesComponent struct {
 	cache           *PartCache
 	loading         bool
 	showToolDetails bool
-	rendering       bool
-	dirty           bool
-	tail            bool
-	partCount       int
-	lineCount       int
-	selection       *selection
+	wrapToolOutput  bool
+	rawMarkdown     bool
+	showSynthetic   bool
+	// scrollAnchor keeps the scroll position, as a fraction of the content,
+	// across a render that changes the content height
+	scrollAnchor *float64
+	toolOffset   int
+	rendering    bool
+	dirty        bool
+	tail         bool
+	partCount    int
+	lineCount    int
+	selection    *selection
+	tocs         []messageToc
+	// messageStarts holds the viewport line each rendered message starts on
+	messageStarts []messageStart
+	// partLines holds the viewport line of each tool call shown with details,
+	// keyed by part ID
+	partLines map[string]int
+	focused   bool
+	// partRendered holds when a part update last re-rendered each message,
+	// keyed by message ID, to throttle re-renders while it streams
+	partRendered map[string]time.Time
+	// throttled holds the IDs of messages with updates waiting on the throttle
+	throttled map[string]bool
+	// errorLines holds the viewport line of each message error, keyed by
+	// message ID
+	errorLines map[string]int
+	// highlightedError is the ID of the message whose error was jumped to
+	highlightedError string
 }
 
 type selection struct {
@@ -87,6 +126,42 @@ func (s selection) coords(offset int) *selection {
 
 type ToggleToolDetailsMsg struct{}
 
+// SetToolDetailsMsg expands or collapses the details of every tool call
+type SetToolDetailsMsg struct {
+	Visible bool
+}
+type ToggleToolOutputWrapMsg struct{}
+type ToggleModelBadgesMsg struct{}
+type ToggleRawMarkdownMsg struct{}
+type ToggleSyntheticMsg struct{}
+
+// MessageDensityChangedMsg re-renders messages after the density in state changes
+type MessageDensityChangedMsg struct{}
+
+// ClearCacheMsg drops the rendered parts and renders every message again, and
+// drops the line wrapping memoized by the editor
+type ClearCacheMsg struct{}
+
+// PathsChangedMsg re-renders messages after file paths are shortened or
+// shown in full
+type PathsChangedMsg struct{}
+
+// errorHighlightDuration is how long an error jumped to stays emphasized
+const errorHighlightDuration = 3 * time.Second
+
+// clearErrorHighlightMsg ends the emphasis of the error jumped to
+type clearErrorHighlightMsg struct {
+	messageID string
+}
+
+// throttleFlushMsg renders the part updates held back by the throttle
+type throttleFlushMsg struct{}
+
+// ScrollToolOutputMsg scrolls truncated tool output horizontally by Delta columns
+type ScrollToolOutputMsg struct {
+	Delta int
+}
+
 func (m *messagesComponent) Init() tea.Cmd {
 	return tea.Batch(m.viewport.Init())
 }
@@ -134,14 +209,8 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			)
 		}
 	case tea.WindowSizeMsg:
-		effectiveWidth := msg.Width - 4
-		// Clear cache on resize since width affects rendering
-		if m.width != effectiveWidth {
-			m.cache.Clear()
-		}
-		m.width = effectiveWidth
+		m.setWidth(msg.Width - 2*m.app.State.ContentPadding().Horizontal)
 		m.height = msg.Height - 7
-		m.viewport.SetWidth(m.width)
 		m.loading = true
 		return m, m.renderView()
 	case app.SendPrompt:
@@ -155,6 +224,45 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ToggleToolDetailsMsg:
 		m.showToolDetails = !m.showToolDetails
 		return m, m.renderView()
+	case SetToolDetailsMsg:
+		if m.showToolDetails == msg.Visible {
+			return m, nil
+		}
+		m.showToolDetails = msg.Visible
+		return m, m.renderView()
+	case ToggleModelBadgesMsg:
+		return m, m.renderView()
+	case ToggleRawMarkdownMsg:
+		m.rawMarkdown = !m.rawMarkdown
+		anchor := m.viewport.ScrollPercent()
+		m.scrollAnchor = &anchor
+		return m, m.renderView()
+	case ToggleSyntheticMsg:
+		m.showSynthetic = !m.showSynthetic
+		anchor := m.viewport.ScrollPercent()
+		m.scrollAnchor = &anchor
+		return m, m.renderView()
+	case MessageDensityChangedMsg, PathsChangedMsg, ClearCacheMsg:
+		m.cache.Clear()
+		anchor := m.viewport.ScrollPercent()
+		m.scrollAnchor = &anchor
+		return m, m.renderView()
+	case ToggleToolOutputWrapMsg:
+		m.wrapToolOutput = !m.wrapToolOutput
+		m.toolOffset = 0
+		return m, m.renderView()
+	case ScrollToolOutputMsg:
+		if m.wrapToolOutput {
+			return m, nil
+		}
+		m.toolOffset = max(0, m.toolOffset+msg.Delta)
+		return m, m.renderView()
+	case dialog.TocSelectedMsg:
+		m.viewport.SetYOffset(msg.Line)
+		m.tail = m.viewport.AtBottom()
+		return m, nil
+	case app.PromptCanceledMsg:
+		return m, m.renderView()
 	case app.SessionLoadedMsg, app.SessionClearedMsg:
 		m.cache.Clear()
 		m.tail = true
@@ -171,7 +279,20 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case opencode.EventListResponseEventMessagePartUpdated:
 		if msg.Properties.Part.SessionID == m.app.Session.ID {
-			cmds = append(cmds, m.renderView())
+			cmds = append(cmds, m.throttleRender(msg.Properties.Part.MessageID))
+		}
+	case clearErrorHighlightMsg:
+		if m.highlightedError == msg.messageID {
+			m.highlightedError = ""
+			return m, m.renderView()
+		}
+	case throttleFlushMsg:
+		if len(m.throttled) > 0 {
+			cmds = append(cmds, m.flushThrottled())
+		}
+	case opencode.EventListResponseEventSessionIdle:
+		if msg.Properties.SessionID == m.app.Session.ID && len(m.throttled) > 0 {
+			cmds = append(cmds, m.flushThrottled())
 		}
 	case renderCompleteMsg:
 		m.partCount = msg.partCount
@@ -182,6 +303,10 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.tail = m.viewport.AtBottom()
 		m.viewport = msg.viewport
 		m.header = msg.header
+		m.tocs = msg.tocs
+		m.errorLines = msg.errorLines
+		m.messageStarts = msg.messageStarts
+		m.partLines = msg.partLines
 		if m.dirty {
 			cmds = append(cmds, m.renderView())
 		}
@@ -195,12 +320,67 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// renderThrottle is the minimum time between re-renders caused by part updates
+// to the same message, zero when not throttled
+func (m *messagesComponent) renderThrottle() time.Duration {
+	return time.Duration(m.app.Config.Tui.RenderThrottle) * time.Millisecond
+}
+
+// throttleRender re-renders for a part update to the given message, unless the
+// message re-rendered within the throttle, in which case the update is held
+// back until the throttle passes or the session goes idle
+func (m *messagesComponent) throttleRender(messageID string) tea.Cmd {
+	throttle := m.renderThrottle()
+	if throttle <= 0 {
+		return m.renderView()
+	}
+	if m.partRendered == nil {
+		m.partRendered = make(map[string]time.Time)
+		m.throttled = make(map[string]bool)
+	}
+	elapsed := time.Since(m.partRendered[messageID])
+	if elapsed >= throttle {
+		m.partRendered[messageID] = time.Now()
+		return m.renderView()
+	}
+	if m.throttled[messageID] {
+		return nil
+	}
+	m.throttled[messageID] = true
+	return tea.Tick(throttle-elapsed, func(time.Time) tea.Msg {
+		return throttleFlushMsg{}
+	})
+}
+
+// flushThrottled renders the part updates held back by the throttle
+func (m *messagesComponent) flushThrottled() tea.Cmd {
+	now := time.Now()
+	for messageID := range m.throttled {
+		m.partRendered[messageID] = now
+	}
+	clear(m.throttled)
+	return m.renderView()
+}
+
 type renderCompleteMsg struct {
 	viewport  viewport.Model
 	clipboard []string
 	header    string
 	partCount int
 	lineCount int
+	tocs      []messageToc
+	// errorLines holds the viewport line of each message error, keyed by
+	// message ID
+	errorLines    map[string]int
+	messageStarts []messageStart
+	partLines     map[string]int
+}
+
+// messageStart is the viewport line a message starts on, with the message's
+// index in the session messages
+type messageStart struct {
+	index int
+	line  int
 }
 
 func (m *messagesComponent) renderView() tea.Cmd {
@@ -217,6 +397,8 @@ func (m *messagesComponent) renderView() tea.Cmd {
 
 	viewport := m.viewport
 	tail := m.tail
+	anchor := m.scrollAnchor
+	m.scrollAnchor = nil
 
 	return func() tea.Msg {
 		header := m.renderHeader()
@@ -225,6 +407,10 @@ func (m *messagesComponent) renderView() tea.Cmd {
 
 		t := theme.CurrentTheme()
 		blocks := make([]string, 0)
+		blockHeadings := make(map[int][]dialog.TocHeading)
+		blockErrors := make(map[int]string)
+		blockMessages := make([]int, 0)
+		blockParts := make(map[int]string)
 		partCount := 0
 		lineCount := 0
 
@@ -232,263 +418,55 @@ func (m *messagesComponent) renderView() tea.Cmd {
 
 		width := m.width // always use full width
 
-		for _, message := range m.app.Messages {
-			var content string
-			var cached bool
-
-			switch casted := message.Info.(type) {
-			case opencode.UserMessage:
-				for partIndex, part := range message.Parts {
-					switch part := part.(type) {
-					case opencode.TextPart:
-						if part.Synthetic {
-							continue
-						}
-						remainingParts := message.Parts[partIndex+1:]
-						fileParts := make([]opencode.FilePart, 0)
-						for _, part := range remainingParts {
-							switch part := part.(type) {
-							case opencode.FilePart:
-								fileParts = append(fileParts, part)
-							}
-						}
-						flexItems := []layout.FlexItem{}
-						if len(fileParts) > 0 {
-							fileStyle := styles.NewStyle().Background(t.BackgroundElement()).Foreground(t.TextMuted()).Padding(0, 1)
-							mediaTypeStyle := styles.NewStyle().Background(t.Secondary()).Foreground(t.BackgroundPanel()).Padding(0, 1)
-							for _, filePart := range fileParts {
-								mediaType := ""
-								switch filePart.Mime {
-								case "text/plain":
-									mediaType = "txt"
-								case "image/png", "image/jpeg", "image/gif", "image/webp":
-									mediaType = "img"
-									mediaTypeStyle = mediaTypeStyle.Background(t.Accent())
-								case "application/pdf":
-									mediaType = "pdf"
-									mediaTypeStyle = mediaTypeStyle.Background(t.Primary())
-								}
-								flexItems = append(flexItems, layout.FlexItem{
-									View: mediaTypeStyle.Render(mediaType) + fileStyle.Render(filePart.Filename),
-								})
-							}
-						}
-						bgColor := t.BackgroundPanel()
-						files := layout.Render(
-							layout.FlexOptions{
-								Background: &bgColor,
-								Width:      width - 6,
-								Direction:  layout.Column,
-							},
-							flexItems...,
-						)
-
-						key := m.cache.GenerateKey(casted.ID, part.Text, width, files)
-						content, cached = m.cache.Get(key)
-						if !cached {
-							content = renderText(
-								m.app,
-								message.Info,
-								part.Text,
-								m.app.Config.Username,
-								m.showToolDetails,
-								width,
-								files,
-							)
-							content = lipgloss.PlaceHorizontal(
-								m.width,
-								lipgloss.Center,
-								content,
-								styles.WhitespaceStyle(t.Background()),
-							)
-							m.cache.Set(key, content)
-						}
-						if content != "" {
-							partCount++
-							lineCount += lipgloss.Height(content) + 1
-							blocks = append(blocks, content)
-						}
-					}
+		for messageIndex, message := range m.app.Messages {
+			var messageBlocks []messageBlock
+			messageBlocks, orphanedToolCalls = renderMessageBlocks(
+				m.app,
+				m.cache,
+				message,
+				width,
+				m.renderOptions(),
+				orphanedToolCalls,
+			)
+			for _, block := range messageBlocks {
+				if block.error {
+					blockErrors[len(blocks)] = message.Info.(opencode.AssistantMessage).ID
+				} else {
+					partCount++
 				}
-
-			case opencode.AssistantMessage:
-				hasTextPart := false
-				for partIndex, p := range message.Parts {
-					switch part := p.(type) {
-					case opencode.TextPart:
-						hasTextPart = true
-						finished := part.Time.End > 0
-						remainingParts := message.Parts[partIndex+1:]
-						toolCallParts := make([]opencode.ToolPart, 0)
-
-						// sometimes tool calls happen without an assistant message
-						// these should be included in this assistant message as well
-						if len(orphanedToolCalls) > 0 {
-							toolCallParts = append(toolCallParts, orphanedToolCalls...)
-							orphanedToolCalls = make([]opencode.ToolPart, 0)
-						}
-
-						remaining := true
-						for _, part := range remainingParts {
-							if !remaining {
-								break
-							}
-							switch part := part.(type) {
-							case opencode.TextPart:
-								// we only want tool calls associated with the current text part.
-								// if we hit another text part, we're done.
-								remaining = false
-							case opencode.ToolPart:
-								toolCallParts = append(toolCallParts, part)
-								if part.State.Status != opencode.ToolPartStateStatusCompleted && part.State.Status != opencode.ToolPartStateStatusError {
-									// i don't think there's a case where a tool call isn't in result state
-									// and the message time is 0, but just in case
-									finished = false
-								}
-							}
-						}
-
-						if finished {
-							key := m.cache.GenerateKey(casted.ID, part.Text, width, m.showToolDetails)
-							content, cached = m.cache.Get(key)
-							if !cached {
-								content = renderText(
-									m.app,
-									message.Info,
-									part.Text,
-									casted.ModelID,
-									m.showToolDetails,
-									width,
-									"",
-									toolCallParts...,
-								)
-								content = lipgloss.PlaceHorizontal(
-									m.width,
-									lipgloss.Center,
-									content,
-									styles.WhitespaceStyle(t.Background()),
-								)
-								m.cache.Set(key, content)
-							}
-						} else {
-							content = renderText(
-								m.app,
-								message.Info,
-								part.Text,
-								casted.ModelID,
-								m.showToolDetails,
-								width,
-								"",
-								toolCallParts...,
-							)
-							content = lipgloss.PlaceHorizontal(
-								m.width,
-								lipgloss.Center,
-								content,
-								styles.WhitespaceStyle(t.Background()),
-							)
-						}
-						if content != "" {
-							partCount++
-							lineCount += lipgloss.Height(content) + 1
-							blocks = append(blocks, content)
-						}
-					case opencode.ToolPart:
-						if !m.showToolDetails {
-							if !hasTextPart {
-								orphanedToolCalls = append(orphanedToolCalls, part)
-							}
-							continue
-						}
-
-						if part.State.Status == opencode.ToolPartStateStatusCompleted || part.State.Status == opencode.ToolPartStateStatusError {
-							key := m.cache.GenerateKey(casted.ID,
-								part.ID,
-								m.showToolDetails,
-								width,
-							)
-							content, cached = m.cache.Get(key)
-							if !cached {
-								content = renderToolDetails(
-									m.app,
-									part,
-									width,
-								)
-								content = lipgloss.PlaceHorizontal(
-									m.width,
-									lipgloss.Center,
-									content,
-									styles.WhitespaceStyle(t.Background()),
-								)
-								m.cache.Set(key, content)
-							}
-						} else {
-							// if the tool call isn't finished, don't cache
-							content = renderToolDetails(
-								m.app,
-								part,
-								width,
-							)
-							content = lipgloss.PlaceHorizontal(
-								m.width,
-								lipgloss.Center,
-								content,
-								styles.WhitespaceStyle(t.Background()),
-							)
-						}
-						if content != "" {
-							partCount++
-							lineCount += lipgloss.Height(content) + 1
-							blocks = append(blocks, content)
-						}
-					}
+				lineCount += lipgloss.Height(block.content) + 1
+				blocks = append(blocks, block.content)
+				blockMessages = append(blockMessages, messageIndex)
+				if block.partID != "" {
+					blockParts[len(blocks)-1] = block.partID
 				}
-			}
-
-			error := ""
-			if assistant, ok := message.Info.(opencode.AssistantMessage); ok {
-				switch err := assistant.Error.AsUnion().(type) {
-				case nil:
-				case opencode.AssistantMessageErrorMessageOutputLengthError:
-					error = "Message output length exceeded"
-				case opencode.ProviderAuthError:
-					error = err.Data.Message
-				case opencode.MessageAbortedError:
-					error = "Request was aborted"
-				case opencode.UnknownError:
-					error = err.Data.Message
+				if len(block.headings) > 0 {
+					blockHeadings[len(blocks)-1] = block.headings
 				}
 			}
-
-			if error != "" {
-				error = styles.NewStyle().Width(width - 6).Render(error)
-				error = renderContentBlock(
-					m.app,
-					error,
-					width,
-					WithBorderColor(t.Error()),
-				)
-				error = lipgloss.PlaceHorizontal(
-					m.width,
-					lipgloss.Center,
-					error,
-					styles.WhitespaceStyle(t.Background()),
-				)
-				blocks = append(blocks, error)
-				lineCount += lipgloss.Height(error) + 1
-			}
 		}
 
 		final := []string{}
 		clipboard := []string{}
+		tocs := []messageToc{}
+		errorLines := make(map[string]int)
+		messageStarts := []messageStart{}
+		partLines := make(map[string]int)
 		var selection *selection
 		if m.selection != nil {
 			selection = m.selection.coords(lipgloss.Height(header) + 1)
 		}
-		for _, block := range blocks {
+		// blocks have a padding line above and below the text, except when compact
+		compact := m.app.State.CompactMessages()
+		for i, block := range blocks {
+			start := len(final)
+			if n := len(messageStarts); n == 0 || messageStarts[n-1].index != blockMessages[i] {
+				// content is prefixed with a newline, shifting every line down by one
+				messageStarts = append(messageStarts, messageStart{index: blockMessages[i], line: start + 1})
+			}
 			lines := strings.Split(block, "\n")
 			for index, line := range lines {
-				if selection == nil || index == 0 || index == len(lines)-1 {
+				if selection == nil || (!compact && (index == 0 || index == len(lines)-1)) {
 					final = append(final, line)
 					continue
 				}
@@ -514,29 +492,66 @@ func (m *messagesComponent) renderView() tea.Cmd {
 				}
 				final = append(final, line)
 			}
+			if messageID, ok := blockErrors[i]; ok {
+				// content is prefixed with a newline, shifting every line down by one
+				errorLines[messageID] = start + 1
+			}
+			if partID, ok := blockParts[i]; ok {
+				// content is prefixed with a newline, shifting every line down by one
+				partLines[partID] = start + 1
+			}
+			if headings, ok := blockHeadings[i]; ok {
+				// content is prefixed with a newline, shifting every line down by one
+				tocs = append(tocs, messageToc{
+					start:    start + 1,
+					end:      len(final) + 1,
+					headings: locateHeadings(headings, lines, start+1),
+				})
+			}
 			y := len(final)
 			if selection != nil && y >= selection.startY && y < selection.endY {
 				clipboard = append(clipboard, "")
 			}
-			final = append(final, "")
+			if !compact {
+				final = append(final, "")
+			}
 		}
 		content := "\n" + strings.Join(final, "\n")
 		viewport.SetHeight(m.height - lipgloss.Height(header))
 		viewport.SetContent(content)
 		if tail {
 			viewport.GotoBottom()
+		} else if anchor != nil {
+			maxOffset := max(0, viewport.TotalLineCount()-viewport.Height())
+			viewport.SetYOffset(int(*anchor * float64(maxOffset)))
 		}
 
 		return renderCompleteMsg{
-			header:    header,
-			clipboard: clipboard,
-			viewport:  viewport,
-			partCount: partCount,
-			lineCount: lineCount,
+			header:        header,
+			clipboard:     clipboard,
+			viewport:      viewport,
+			partCount:     partCount,
+			lineCount:     lineCount,
+			tocs:          tocs,
+			errorLines:    errorLines,
+			messageStarts: messageStarts,
+			partLines:     partLines,
 		}
 	}
 }
 
+// renderOptions returns the options messages are currently rendered with
+func (m *messagesComponent) renderOptions() RenderOptions {
+	return RenderOptions{
+		ShowToolDetails:  m.showToolDetails,
+		WrapToolOutput:   m.wrapToolOutput,
+		RawMarkdown:      m.rawMarkdown,
+		ShowSynthetic:    m.showSynthetic,
+		ToolOffset:       m.toolOffset,
+		HighlightedError: m.highlightedError,
+	}
+}
+
 func (m *messagesComponent) renderHeader() string {
 	if m.app.Session.ID == "" {
 		return ""
@@ -549,25 +564,13 @@ func (m *messagesComponent) renderHeader() string {
 	base := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Render
 
 	sessionInfo := ""
-	tokens := float64(0)
+	tokens := m.app.ContextTokens()
 	cost := float64(0)
 	contextWindow := m.app.Model.Limit.Context
 
 	for _, message := range m.app.Messages {
 		if assistant, ok := message.Info.(opencode.AssistantMessage); ok {
 			cost += assistant.Cost
-			usage := assistant.Tokens
-			if usage.Output > 0 {
-				if assistant.Summary {
-					tokens = usage.Output
-					continue
-				}
-				tokens = (usage.Input +
-					usage.Cache.Write +
-					usage.Cache.Read +
-					usage.Output +
-					usage.Reasoning)
-			}
 		}
 	}
 
@@ -587,8 +590,13 @@ func (m *messagesComponent) renderHeader() string {
 		t.Background(),
 	)
 
+	// sub-agent sessions are shared with their parent, so point back to it
+	isChild := m.app.Session.ParentID != ""
 	var items []layout.FlexItem
-	if shareEnabled {
+	if isChild {
+		parent := muted("sub-agent session  ") + base("/parent") + muted(" to return")
+		items = []layout.FlexItem{{View: parent}, {View: sessionInfo}}
+	} else if shareEnabled {
 		share := base("/share") + muted(" to create a shareable link")
 		if m.app.Session.Share.URL != "" {
 			share = muted(m.app.Session.Share.URL + "  /unshare")
@@ -611,12 +619,18 @@ func (m *messagesComponent) renderHeader() string {
 	)
 
 	var headerLines []string
-	if shareEnabled {
+	if shareEnabled || isChild {
 		headerLines = []string{headerText, headerRow}
 	} else {
 		headerLines = []string{headerRow}
 	}
 
+	// highlight the header border while the messages have keyboard focus
+	borderColor := t.BackgroundElement()
+	if m.focused {
+		borderColor = t.Primary()
+	}
+
 	header := strings.Join(headerLines, "\n")
 	header = styles.NewStyle().
 		Background(t.Background()).
@@ -626,7 +640,7 @@ func (m *messagesComponent) renderHeader() string {
 		BorderLeft(true).
 		BorderRight(true).
 		BorderBackground(t.Background()).
-		BorderForeground(t.BackgroundElement()).
+		BorderForeground(borderColor).
 		BorderStyle(lipgloss.ThickBorder()).
 		Render(header)
 	header = lipgloss.PlaceHorizontal(
@@ -707,6 +721,40 @@ func (m *messagesComponent) View() string {
 		Render(m.header + "\n" + viewport)
 }
 
+func (m *messagesComponent) ScrollUp(lines int) {
+	m.viewport.LineUp(lines)
+}
+
+func (m *messagesComponent) ScrollDown(lines int) {
+	m.viewport.LineDown(lines)
+}
+
+func (m *messagesComponent) SetFocused(focused bool) {
+	m.focused = focused
+	m.header = m.renderHeader()
+}
+
+// SetWidth narrows or widens the messages, such as when the file viewer opens
+// beside them, re-rendering only when the width changes
+func (m *messagesComponent) SetWidth(width int) (tea.Model, tea.Cmd) {
+	if m.width == width {
+		return m, nil
+	}
+	m.setWidth(width)
+	anchor := m.viewport.ScrollPercent()
+	m.scrollAnchor = &anchor
+	return m, m.renderView()
+}
+
+func (m *messagesComponent) setWidth(width int) {
+	// Clear cache on resize since width affects rendering
+	if m.width != width {
+		m.cache.Clear()
+	}
+	m.width = width
+	m.viewport.SetWidth(m.width)
+}
+
 func (m *messagesComponent) PageUp() (tea.Model, tea.Cmd) {
 	m.viewport.ViewUp()
 	return m, nil
@@ -731,6 +779,18 @@ func (m *messagesComponent) ToolDetailsVisible() bool {
 	return m.showToolDetails
 }
 
+func (m *messagesComponent) ToolOutputWrapped() bool {
+	return m.wrapToolOutput
+}
+
+func (m *messagesComponent) RawMarkdown() bool {
+	return m.rawMarkdown
+}
+
+func (m *messagesComponent) SyntheticVisible() bool {
+	return m.showSynthetic
+}
+
 func (m *messagesComponent) GotoTop() (tea.Model, tea.Cmd) {
 	m.viewport.GotoTop()
 	return m, nil
@@ -741,6 +801,31 @@ func (m *messagesComponent) GotoBottom() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// GotoLastError scrolls to the error of the most recent failed message and
+// emphasizes it for a few seconds
+func (m *messagesComponent) GotoLastError() (tea.Model, tea.Cmd) {
+	for i := len(m.app.Messages) - 1; i >= 0; i-- {
+		assistant, ok := m.app.Messages[i].Info.(opencode.AssistantMessage)
+		if !ok || assistant.Error.AsUnion() == nil {
+			continue
+		}
+		line, ok := m.errorLines[assistant.ID]
+		if !ok {
+			continue
+		}
+		m.viewport.SetYOffset(max(0, line-m.viewport.Height()/2))
+		m.tail = m.viewport.AtBottom()
+		m.highlightedError = assistant.ID
+		return m, tea.Batch(
+			m.renderView(),
+			tea.Tick(errorHighlightDuration, func(time.Time) tea.Msg {
+				return clearErrorHighlightMsg{messageID: assistant.ID}
+			}),
+		)
+	}
+	return m, toast.NewInfoToast("No errors in this session")
+}
+
 func (m *messagesComponent) CopyLastMessage() (tea.Model, tea.Cmd) {
 	if len(m.app.Messages) == 0 {
 		return m, nil
@@ -761,6 +846,140 @@ func (m *messagesComponent) CopyLastMessage() (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// CopyToolInput copies the input arguments of the latest tool call as indented
+// JSON, so the invocation can be reproduced by hand
+func (m *messagesComponent) CopyToolInput() (tea.Model, tea.Cmd) {
+	if !m.showToolDetails {
+		return m, toast.NewInfoToast("Show tool details to copy tool input")
+	}
+	for i := len(m.app.Messages) - 1; i >= 0; i-- {
+		parts := m.app.Messages[i].Parts
+		for j := len(parts) - 1; j >= 0; j-- {
+			part, ok := parts[j].(opencode.ToolPart)
+			if !ok || part.State.Input == nil {
+				continue
+			}
+			input, err := json.MarshalIndent(part.State.Input, "", "  ")
+			if err != nil {
+				slog.Error("Failed to encode tool input", "tool", part.Tool, "error", err)
+				return m, toast.NewErrorToast("Failed to copy tool input")
+			}
+			var cmds []tea.Cmd
+			cmds = append(cmds, app.SetClipboard(string(input)))
+			cmds = append(cmds, toast.NewSuccessToast(
+				fmt.Sprintf("Copied %s input to clipboard", part.Tool),
+			))
+			return m, tea.Batch(cmds...)
+		}
+	}
+	return m, toast.NewInfoToast("No tool calls to copy")
+}
+
+// CopyCodeBlocks copies the fenced code blocks of the message at the middle
+// of the viewport, separated by blank lines and without their fences
+func (m *messagesComponent) CopyCodeBlocks() (tea.Model, tea.Cmd) {
+	index := m.currentMessage()
+	if index < 0 || index >= len(m.app.Messages) {
+		return m, toast.NewInfoToast("No message to copy code from")
+	}
+	blocks := parseCodeBlocks(m.app.Messages[index].Text())
+	if len(blocks) == 0 {
+		return m, toast.NewInfoToast("No code blocks in this message")
+	}
+	var cmds []tea.Cmd
+	cmds = append(cmds, app.SetClipboard(strings.Join(blocks, "\n\n")))
+	cmds = append(cmds, toast.NewSuccessToast(
+		fmt.Sprintf("Copied %s to clipboard", util.Pluralize(len(blocks), "code block")),
+	))
+	return m, tea.Batch(cmds...)
+}
+
+// GotoEdit scrolls to the tool call that most recently changed the file near
+// the line, or to its message when tool details are hidden
+func (m *messagesComponent) GotoEdit(path string, line int) (tea.Model, tea.Cmd) {
+	edit, ok := findEdit(m.app.Messages, path, line)
+	if !ok {
+		return m, toast.NewInfoToast(fmt.Sprintf("No edit in this session changed line %d", line))
+	}
+	target, ok := m.partLines[edit.partID]
+	if !ok {
+		for _, start := range m.messageStarts {
+			if start.index == edit.message {
+				target, ok = start.line, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return m, toast.NewInfoToast("The edit is not shown in the messages")
+	}
+	m.viewport.SetYOffset(target)
+	m.tail = m.viewport.AtBottom()
+	return m, nil
+}
+
+// currentMessage returns the index of the message at the middle of the
+// viewport, or -1 when nothing is rendered
+func (m *messagesComponent) currentMessage() int {
+	middle := m.viewport.YOffset + m.viewport.Height()/2
+	current := -1
+	for _, start := range m.messageStarts {
+		if start.line > middle && current >= 0 {
+			break
+		}
+		current = start.index
+	}
+	return current
+}
+
+// Headings returns the headings of the message at the middle of the viewport,
+// falling back to the closest message above it
+func (m *messagesComponent) Headings() []dialog.TocHeading {
+	middle := m.viewport.YOffset + m.viewport.Height()/2
+	var current *messageToc
+	for i, toc := range m.tocs {
+		if toc.start > middle && current != nil {
+			break
+		}
+		current = &m.tocs[i]
+	}
+	if current == nil {
+		return nil
+	}
+	return current.headings
+}
+
+// ToolInView returns the tool call with output closest above the middle of
+// the viewport, falling back to the first one below it, or to the latest
+// tool call when tool details are hidden
+func (m *messagesComponent) ToolInView() (opencode.ToolPart, bool) {
+	middle := m.viewport.YOffset + m.viewport.Height()/2
+	var above, below, latest *opencode.ToolPart
+	for _, message := range m.app.Messages {
+		for _, part := range message.Parts {
+			tool, ok := part.(opencode.ToolPart)
+			if !ok || ToolOutput(tool) == "" {
+				continue
+			}
+			latest = &tool
+			line, ok := m.partLines[tool.ID]
+			switch {
+			case !ok:
+			case line <= middle:
+				above = &tool
+			case below == nil:
+				below = &tool
+			}
+		}
+	}
+	for _, tool := range []*opencode.ToolPart{above, below, latest} {
+		if tool != nil {
+			return *tool, true
+		}
+	}
+	return opencode.ToolPart{}, false
+}
+
 func NewMessagesComponent(app *app.App) MessagesComponent {
 	vp := viewport.New()
 	vp.KeyMap = viewport.KeyMap{}
@@ -770,6 +989,7 @@ func NewMessagesComponent(app *app.App) MessagesComponent {
 		app:             app,
 		viewport:        vp,
 		showToolDetails: true,
+		wrapToolOutput:  true,
 		cache:           NewPartCache(),
 		tail:            true,
 	}
@@ -0,0 +1,46 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+)
+
+func TestThrottleRender(t *testing.T) {
+	config := &opencode.Config{}
+	config.Tui.RenderThrottle = 60_000
+	m := &messagesComponent{app: &app.App{Config: config}, cache: NewPartCache()}
+
+	if m.throttleRender("msg_1"); !m.rendering || len(m.throttled) != 0 {
+		t.Fatal("expected the first update to render right away")
+	}
+	m.rendering = false
+
+	if cmd := m.throttleRender("msg_1"); cmd == nil || m.rendering || !m.throttled["msg_1"] {
+		t.Fatal("expected a second update within the throttle to be held back")
+	}
+	if cmd := m.throttleRender("msg_1"); cmd != nil {
+		t.Fatal("expected a held back message not to schedule another flush")
+	}
+	if m.throttleRender("msg_2"); !m.rendering {
+		t.Fatal("expected other messages not to be throttled")
+	}
+	m.rendering = false
+
+	m.flushThrottled()
+	if !m.rendering || len(m.throttled) != 0 {
+		t.Fatal("expected flushing to render and clear held back updates")
+	}
+}
+
+func TestThrottleRenderDisabled(t *testing.T) {
+	m := &messagesComponent{app: &app.App{Config: &opencode.Config{}}, cache: NewPartCache()}
+	for range 3 {
+		m.rendering = false
+		m.throttleRender("msg_1")
+		if !m.rendering {
+			t.Fatal("expected every update to render without a throttle")
+		}
+	}
+}
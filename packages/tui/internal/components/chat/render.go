@@ -0,0 +1,381 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/dialog"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// RenderOptions controls how RenderMessage lays out a message
+type RenderOptions struct {
+	ShowToolDetails bool
+	WrapToolOutput  bool
+	RawMarkdown     bool
+	// ToolOffset scrolls truncated tool output horizontally
+	ToolOffset int
+	// HighlightedError is the ID of the message whose error is emphasized
+	HighlightedError string
+	// ShowSynthetic shows the text parts injected into user messages, such as
+	// file contents, which are normally hidden
+	ShowSynthetic bool
+}
+
+// messageBlock is a rendered part of a message
+type messageBlock struct {
+	content string
+	// headings are the markdown headings in the part's text
+	headings []dialog.TocHeading
+	// error is set for the block reporting a failed message
+	error bool
+	// partID is set for the block showing the details of a tool call
+	partID string
+}
+
+// renderNote renders a note as a rule across the conversation, labeled with
+// its text unless it is a plain separator
+func renderNote(note string, width int) string {
+	t := theme.CurrentTheme()
+	style := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background())
+	note = strings.Join(strings.Fields(note), " ")
+	if note == "" || note == app.NoteSeparator {
+		return style.Render(strings.Repeat("─", width))
+	}
+	label := " " + ansi.Truncate(note, max(0, width-8), "…") + " "
+	left := max(0, width-ansi.StringWidth(label)) / 2
+	right := max(0, width-ansi.StringWidth(label)-left)
+	return style.Render(strings.Repeat("─", left)) +
+		style.Foreground(t.Text()).Render(label) +
+		style.Render(strings.Repeat("─", right))
+}
+
+// RenderMessage renders a message at the given width the way the messages
+// view shows it, without caching, so rendering can be tested outside the
+// Bubble Tea loop
+func RenderMessage(app *app.App, message app.Message, width int, opts RenderOptions) string {
+	blocks, _ := renderMessageBlocks(app, nil, message, width, opts, nil)
+	contents := make([]string, len(blocks))
+	for i, block := range blocks {
+		contents[i] = block.content
+	}
+	separator := "\n\n"
+	if app.State.CompactMessages() {
+		separator = "\n"
+	}
+	return strings.Join(contents, separator)
+}
+
+// renderMessageBlocks renders the parts of a message, caching finished parts
+// when cache isn't nil. Tool calls from earlier messages without a text part
+// are passed in as orphaned and shown with the next text part, and any still
+// waiting for one are returned.
+func renderMessageBlocks(
+	app *app.App,
+	cache *PartCache,
+	message app.Message,
+	width int,
+	opts RenderOptions,
+	orphanedToolCalls []opencode.ToolPart,
+) ([]messageBlock, []opencode.ToolPart) {
+	t := theme.CurrentTheme()
+	blocks := make([]messageBlock, 0)
+
+	var content string
+	var cached bool
+
+	switch casted := message.Info.(type) {
+	case opencode.UserMessage:
+		if note, ok := message.Note(); ok {
+			blocks = append(blocks, messageBlock{content: renderNote(note, width)})
+			break
+		}
+		for partIndex, part := range message.Parts {
+			switch part := part.(type) {
+			case opencode.TextPart:
+				if part.Synthetic {
+					if !opts.ShowSynthetic {
+						continue
+					}
+					key := cache.GenerateKey(casted.ID, "synthetic", part.Text, width)
+					content, cached = cache.Get(key)
+					if !cached {
+						content = renderText(
+							app,
+							message.Info,
+							part.Text,
+							"synthetic",
+							opts.ShowToolDetails,
+							opts.RawMarkdown,
+							width,
+							"",
+						)
+						content = lipgloss.PlaceHorizontal(
+							width,
+							lipgloss.Center,
+							content,
+							styles.WhitespaceStyle(t.Background()),
+						)
+						cache.Set(key, content)
+					}
+					blocks = append(blocks, messageBlock{content: content})
+					continue
+				}
+				remainingParts := message.Parts[partIndex+1:]
+				fileParts := make([]opencode.FilePart, 0)
+				for _, part := range remainingParts {
+					switch part := part.(type) {
+					case opencode.FilePart:
+						fileParts = append(fileParts, part)
+					}
+				}
+				flexItems := []layout.FlexItem{}
+				if len(fileParts) > 0 {
+					fileStyle := styles.NewStyle().Background(t.BackgroundElement()).Foreground(t.TextMuted()).Padding(0, 1)
+					mediaTypeStyle := styles.NewStyle().Background(t.Secondary()).Foreground(t.BackgroundPanel()).Padding(0, 1)
+					for _, filePart := range fileParts {
+						mediaType := ""
+						switch filePart.Mime {
+						case "text/plain":
+							mediaType = "txt"
+						case "image/png", "image/jpeg", "image/gif", "image/webp":
+							mediaType = "img"
+							mediaTypeStyle = mediaTypeStyle.Background(t.Accent())
+						case "application/pdf":
+							mediaType = "pdf"
+							mediaTypeStyle = mediaTypeStyle.Background(t.Primary())
+						}
+						flexItems = append(flexItems, layout.FlexItem{
+							View: mediaTypeStyle.Render(mediaType) + fileStyle.Render(util.DisplayPath(filePart.Filename)),
+						})
+					}
+				}
+				bgColor := t.BackgroundPanel()
+				files := layout.Render(
+					layout.FlexOptions{
+						Background: &bgColor,
+						Width:      width - 6,
+						Direction:  layout.Column,
+					},
+					flexItems...,
+				)
+
+				key := cache.GenerateKey(casted.ID, part.Text, width, files)
+				content, cached = cache.Get(key)
+				if !cached {
+					content = renderText(
+						app,
+						message.Info,
+						part.Text,
+						app.Config.Username,
+						opts.ShowToolDetails,
+						opts.RawMarkdown,
+						width,
+						files,
+					)
+					content = lipgloss.PlaceHorizontal(
+						width,
+						lipgloss.Center,
+						content,
+						styles.WhitespaceStyle(t.Background()),
+					)
+					cache.Set(key, content)
+				}
+				if content != "" {
+					blocks = append(blocks, messageBlock{content: content})
+				}
+			}
+		}
+
+	case opencode.AssistantMessage:
+		hasTextPart := false
+		for partIndex, p := range message.Parts {
+			switch part := p.(type) {
+			case opencode.TextPart:
+				hasTextPart = true
+				finished := part.Time.End > 0
+				remainingParts := message.Parts[partIndex+1:]
+				toolCallParts := make([]opencode.ToolPart, 0)
+
+				// sometimes tool calls happen without an assistant message
+				// these should be included in this assistant message as well
+				if len(orphanedToolCalls) > 0 {
+					toolCallParts = append(toolCallParts, orphanedToolCalls...)
+					orphanedToolCalls = make([]opencode.ToolPart, 0)
+				}
+
+				remaining := true
+				for _, part := range remainingParts {
+					if !remaining {
+						break
+					}
+					switch part := part.(type) {
+					case opencode.TextPart:
+						// we only want tool calls associated with the current text part.
+						// if we hit another text part, we're done.
+						remaining = false
+					case opencode.ToolPart:
+						toolCallParts = append(toolCallParts, part)
+						if part.State.Status != opencode.ToolPartStateStatusCompleted && part.State.Status != opencode.ToolPartStateStatusError {
+							// i don't think there's a case where a tool call isn't in result state
+							// and the message time is 0, but just in case
+							finished = false
+						}
+					}
+				}
+
+				if finished {
+					key := cache.GenerateKey(
+						casted.ID,
+						part.Text,
+						width,
+						opts.ShowToolDetails,
+						app.State.HideModelBadges,
+						opts.RawMarkdown,
+					)
+					content, cached = cache.Get(key)
+					if !cached {
+						content = renderText(
+							app,
+							message.Info,
+							part.Text,
+							casted.ModelID,
+							opts.ShowToolDetails,
+							opts.RawMarkdown,
+							width,
+							"",
+							toolCallParts...,
+						)
+						content = lipgloss.PlaceHorizontal(
+							width,
+							lipgloss.Center,
+							content,
+							styles.WhitespaceStyle(t.Background()),
+						)
+						cache.Set(key, content)
+					}
+				} else {
+					content = renderText(
+						app,
+						message.Info,
+						part.Text,
+						casted.ModelID,
+						opts.ShowToolDetails,
+						opts.RawMarkdown,
+						width,
+						"",
+						toolCallParts...,
+					)
+					content = lipgloss.PlaceHorizontal(
+						width,
+						lipgloss.Center,
+						content,
+						styles.WhitespaceStyle(t.Background()),
+					)
+				}
+				if content != "" {
+					blocks = append(blocks, messageBlock{
+						content:  content,
+						headings: parseHeadings(part.Text),
+					})
+				}
+			case opencode.ToolPart:
+				if !opts.ShowToolDetails {
+					if !hasTextPart {
+						orphanedToolCalls = append(orphanedToolCalls, part)
+					}
+					continue
+				}
+
+				if part.State.Status == opencode.ToolPartStateStatusCompleted || part.State.Status == opencode.ToolPartStateStatusError {
+					key := cache.GenerateKey(casted.ID,
+						part.ID,
+						opts.ShowToolDetails,
+						opts.WrapToolOutput,
+						opts.ToolOffset,
+						width,
+					)
+					content, cached = cache.Get(key)
+					if !cached {
+						content = renderToolDetails(
+							app,
+							part,
+							width,
+							opts.WrapToolOutput,
+							opts.ToolOffset,
+						)
+						content = lipgloss.PlaceHorizontal(
+							width,
+							lipgloss.Center,
+							content,
+							styles.WhitespaceStyle(t.Background()),
+						)
+						cache.Set(key, content)
+					}
+				} else {
+					// if the tool call isn't finished, don't cache
+					content = renderToolDetails(
+						app,
+						part,
+						width,
+						opts.WrapToolOutput,
+						opts.ToolOffset,
+					)
+					content = lipgloss.PlaceHorizontal(
+						width,
+						lipgloss.Center,
+						content,
+						styles.WhitespaceStyle(t.Background()),
+					)
+				}
+				if content != "" {
+					blocks = append(blocks, messageBlock{content: content, partID: part.ID})
+				}
+			}
+		}
+	}
+
+	error := ""
+	if assistant, ok := message.Info.(opencode.AssistantMessage); ok {
+		switch err := assistant.Error.AsUnion().(type) {
+		case nil:
+		case opencode.AssistantMessageErrorMessageOutputLengthError:
+			error = "Message output length exceeded"
+		case opencode.ProviderAuthError:
+			error = err.Data.Message + "\n\n" + app.AuthHint(err.Data.ProviderID)
+		case opencode.MessageAbortedError:
+			error = "Request was aborted"
+		case opencode.UnknownError:
+			error = err.Data.Message
+		}
+	}
+
+	if error != "" {
+		errorStyle := styles.NewStyle().Width(width - 6)
+		if opts.HighlightedError != "" && opts.HighlightedError == message.Info.(opencode.AssistantMessage).ID {
+			errorStyle = errorStyle.Foreground(t.Error()).Bold(true)
+		}
+		error = errorStyle.Render(error)
+		error = renderContentBlock(
+			app,
+			error,
+			width,
+			WithBorderColor(t.Error()),
+		)
+		error = lipgloss.PlaceHorizontal(
+			width,
+			lipgloss.Center,
+			error,
+			styles.WhitespaceStyle(t.Background()),
+		)
+		blocks = append(blocks, messageBlock{content: error, error: true})
+	}
+
+	return blocks, orphanedToolCalls
+}
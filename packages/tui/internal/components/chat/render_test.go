@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/theme"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// loadMessage reads a message in the shape the server returns it
+func loadMessage(t *testing.T, path string) app.Message {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response opencode.SessionMessagesResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	message := app.Message{Info: response.Info.AsUnion()}
+	for _, part := range response.Parts {
+		message.Parts = append(message.Parts, part.AsUnion())
+	}
+	return message
+}
+
+func TestRenderMessageGolden(t *testing.T) {
+	time.Local = time.UTC
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+	testApp := &app.App{
+		Config: &opencode.Config{},
+		State:  app.NewState(),
+	}
+
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "messages", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		t.Run(name, func(t *testing.T) {
+			message := loadMessage(t, fixture)
+			got := RenderMessage(testApp, message, 80, RenderOptions{ShowToolDetails: true})
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(expected) {
+				t.Errorf("rendered message differs from %s, run with -update to accept:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestRenderSyntheticParts(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+	testApp := &app.App{
+		Config: &opencode.Config{},
+		State:  app.NewState(),
+	}
+	message := loadMessage(t, filepath.Join("testdata", "messages", "user_synthetic.json"))
+
+	hidden := ansi.Strip(RenderMessage(testApp, message, 80, RenderOptions{}))
+	if strings.Contains(hidden, "Read tool") {
+		t.Errorf("synthetic part rendered while hidden:\n%s", hidden)
+	}
+	shown := ansi.Strip(RenderMessage(testApp, message, 80, RenderOptions{ShowSynthetic: true}))
+	if !strings.Contains(shown, "Read tool") || !strings.Contains(shown, "synthetic") {
+		t.Errorf("synthetic part missing when shown:\n%s", shown)
+	}
+}
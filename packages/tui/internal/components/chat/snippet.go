@@ -0,0 +1,159 @@
+package chat
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// snippetPlaceholder matches tab stops in a snippet: $1, ${1} or ${1:default}.
+// $0 marks where the cursor ends up once every other stop has been visited.
+var snippetPlaceholder = regexp.MustCompile(`\$(?:(\d+)|\{(\d+)(?::([^}]*))?\})`)
+
+// snippetField is a tab stop in an expanded snippet
+type snippetField struct {
+	index    int
+	row, col int
+	length   int
+}
+
+// snippetSession tracks the tab stops of the last expanded snippet while the
+// user tabs through them
+type snippetSession struct {
+	fields  []snippetField
+	current int
+	// pristine reports whether the current field still holds its default
+	// text, which is replaced as soon as the user types
+	pristine bool
+	// rows and rowLength snapshot the textarea when the current field was
+	// entered, so edits to it can be applied to the fields after it
+	rows, rowLength int
+}
+
+// snippetPart is a run of literal text followed by an optional tab stop
+type snippetPart struct {
+	text     string
+	index    int
+	value    string
+	hasField bool
+}
+
+// parseSnippet splits a template into literal text and tab stops
+func parseSnippet(template string) []snippetPart {
+	parts := []snippetPart{}
+	last := 0
+	for _, match := range snippetPlaceholder.FindAllStringSubmatchIndex(template, -1) {
+		var number string
+		if match[2] != -1 {
+			number = template[match[2]:match[3]]
+		} else {
+			number = template[match[4]:match[5]]
+		}
+		index, _ := strconv.Atoi(number)
+		value := ""
+		if match[6] != -1 {
+			value = template[match[6]:match[7]]
+		}
+		parts = append(parts, snippetPart{
+			text:     template[last:match[0]],
+			index:    index,
+			value:    value,
+			hasField: true,
+		})
+		last = match[1]
+	}
+	return append(parts, snippetPart{text: template[last:]})
+}
+
+// insertSnippet inserts a snippet template at the cursor and moves to its
+// first tab stop. Without tab stops the cursor is left after the snippet.
+func (m *editorComponent) insertSnippet(template string) {
+	fields := []snippetField{}
+	for _, part := range parseSnippet(template) {
+		m.textarea.InsertString(part.text)
+		if !part.hasField {
+			continue
+		}
+		fields = append(fields, snippetField{
+			index:  part.index,
+			row:    m.textarea.Line(),
+			col:    m.textarea.CursorColumn(),
+			length: utf8.RuneCountInString(part.value),
+		})
+		m.textarea.InsertString(part.value)
+	}
+	if len(fields) == 0 {
+		m.snippet = nil
+		return
+	}
+
+	// visit stops in order, with $0 last
+	sort.SliceStable(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+	m.snippet = &snippetSession{fields: fields, current: -1}
+	m.NextSnippetField()
+}
+
+// InSnippet reports whether a snippet has tab stops left to visit
+func (m *editorComponent) InSnippet() bool {
+	return m.snippet != nil
+}
+
+// NextSnippetField moves the cursor to the next tab stop of the expanded
+// snippet, ending the snippet after the last one
+func (m *editorComponent) NextSnippetField() {
+	s := m.snippet
+	if s == nil {
+		return
+	}
+	if s.current >= 0 {
+		// shift the remaining stops by whatever was typed into this one
+		field := s.fields[s.current]
+		rowDelta := m.textarea.RowCount() - s.rows
+		colDelta := m.textarea.RowLength(field.row) - s.rowLength
+		for i := s.current + 1; i < len(s.fields); i++ {
+			next := &s.fields[i]
+			if next.row == field.row && next.col >= field.col {
+				next.col += colDelta
+			} else if next.row > field.row {
+				next.row += rowDelta
+			}
+		}
+	}
+	s.current++
+	if s.current >= len(s.fields) {
+		m.snippet = nil
+		return
+	}
+
+	field := s.fields[s.current]
+	m.textarea.SetCursorPosition(field.row, field.col)
+	s.pristine = field.length > 0
+	s.rows = m.textarea.RowCount()
+	s.rowLength = m.textarea.RowLength(field.row)
+	if s.current == len(s.fields)-1 && field.length == 0 {
+		// nothing left to fill in
+		m.snippet = nil
+	}
+}
+
+// replaceSnippetDefault clears the default text of the current tab stop when
+// the user starts typing over it
+func (m *editorComponent) replaceSnippetDefault() {
+	s := m.snippet
+	if s == nil || !s.pristine {
+		return
+	}
+	s.pristine = false
+	field := s.fields[s.current]
+	if m.textarea.Line() != field.row || m.textarea.CursorColumn() != field.col {
+		return
+	}
+	m.textarea.ReplaceRange(field.col, field.col+field.length, "")
+}
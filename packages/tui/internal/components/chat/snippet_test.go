@@ -0,0 +1,21 @@
+package chat
+
+import "testing"
+
+func TestParseSnippet(t *testing.T) {
+	parts := parseSnippet("fix ${1:the bug} in $2 then ${0}")
+	expected := []snippetPart{
+		{text: "fix ", index: 1, value: "the bug", hasField: true},
+		{text: " in ", index: 2, hasField: true},
+		{text: " then ", index: 0, hasField: true},
+		{text: ""},
+	}
+	if len(parts) != len(expected) {
+		t.Fatalf("expected %d parts, got %d: %+v", len(expected), len(parts), parts)
+	}
+	for i, part := range parts {
+		if part != expected[i] {
+			t.Errorf("part %d: expected %+v, got %+v", i, expected[i], part)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/components/dialog"
+)
+
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)(?:\s+#+)?\s*$`)
+var inlineMarkdown = strings.NewReplacer("**", "", "__", "", "`", "", "*", "", "~~", "")
+
+// messageToc holds the headings of one rendered block and the viewport lines
+// the block spans
+type messageToc struct {
+	start    int
+	end      int
+	headings []dialog.TocHeading
+}
+
+// parseHeadings extracts ATX headings from markdown, skipping fenced code
+func parseHeadings(text string) []dialog.TocHeading {
+	headings := []dialog.TocHeading{}
+	fence := ""
+	for line := range strings.SplitSeq(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		match := headingPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		headings = append(headings, dialog.TocHeading{
+			Level: len(match[1]),
+			Title: inlineMarkdown.Replace(match[2]),
+		})
+	}
+	return headings
+}
+
+// locateHeadings assigns each heading the line it was rendered on, searching
+// in order so repeated titles resolve to successive occurrences
+func locateHeadings(headings []dialog.TocHeading, lines []string, offset int) []dialog.TocHeading {
+	located := []dialog.TocHeading{}
+	next := 0
+	for _, heading := range headings {
+		prefix := strings.Repeat("#", heading.Level) + " "
+		words := strings.Fields(heading.Title)
+		for i := next; i < len(lines); i++ {
+			text := strings.TrimSpace(strings.Trim(strings.TrimSpace(ansi.Strip(lines[i])), "┃"))
+			if !strings.HasPrefix(text, prefix) {
+				continue
+			}
+			if len(words) > 0 && !strings.HasPrefix(text[len(prefix):], words[0]) {
+				continue
+			}
+			heading.Line = offset + i
+			located = append(located, heading)
+			next = i + 1
+			break
+		}
+	}
+	return located
+}
@@ -0,0 +1,174 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	list "github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// CompareMessagesMsg is sent when two responses are marked for comparison,
+// holding their indexes in the session messages, earliest first
+type CompareMessagesMsg struct {
+	Before int
+	After  int
+}
+
+// CompareDialog interface for marking two responses to diff
+type CompareDialog interface {
+	layout.Modal
+}
+
+type compareDialog struct {
+	width     int
+	height    int
+	modal     *modal.Modal
+	list      list.List[list.Item]
+	responses []compareResponse
+	marked    []int
+}
+
+// compareResponse is an assistant message with text that can be compared
+type compareResponse struct {
+	index   int
+	preview string
+}
+
+func (c *compareDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *compareDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+		c.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "space":
+			if _, idx := c.list.GetSelectedItem(); idx >= 0 && idx < len(c.responses) {
+				return c, c.toggle(idx)
+			}
+		}
+	}
+
+	listModel, cmd := c.list.Update(msg)
+	c.list = listModel.(list.List[list.Item])
+	return c, cmd
+}
+
+// toggle marks or unmarks a response, comparing as soon as two are marked
+func (c *compareDialog) toggle(idx int) tea.Cmd {
+	for i, marked := range c.marked {
+		if marked == idx {
+			c.marked = append(c.marked[:i], c.marked[i+1:]...)
+			c.refresh()
+			return nil
+		}
+	}
+	c.marked = append(c.marked, idx)
+	if len(c.marked) < 2 {
+		c.refresh()
+		return nil
+	}
+
+	before, after := c.responses[c.marked[0]].index, c.responses[c.marked[1]].index
+	if before > after {
+		before, after = after, before
+	}
+	return tea.Sequence(
+		util.CmdHandler(modal.CloseModalMsg{}),
+		util.CmdHandler(CompareMessagesMsg{Before: before, After: after}),
+	)
+}
+
+// refresh renders the responses into the list, keeping the selection
+func (c *compareDialog) refresh() {
+	_, selected := c.list.GetSelectedItem()
+	items := make([]list.Item, len(c.responses))
+	for i, response := range c.responses {
+		mark := "[ ]"
+		for _, marked := range c.marked {
+			if marked == i {
+				mark = "[x]"
+			}
+		}
+		items[i] = list.StringItem(fmt.Sprintf("%s Response %d  %s", mark, i+1, response.preview))
+	}
+	c.list.SetItems(items)
+	if selected > 0 && selected < len(items) {
+		c.list.SetSelectedIndex(selected)
+	}
+}
+
+func (c *compareDialog) Render(background string) string {
+	content := c.list.View()
+	if len(c.responses) > 0 {
+		t := theme.CurrentTheme()
+		base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+		muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+		content += "\n\n" + base.Render("enter") + muted.Render(" mark two responses to compare")
+	}
+	return c.modal.Render(content, background)
+}
+
+func (c *compareDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewCompareDialog creates a dialog listing the assistant responses of the
+// session, where marking two of them opens a diff of their text
+func NewCompareDialog(app *app.App) CompareDialog {
+	var responses []compareResponse
+	for i, message := range app.Messages {
+		if _, ok := message.Info.(opencode.AssistantMessage); !ok {
+			continue
+		}
+		text := message.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		preview := ""
+		for line := range strings.Lines(text) {
+			if line = strings.TrimSpace(line); line != "" {
+				preview = ansi.Truncate(line, 36, "…")
+				break
+			}
+		}
+		responses = append(responses, compareResponse{index: i, preview: preview})
+	}
+
+	listComponent := list.NewListComponent(
+		list.WithItems([]list.Item{}),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("No responses to compare"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(56)
+
+	dialog := &compareDialog{
+		list:      listComponent,
+		responses: responses,
+		modal: modal.New(
+			modal.WithTitle("Compare Responses"),
+			modal.WithMaxWidth(60),
+		),
+	}
+	dialog.refresh()
+	return dialog
+}
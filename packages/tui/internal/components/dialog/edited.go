@@ -0,0 +1,97 @@
+package dialog
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	list "github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/util"
+)
+
+// EditedFilesDialog interface for the dialog listing files edited in the
+// session
+type EditedFilesDialog interface {
+	layout.Modal
+}
+
+type editedFilesDialog struct {
+	width  int
+	height int
+	modal  *modal.Modal
+	list   list.List[list.Item]
+	files  []app.EditedFile
+}
+
+func (e *editedFilesDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (e *editedFilesDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.width = msg.Width
+		e.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := e.list.GetSelectedItem(); idx >= 0 && idx < len(e.files) {
+				return e, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(FindSelectedMsg{FilePath: e.files[idx].Path}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := e.list.Update(msg)
+	e.list = listModel.(list.List[list.Item])
+	return e, cmd
+}
+
+func (e *editedFilesDialog) Render(background string) string {
+	return e.modal.Render(e.list.View(), background)
+}
+
+func (e *editedFilesDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewEditedFilesDialog creates a dialog listing the files edited in the
+// session with their edit counts, opening the selected one in the file viewer
+func NewEditedFilesDialog(files []app.EditedFile) EditedFilesDialog {
+	items := make([]list.Item, len(files))
+	for i, file := range files {
+		edits := "1 edit"
+		if file.Edits > 1 {
+			edits = fmt.Sprintf("%d edits", file.Edits)
+		}
+		items[i] = list.StringItem(fmt.Sprintf("%s (%s)", file.Path, edits))
+	}
+
+	listComponent := list.NewListComponent(
+		list.WithItems(items),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("No files edited in this session"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(findDialogWidth - 4)
+
+	return &editedFilesDialog{
+		list:  listComponent,
+		files: files,
+		modal: modal.New(
+			modal.WithTitle("Edited Files"),
+			modal.WithMaxWidth(findDialogWidth),
+		),
+	}
+}
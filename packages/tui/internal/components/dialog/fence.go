@@ -0,0 +1,108 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	list "github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/util"
+)
+
+// fenceLanguages are offered for the code fence, after the option to leave
+// the language out
+var fenceLanguages = []string{
+	"bash", "c", "cpp", "css", "diff", "go", "html", "java", "javascript",
+	"json", "markdown", "python", "ruby", "rust", "sql", "toml", "typescript",
+	"yaml",
+}
+
+// noFenceLanguage is the list entry for a fence without a language
+const noFenceLanguage = "none"
+
+// FenceLanguageSelectedMsg is sent when a language is chosen to wrap the
+// editor content in a code fence, empty for no language
+type FenceLanguageSelectedMsg struct {
+	Language string
+}
+
+// FenceDialog interface for choosing the language of a code fence
+type FenceDialog interface {
+	layout.Modal
+}
+
+type fenceDialog struct {
+	width     int
+	height    int
+	modal     *modal.Modal
+	list      list.List[list.Item]
+	languages []string
+}
+
+func (f *fenceDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (f *fenceDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		f.width = msg.Width
+		f.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := f.list.GetSelectedItem(); idx >= 0 && idx < len(f.languages) {
+				language := f.languages[idx]
+				if language == noFenceLanguage {
+					language = ""
+				}
+				return f, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(FenceLanguageSelectedMsg{Language: language}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := f.list.Update(msg)
+	f.list = listModel.(list.List[list.Item])
+	return f, cmd
+}
+
+func (f *fenceDialog) Render(background string) string {
+	return f.modal.Render(f.list.View(), background)
+}
+
+func (f *fenceDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewFenceDialog creates a dialog choosing the language to wrap the editor
+// content in a code fence with
+func NewFenceDialog() FenceDialog {
+	languages := append([]string{noFenceLanguage}, fenceLanguages...)
+	items := make([]list.Item, len(languages))
+	for i, language := range languages {
+		items[i] = list.StringItem(language)
+	}
+
+	listComponent := list.NewListComponent(
+		list.WithItems(items),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("No languages"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(36)
+
+	return &fenceDialog{
+		list:      listComponent,
+		modal:     modal.New(modal.WithTitle("Code Fence Language"), modal.WithMaxWidth(40)),
+		languages: languages,
+	}
+}
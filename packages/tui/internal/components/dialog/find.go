@@ -17,8 +17,21 @@ const (
 	findDialogWidth = 76
 )
 
+// FindAction is what happens to the file picked in a find dialog
+type FindAction int
+
+const (
+	// FindOpen opens the file in the file viewer
+	FindOpen FindAction = iota
+	// FindInsert inserts the file contents into the editor
+	FindInsert
+	// FindImport replays an exported conversation into a new session
+	FindImport
+)
+
 type FindSelectedMsg struct {
 	FilePath string
+	Action   FindAction
 }
 
 type FindDialogCloseMsg struct{}
@@ -70,6 +83,8 @@ type findDialogComponent struct {
 	modal              *modal.Modal
 	searchDialog       *SearchDialog
 	dialogWidth        int
+	title              string
+	action             FindAction
 }
 
 func (f *findDialogComponent) Init() tea.Cmd {
@@ -112,7 +127,7 @@ func (f *findDialogComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Update modal with calculated width
 		f.modal = modal.New(
-			modal.WithTitle("Find Files"),
+			modal.WithTitle(f.title),
 			modal.WithMaxWidth(f.dialogWidth+4),
 		)
 
@@ -136,7 +151,7 @@ func (f *findDialogComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return f, nil
 
 	case SearchCancelledMsg:
-		return f, f.Close()
+		return f, util.CmdHandler(modal.CloseModalMsg{})
 
 	case SearchQueryChangedMsg:
 		// Update completion items based on search query
@@ -159,7 +174,7 @@ func (f *findDialogComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			f.searchDialog.SetWidth(f.dialogWidth)
 			// Update modal max width too
 			f.modal = modal.New(
-				modal.WithTitle("Find Files"),
+				modal.WithTitle(f.title),
 				modal.WithMaxWidth(f.dialogWidth+4),
 			)
 		}
@@ -199,9 +214,10 @@ func (f *findDialogComponent) IsEmpty() bool {
 
 func (f *findDialogComponent) selectFile(item completions.CompletionSuggestion) tea.Cmd {
 	return tea.Sequence(
-		f.Close(),
+		util.CmdHandler(modal.CloseModalMsg{}),
 		util.CmdHandler(FindSelectedMsg{
 			FilePath: item.Value,
+			Action:   f.action,
 		}),
 	)
 }
@@ -213,14 +229,36 @@ func (f *findDialogComponent) Render(background string) string {
 func (f *findDialogComponent) Close() tea.Cmd {
 	f.searchDialog.SetQuery("")
 	f.searchDialog.Blur()
-	return util.CmdHandler(modal.CloseModalMsg{})
+	return nil
 }
 
 func NewFindDialog(completionProvider completions.CompletionProvider) FindDialog {
+	return newFindDialog(completionProvider, "Find Files", FindOpen)
+}
+
+// NewInsertFileDialog creates a find dialog whose selection is inserted into
+// the editor as text instead of being opened in the file viewer
+func NewInsertFileDialog(completionProvider completions.CompletionProvider) FindDialog {
+	return newFindDialog(completionProvider, "Insert File", FindInsert)
+}
+
+// NewImportConversationDialog creates a find dialog whose selection is read as
+// an exported conversation and replayed into a new session
+func NewImportConversationDialog(completionProvider completions.CompletionProvider) FindDialog {
+	return newFindDialog(completionProvider, "Import Conversation", FindImport)
+}
+
+func newFindDialog(
+	completionProvider completions.CompletionProvider,
+	title string,
+	action FindAction,
+) FindDialog {
 	component := &findDialogComponent{
 		completionProvider: completionProvider,
 		dialogWidth:        findDialogWidth,
 		allSuggestions:     []completions.CompletionSuggestion{},
+		title:              title,
+		action:             action,
 	}
 
 	// Create search dialog and modal with fixed width
@@ -228,7 +266,7 @@ func NewFindDialog(completionProvider completions.CompletionProvider) FindDialog
 	component.searchDialog.SetWidth(findDialogWidth)
 
 	component.modal = modal.New(
-		modal.WithTitle("Find Files"),
+		modal.WithTitle(title),
 		modal.WithMaxWidth(findDialogWidth+4),
 	)
 
@@ -0,0 +1,107 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	list "github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// PermissionGrantsDialog interface for reviewing permissions allowed for the
+// session
+type PermissionGrantsDialog interface {
+	layout.Modal
+}
+
+type permissionGrantsDialog struct {
+	width  int
+	height int
+	app    *app.App
+	modal  *modal.Modal
+	list   list.List[list.Item]
+	titles []string
+}
+
+func (p *permissionGrantsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *permissionGrantsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "x":
+			if _, idx := p.list.GetSelectedItem(); idx >= 0 && idx < len(p.titles) {
+				title := p.titles[idx]
+				p.app.RevokePermission(p.app.Session.ID, title)
+				p.refresh()
+				return p, toast.NewInfoToast("Revoked " + title)
+			}
+		}
+	}
+
+	listModel, cmd := p.list.Update(msg)
+	p.list = listModel.(list.List[list.Item])
+	return p, cmd
+}
+
+// refresh reloads the granted permissions into the list
+func (p *permissionGrantsDialog) refresh() {
+	p.titles = p.app.GrantedPermissions(p.app.Session.ID)
+	items := make([]list.Item, len(p.titles))
+	for i, title := range p.titles {
+		items[i] = list.StringItem(title)
+	}
+	p.list.SetItems(items)
+}
+
+func (p *permissionGrantsDialog) Render(background string) string {
+	content := p.list.View()
+	if len(p.titles) > 0 {
+		t := theme.CurrentTheme()
+		base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+		muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+		content += "\n\n" + base.Render("enter") + muted.Render(" revoke")
+	}
+	return p.modal.Render(content, background)
+}
+
+func (p *permissionGrantsDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewPermissionGrantsDialog creates a dialog listing the permissions allowed
+// for the current session, where selecting one revokes it
+func NewPermissionGrantsDialog(app *app.App) PermissionGrantsDialog {
+	listComponent := list.NewListComponent(
+		list.WithItems([]list.Item{}),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("No permissions granted"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(56)
+
+	dialog := &permissionGrantsDialog{
+		app:  app,
+		list: listComponent,
+		modal: modal.New(
+			modal.WithTitle("Granted Permissions"),
+			modal.WithMaxWidth(60),
+		),
+	}
+	dialog.refresh()
+	return dialog
+}
@@ -0,0 +1,114 @@
+package dialog
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	list "github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// LogFilterDialog interface for choosing which event types are forwarded to
+// the debug log
+type LogFilterDialog interface {
+	layout.Modal
+}
+
+type logFilterDialog struct {
+	width      int
+	height     int
+	modal      *modal.Modal
+	list       list.List[list.Item]
+	categories []util.LogCategory
+}
+
+func (l *logFilterDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (l *logFilterDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.width = msg.Width
+		l.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "space":
+			if _, idx := l.list.GetSelectedItem(); idx >= 0 && idx < len(l.categories) {
+				util.ToggleLogCategory(l.categories[idx].Name)
+				l.refresh()
+				return l, nil
+			}
+		}
+	}
+
+	listModel, cmd := l.list.Update(msg)
+	l.list = listModel.(list.List[list.Item])
+	return l, cmd
+}
+
+// refresh reloads the seen log categories into the list, keeping the selection
+func (l *logFilterDialog) refresh() {
+	_, selected := l.list.GetSelectedItem()
+	l.categories = util.LogCategories()
+	items := make([]list.Item, len(l.categories))
+	for i, category := range l.categories {
+		mark := "[x]"
+		if category.Excluded {
+			mark = "[ ]"
+		}
+		items[i] = list.StringItem(fmt.Sprintf("%s %s (%d)", mark, category.Name, category.Count))
+	}
+	l.list.SetItems(items)
+	if selected > 0 && selected < len(items) {
+		l.list.SetSelectedIndex(selected)
+	}
+}
+
+func (l *logFilterDialog) Render(background string) string {
+	content := l.list.View()
+	if len(l.categories) > 0 {
+		t := theme.CurrentTheme()
+		base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+		muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+		content += "\n\n" + base.Render("enter") + muted.Render(" toggle forwarding")
+	}
+	return l.modal.Render(content, background)
+}
+
+func (l *logFilterDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewLogFilterDialog creates a dialog listing the event types and log messages
+// seen so far with their counts, where selecting one toggles whether it is
+// forwarded to the debug log
+func NewLogFilterDialog() LogFilterDialog {
+	listComponent := list.NewListComponent(
+		list.WithItems([]list.Item{}),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("Nothing logged yet"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(56)
+
+	dialog := &logFilterDialog{
+		list: listComponent,
+		modal: modal.New(
+			modal.WithTitle("Debug Log Filter"),
+			modal.WithMaxWidth(60),
+		),
+	}
+	dialog.refresh()
+	return dialog
+}
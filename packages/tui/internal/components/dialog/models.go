@@ -39,6 +39,8 @@ type modelDialog struct {
 	modal        *modal.Modal
 	searchDialog *SearchDialog
 	dialogWidth  int
+	// providerID limits the dialog to the models of one provider when set
+	providerID string
 }
 
 type ModelWithProvider struct {
@@ -180,6 +182,9 @@ func (m *modelDialog) setupAllModels() {
 
 	m.allModels = make([]ModelWithProvider, 0)
 	for _, provider := range providers {
+		if m.providerID != "" && provider.ID != m.providerID {
+			continue
+		}
 		for _, model := range provider.Models {
 			m.allModels = append(m.allModels, ModelWithProvider{
 				Model:    model,
@@ -328,10 +333,12 @@ func (m *modelDialog) buildGroupedResults() []list.Item {
 	}
 
 	// Add ZeroSync section
-	items = append(items, list.HeaderItem("ZeroSync"))
-	for _, model := range m.allModels {
-		if model.Provider.ID == "zerosync" {
-			items = append(items, modelItem{model: model})
+	if m.providerID == "" || m.providerID == "zerosync" {
+		items = append(items, list.HeaderItem("ZeroSync"))
+		for _, model := range m.allModels {
+			if model.Provider.ID == "zerosync" {
+				items = append(items, modelItem{model: model})
+			}
 		}
 	}
 
@@ -454,14 +461,25 @@ func (s *modelDialog) Close() tea.Cmd {
 }
 
 func NewModelDialog(app *app.App) ModelDialog {
+	return newModelDialog(app, "", "Select Model")
+}
+
+// NewProviderModelDialog creates a model dialog that only lists the models of
+// the given provider
+func NewProviderModelDialog(app *app.App, provider opencode.Provider) ModelDialog {
+	return newModelDialog(app, provider.ID, "Select "+provider.Name+" Model")
+}
+
+func newModelDialog(app *app.App, providerID string, title string) ModelDialog {
 	dialog := &modelDialog{
-		app: app,
+		app:        app,
+		providerID: providerID,
 	}
 
 	dialog.setupAllModels()
 
 	dialog.modal = modal.New(
-		modal.WithTitle("Select Model"),
+		modal.WithTitle(title),
 		modal.WithMaxWidth(dialog.dialogWidth+4),
 	)
 
@@ -0,0 +1,92 @@
+package dialog
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// OpenEditedConfirmedMsg is sent when the open edited files confirmation
+// dialog closes, with Confirmed reporting whether the files should be opened
+type OpenEditedConfirmedMsg struct {
+	Files     []string
+	Confirmed bool
+}
+
+// OpenEditedConfirmDialog interface for the open edited files confirmation
+// dialog
+type OpenEditedConfirmDialog interface {
+	layout.Modal
+}
+
+type openEditedConfirmDialog struct {
+	width     int
+	height    int
+	modal     *modal.Modal
+	files     []string
+	total     int
+	confirmed bool
+}
+
+func (o *openEditedConfirmDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (o *openEditedConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		o.width = msg.Width
+		o.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "y":
+			o.confirmed = true
+			return o, util.CmdHandler(modal.CloseModalMsg{})
+		case "n":
+			return o, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return o, nil
+}
+
+func (o *openEditedConfirmDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	message := fmt.Sprintf("Open %d edited files as tabs?", len(o.files))
+	if o.total > len(o.files) {
+		message = fmt.Sprintf(
+			"%d files were edited in this session. Open the first %d as tabs?",
+			o.total,
+			len(o.files),
+		)
+	}
+
+	content := base.Width(56).Render(message) + "\n\n" +
+		base.Render("enter") + muted.Render(" open  ") +
+		base.Render("esc") + muted.Render(" cancel")
+	return o.modal.Render(content, background)
+}
+
+func (o *openEditedConfirmDialog) Close() tea.Cmd {
+	return util.CmdHandler(OpenEditedConfirmedMsg{
+		Files:     o.files,
+		Confirmed: o.confirmed,
+	})
+}
+
+// NewOpenEditedConfirmDialog creates a dialog asking whether to open the
+// files as tabs, where total is the number of edited files before capping
+func NewOpenEditedConfirmDialog(files []string, total int) OpenEditedConfirmDialog {
+	return &openEditedConfirmDialog{
+		files: files,
+		total: total,
+		modal: modal.New(modal.WithTitle("Open Edited Files?"), modal.WithMaxWidth(60)),
+	}
+}
@@ -0,0 +1,92 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// PasteChosenMsg is sent when long pasted text should be added to the editor,
+// with Attach reporting whether to store it as a single attachment rather
+// than inserting its lines
+type PasteChosenMsg struct {
+	Text   string
+	Attach bool
+}
+
+// PasteDialog interface for the long paste dialog
+type PasteDialog interface {
+	layout.Modal
+}
+
+type pasteDialog struct {
+	width  int
+	height int
+	modal  *modal.Modal
+	text   string
+	chosen bool
+	attach bool
+}
+
+func (p *pasteDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *pasteDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "a":
+			p.chosen, p.attach = true, true
+			return p, util.CmdHandler(modal.CloseModalMsg{})
+		case "i":
+			p.chosen = true
+			return p, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return p, nil
+}
+
+func (p *pasteDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	message := fmt.Sprintf(
+		"The pasted text has %d lines. Store it as a single attachment to keep the prompt compact, or insert it into the editor?",
+		strings.Count(p.text, "\n")+1,
+	)
+	content := base.Width(56).Render(message) + "\n\n" +
+		base.Render("enter") + muted.Render(" attach  ") +
+		base.Render("i") + muted.Render(" insert  ") +
+		base.Render("esc") + muted.Render(" cancel")
+	return p.modal.Render(content, background)
+}
+
+func (p *pasteDialog) Close() tea.Cmd {
+	if !p.chosen {
+		return nil
+	}
+	return util.CmdHandler(PasteChosenMsg{
+		Text:   p.text,
+		Attach: p.attach,
+	})
+}
+
+// NewPasteDialog creates a dialog asking whether to attach long pasted text
+// or insert it into the editor
+func NewPasteDialog(text string) PasteDialog {
+	return &pasteDialog{
+		text:  text,
+		modal: modal.New(modal.WithTitle("Paste Long Text?"), modal.WithMaxWidth(60)),
+	}
+}
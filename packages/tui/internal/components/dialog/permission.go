@@ -0,0 +1,124 @@
+package dialog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const permissionDialogWidth = 60
+
+// PermissionRespondedMsg is sent when the permission dialog closes, with the
+// response to post for the request. Closing without choosing denies it.
+type PermissionRespondedMsg struct {
+	Permission opencode.EventListResponseEventPermissionUpdatedProperties
+	Response   opencode.SessionRespondPermissionParamsResponse
+}
+
+// PermissionDialog interface for the permission request dialog
+type PermissionDialog interface {
+	layout.Modal
+	SetPending(pending int)
+}
+
+type permissionDialog struct {
+	modal      *modal.Modal
+	permission opencode.EventListResponseEventPermissionUpdatedProperties
+	response   opencode.SessionRespondPermissionParamsResponse
+	pending    int
+}
+
+func (p *permissionDialog) Init() tea.Cmd {
+	return nil
+}
+
+// SetPending updates how many requests are queued behind this one
+func (p *permissionDialog) SetPending(pending int) {
+	p.pending = pending
+}
+
+func (p *permissionDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "a":
+			p.response = opencode.SessionRespondPermissionParamsResponseOnce
+			return p, util.CmdHandler(modal.CloseModalMsg{})
+		case "s":
+			p.response = opencode.SessionRespondPermissionParamsResponseAlways
+			return p, util.CmdHandler(modal.CloseModalMsg{})
+		case "d", "n":
+			p.response = opencode.SessionRespondPermissionParamsResponseReject
+			return p, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return p, nil
+}
+
+func (p *permissionDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+	width := permissionDialogWidth - 4
+
+	lines := []string{base.Bold(true).Width(width).Render(p.permission.Title)}
+
+	// metadata is free-form, show it as sorted key: value lines
+	keys := make([]string, 0, len(p.permission.Metadata))
+	for key := range p.permission.Metadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if len(keys) > 0 {
+		lines = append(lines, "")
+	}
+	for _, key := range keys {
+		value := strings.ReplaceAll(fmt.Sprintf("%v", p.permission.Metadata[key]), "\n", " ")
+		line := ansi.Truncate(key+": "+value, width, "…")
+		lines = append(lines, muted.Render(line))
+	}
+
+	lines = append(lines, "")
+	if p.pending > 0 {
+		lines = append(lines, muted.Render(fmt.Sprintf("%d more waiting", p.pending)), "")
+	}
+	lines = append(lines,
+		base.Render("a")+muted.Render(" allow  ")+
+			base.Render("s")+muted.Render(" allow for session  ")+
+			base.Render("d")+muted.Render(" deny"),
+	)
+	return p.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (p *permissionDialog) Close() tea.Cmd {
+	return util.CmdHandler(PermissionRespondedMsg{
+		Permission: p.permission,
+		Response:   p.response,
+	})
+}
+
+// NewPermissionDialog creates a dialog asking whether to allow a tool to
+// proceed, noting how many more requests are queued behind it
+func NewPermissionDialog(
+	permission opencode.EventListResponseEventPermissionUpdatedProperties,
+	pending int,
+) PermissionDialog {
+	return &permissionDialog{
+		permission: permission,
+		pending:    pending,
+		response:   opencode.SessionRespondPermissionParamsResponseReject,
+		modal: modal.New(
+			modal.WithTitle("Permission Required"),
+			modal.WithMaxWidth(permissionDialogWidth),
+		),
+	}
+}
@@ -0,0 +1,154 @@
+package dialog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const providerDialogWidth = 40
+
+// ProviderSelectedMsg is sent when a provider is picked to list its models
+type ProviderSelectedMsg struct {
+	Provider opencode.Provider
+}
+
+// ProviderDialog interface for the provider picker
+type ProviderDialog interface {
+	layout.Modal
+}
+
+type providerDialog struct {
+	app   *app.App
+	modal *modal.Modal
+	list  list.List[list.Item]
+}
+
+// providerItem is a list item showing a provider and how many models it has
+type providerItem struct {
+	provider opencode.Provider
+}
+
+func (p providerItem) Render(
+	selected bool,
+	width int,
+	baseStyle styles.Style,
+) string {
+	t := theme.CurrentTheme()
+
+	itemStyle := baseStyle.
+		Background(t.BackgroundPanel()).
+		Foreground(t.Text())
+	if selected {
+		itemStyle = itemStyle.Foreground(t.Primary())
+	}
+	countStyle := baseStyle.
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundPanel())
+
+	count := fmt.Sprintf(" %d models", len(p.provider.Models))
+	if len(p.provider.Models) == 1 {
+		count = " 1 model"
+	}
+
+	return baseStyle.
+		Background(t.BackgroundPanel()).
+		PaddingLeft(1).
+		Render(itemStyle.Render(p.provider.Name) + countStyle.Render(count))
+}
+
+func (p providerItem) Selectable() bool {
+	return true
+}
+
+func (p *providerDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *providerDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if item, idx := p.list.GetSelectedItem(); idx >= 0 {
+				if providerItem, ok := item.(providerItem); ok {
+					return p, tea.Sequence(
+						util.CmdHandler(modal.CloseModalMsg{}),
+						util.CmdHandler(ProviderSelectedMsg{Provider: providerItem.provider}),
+					)
+				}
+			}
+		}
+	}
+
+	listModel, cmd := p.list.Update(msg)
+	p.list = listModel.(list.List[list.Item])
+	return p, cmd
+}
+
+func (p *providerDialog) Render(background string) string {
+	return p.modal.Render(p.list.View(), background)
+}
+
+func (p *providerDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewProviderDialog creates a provider picker, selecting the provider last
+// picked in the current mode or else the provider of the current model
+func NewProviderDialog(app *app.App) ProviderDialog {
+	providers, _ := app.ListProviders(context.Background())
+
+	// ZeroSync leads, like in the model dialog
+	sort.SliceStable(providers, func(i, j int) bool {
+		if (providers[i].ID == "zerosync") != (providers[j].ID == "zerosync") {
+			return providers[i].ID == "zerosync"
+		}
+		return providers[i].Name < providers[j].Name
+	})
+
+	selectedID := app.State.ModeProvider[app.Mode.Name]
+	if selectedID == "" && app.Provider != nil {
+		selectedID = app.Provider.ID
+	}
+
+	var selectedIdx int
+	items := make([]list.Item, len(providers))
+	for i, provider := range providers {
+		items[i] = providerItem{provider: provider}
+		if provider.ID == selectedID {
+			selectedIdx = i
+		}
+	}
+
+	listComponent := list.NewListComponent(
+		list.WithItems(items),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("No providers configured"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(providerDialogWidth)
+	listComponent.SetSelectedIndex(selectedIdx)
+
+	return &providerDialog{
+		app:   app,
+		list:  listComponent,
+		modal: modal.New(modal.WithTitle("Select Provider"), modal.WithMaxWidth(providerDialogWidth+4)),
+	}
+}
@@ -0,0 +1,81 @@
+package dialog
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// RestartConfirmedMsg is sent when the restart dialog closes, with Confirmed
+// reporting whether to restart into the installed update
+type RestartConfirmedMsg struct {
+	Confirmed bool
+}
+
+// RestartDialog interface for the dialog offering to restart after an update
+type RestartDialog interface {
+	layout.Modal
+}
+
+type restartDialog struct {
+	width     int
+	height    int
+	modal     *modal.Modal
+	version   string
+	confirmed bool
+}
+
+func (r *restartDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (r *restartDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		r.width = msg.Width
+		r.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "y":
+			r.confirmed = true
+			return r, util.CmdHandler(modal.CloseModalMsg{})
+		case "n":
+			return r, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return r, nil
+}
+
+func (r *restartDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	message := fmt.Sprintf("autoprovisioner %s was installed. Restart now to apply it?", r.version)
+	note := "Sessions are kept and can be resumed from the session list. " +
+		"A response in progress is interrupted."
+
+	content := base.Width(56).Render(message) + "\n\n" +
+		muted.Width(56).Render(note) + "\n\n" +
+		base.Render("enter") + muted.Render(" restart  ") +
+		base.Render("esc") + muted.Render(" later")
+	return r.modal.Render(content, background)
+}
+
+func (r *restartDialog) Close() tea.Cmd {
+	return util.CmdHandler(RestartConfirmedMsg{Confirmed: r.confirmed})
+}
+
+// NewRestartDialog creates a dialog offering to restart into the installed
+// version
+func NewRestartDialog(version string) RestartDialog {
+	return &restartDialog{
+		version: version,
+		modal:   modal.New(modal.WithTitle("Update Installed"), modal.WithMaxWidth(60)),
+	}
+}
@@ -0,0 +1,108 @@
+package dialog
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// SendConfirmedMsg is sent when the send confirmation dialog closes, with
+// Confirmed reporting whether the prompt should be sent
+type SendConfirmedMsg struct {
+	Prompt    app.Prompt
+	Confirmed bool
+}
+
+// SendConfirmDialog interface for the long prompt confirmation dialog
+type SendConfirmDialog interface {
+	layout.Modal
+}
+
+type sendConfirmDialog struct {
+	width     int
+	height    int
+	modal     *modal.Modal
+	app       *app.App
+	prompt    app.Prompt
+	confirmed bool
+}
+
+func (s *sendConfirmDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (s *sendConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "y":
+			s.confirmed = true
+			return s, util.CmdHandler(modal.CloseModalMsg{})
+		case "n":
+			return s, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return s, nil
+}
+
+func (s *sendConfirmDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	tokens := s.prompt.EstimateTokens()
+	message := fmt.Sprintf("This prompt is about %s tokens.", formatTokens(float64(tokens)))
+	if s.app.Model != nil && s.app.Model.Limit.Context > 0 {
+		contextWindow := s.app.Model.Limit.Context
+		used := s.app.ContextTokens()
+		message += fmt.Sprintf(
+			" With the %s already in context it would fill %d%% of the %s context window.",
+			formatTokens(used),
+			int((used+float64(tokens))/contextWindow*100),
+			formatTokens(contextWindow),
+		)
+	}
+
+	content := base.Width(56).Render(message) + "\n\n" +
+		base.Render("enter") + muted.Render(" send  ") +
+		base.Render("esc") + muted.Render(" cancel")
+	return s.modal.Render(content, background)
+}
+
+func (s *sendConfirmDialog) Close() tea.Cmd {
+	return util.CmdHandler(SendConfirmedMsg{
+		Prompt:    s.prompt,
+		Confirmed: s.confirmed,
+	})
+}
+
+// formatTokens formats a token count in human-readable form, eg 1.2K or 3M
+func formatTokens(tokens float64) string {
+	switch {
+	case tokens >= 1_000_000:
+		return fmt.Sprintf("%.1fM", tokens/1_000_000)
+	case tokens >= 1_000:
+		return fmt.Sprintf("%.1fK", tokens/1_000)
+	default:
+		return fmt.Sprintf("%d", int(tokens))
+	}
+}
+
+// NewSendConfirmDialog creates a dialog asking whether to send a prompt
+// estimated to be larger than the configured threshold
+func NewSendConfirmDialog(app *app.App, prompt app.Prompt) SendConfirmDialog {
+	return &sendConfirmDialog{
+		app:    app,
+		prompt: prompt,
+		modal:  modal.New(modal.WithTitle("Send Long Prompt?"), modal.WithMaxWidth(60)),
+	}
+}
@@ -0,0 +1,78 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// ShareConfirmedMsg is sent when the share confirmation dialog closes, with
+// Confirmed reporting whether the session should be shared
+type ShareConfirmedMsg struct {
+	Confirmed bool
+}
+
+// ShareConfirmDialog interface for the session share confirmation dialog
+type ShareConfirmDialog interface {
+	layout.Modal
+}
+
+type shareConfirmDialog struct {
+	width     int
+	height    int
+	modal     *modal.Modal
+	confirmed bool
+}
+
+func (s *shareConfirmDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (s *shareConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		s.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "y":
+			s.confirmed = true
+			return s, util.CmdHandler(modal.CloseModalMsg{})
+		case "n":
+			return s, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return s, nil
+}
+
+func (s *shareConfirmDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	message := "Sharing publishes this session at a public URL that anyone with the link can open. " +
+		"It includes every message, the tool calls made and their output, such as file contents and command results."
+	note := "The session stays shared as it continues, until you unshare it. " +
+		"Set tui.confirm_share to false to share without asking."
+
+	content := base.Width(56).Render(message) + "\n\n" +
+		muted.Width(56).Render(note) + "\n\n" +
+		base.Render("enter") + muted.Render(" share  ") +
+		base.Render("esc") + muted.Render(" cancel")
+	return s.modal.Render(content, background)
+}
+
+func (s *shareConfirmDialog) Close() tea.Cmd {
+	return util.CmdHandler(ShareConfirmedMsg{Confirmed: s.confirmed})
+}
+
+// NewShareConfirmDialog creates a dialog explaining what sharing a session
+// exposes and asking for consent before it is shared
+func NewShareConfirmDialog() ShareConfirmDialog {
+	return &shareConfirmDialog{
+		modal: modal.New(modal.WithTitle("Share Session?"), modal.WithMaxWidth(60)),
+	}
+}
@@ -1,9 +1,14 @@
 package dialog
 
 import (
+	"log/slog"
+
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/app"
 	list "github.com/sst/opencode/internal/components/list"
 	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/toast"
 	"github.com/sst/opencode/internal/layout"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
@@ -23,6 +28,7 @@ type ThemeDialog interface {
 type themeDialog struct {
 	width  int
 	height int
+	app    *app.App
 
 	modal         *modal.Modal
 	list          list.List[list.Item]
@@ -41,6 +47,8 @@ func (t *themeDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		t.height = msg.Height
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "ctrl+r":
+			return t, t.reload()
 		case "enter":
 			if item, idx := t.list.GetSelectedItem(); idx >= 0 {
 				if stringItem, ok := item.(list.StringItem); ok {
@@ -75,38 +83,73 @@ func (t *themeDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return t, cmd
 }
 
-func (t *themeDialog) Render(background string) string {
-	return t.modal.Render(t.list.View(), background)
-}
-
-func (t *themeDialog) Close() tea.Cmd {
-	if !t.themeApplied {
-		theme.SetTheme(t.originalTheme)
-		return util.CmdHandler(ThemeSelectedMsg{ThemeName: t.originalTheme})
+// reload rescans themes from disk and refreshes the list, keeping the
+// highlighted theme selected
+func (t *themeDialog) reload() tea.Cmd {
+	err := t.app.ReloadThemes()
+	t.refresh()
+	if err != nil {
+		slog.Warn("Failed to reload themes", "error", err)
+		return tea.Batch(
+			util.CmdHandler(ThemeSelectedMsg{ThemeName: theme.CurrentThemeName()}),
+			toast.NewErrorToast("Some themes failed to load, see the log for details"),
+		)
 	}
-	return nil
+	return util.CmdHandler(ThemeSelectedMsg{ThemeName: theme.CurrentThemeName()})
 }
 
-// NewThemeDialog creates a new theme switching dialog
-func NewThemeDialog() ThemeDialog {
+// refresh loads the available themes into the list, selecting the current one
+func (t *themeDialog) refresh() {
 	themes := theme.AvailableThemes()
 	currentTheme := theme.CurrentThemeName()
 
 	var selectedIdx int
+	items := make([]list.Item, len(themes))
 	for i, name := range themes {
+		items[i] = list.StringItem(name)
 		if name == currentTheme {
 			selectedIdx = i
 		}
 	}
+	t.list.SetItems(items)
+	t.list.SetSelectedIndex(selectedIdx)
+}
 
-	// Convert themes to list items
-	items := make([]list.Item, len(themes))
-	for i, theme := range themes {
-		items[i] = list.StringItem(theme)
+func (t *themeDialog) Render(background string) string {
+	th := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(th.Text()).Background(th.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(th.TextMuted()).Background(th.BackgroundPanel())
+	previewed := th
+	if item, idx := t.list.GetSelectedItem(); idx >= 0 {
+		if stringItem, ok := item.(list.StringItem); ok && theme.GetTheme(string(stringItem)) != nil {
+			previewed = theme.GetTheme(string(stringItem))
+		}
 	}
 
+	content := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		base.Width(36).Render(t.list.View()),
+		base.Render("  "),
+		renderThemePreview(previewed, themePreviewWidth),
+	)
+	content += "\n\n" + base.Render("ctrl+r") + muted.Render(" reload from disk")
+	return t.modal.Render(content, background)
+}
+
+func (t *themeDialog) Close() tea.Cmd {
+	if !t.themeApplied {
+		theme.SetTheme(t.originalTheme)
+		return util.CmdHandler(ThemeSelectedMsg{ThemeName: t.originalTheme})
+	}
+	return nil
+}
+
+// NewThemeDialog creates a new theme switching dialog
+func NewThemeDialog(app *app.App) ThemeDialog {
+	currentTheme := theme.CurrentThemeName()
+
 	listComponent := list.NewListComponent(
-		list.WithItems(items),
+		list.WithItems([]list.Item{}),
 		list.WithMaxVisibleHeight[list.Item](10),
 		list.WithFallbackMessage[list.Item]("No themes available"),
 		list.WithAlphaNumericKeys[list.Item](true),
@@ -118,15 +161,16 @@ func NewThemeDialog() ThemeDialog {
 		}),
 	)
 
-	// Set the initial selection to the current theme
-	listComponent.SetSelectedIndex(selectedIdx)
-
-	// Set the max width for the list to match the modal width
-	listComponent.SetMaxWidth(36) // 40 (modal max width) - 4 (modal padding)
-	return &themeDialog{
+	// The list and preview share the modal width
+	listComponent.SetMaxWidth(36)
+	dialog := &themeDialog{
+		app:           app,
 		list:          listComponent,
-		modal:         modal.New(modal.WithTitle("Select Theme"), modal.WithMaxWidth(40)),
+		modal:         modal.New(modal.WithTitle("Select Theme"), modal.WithMaxWidth(36+2+themePreviewWidth+4)),
 		originalTheme: currentTheme,
 		themeApplied:  false,
 	}
+	// Select the current theme initially
+	dialog.refresh()
+	return dialog
 }
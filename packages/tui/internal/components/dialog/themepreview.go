@@ -0,0 +1,106 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// themePreviewWidth is the width of the preview pane in the theme dialog
+const themePreviewWidth = 42
+
+// previewSegment is a run of text in a single foreground color
+type previewSegment struct {
+	text  string
+	color compat.AdaptiveColor
+}
+
+// renderPreviewLine renders the segments on a background padded to width
+func renderPreviewLine(background compat.AdaptiveColor, width int, segments ...previewSegment) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteString(styles.NewStyle().
+			Foreground(segment.color).
+			Background(background).
+			Render(segment.text))
+	}
+	return styles.NewStyle().Background(background).Width(width).Render(b.String())
+}
+
+// renderThemePreview renders sample text, a diff and highlighted code in the
+// colors of the theme
+func renderThemePreview(t theme.Theme, width int) string {
+	bg := t.BackgroundPanel()
+	code := t.BackgroundElement()
+	line := func(segments ...previewSegment) string {
+		return renderPreviewLine(bg, width, segments...)
+	}
+	diffLine := func(number string, numberBg compat.AdaptiveColor, lineBg compat.AdaptiveColor, segments ...previewSegment) string {
+		gutter := styles.NewStyle().
+			Foreground(t.DiffLineNumber()).
+			Background(numberBg).
+			Render(" " + number + " ")
+		return gutter + renderPreviewLine(lineBg, width-3, segments...)
+	}
+
+	lines := []string{
+		styles.NewStyle().Foreground(t.Primary()).Background(bg).Bold(true).Width(width).Render(t.Name()),
+		line(),
+		line(
+			previewSegment{"Text ", t.Text()},
+			previewSegment{"muted ", t.TextMuted()},
+			previewSegment{"primary ", t.Primary()},
+			previewSegment{"secondary", t.Secondary()},
+		),
+		line(
+			previewSegment{"accent ", t.Accent()},
+			previewSegment{"success ", t.Success()},
+			previewSegment{"warning ", t.Warning()},
+			previewSegment{"error ", t.Error()},
+			previewSegment{"info", t.Info()},
+		),
+		line(),
+		diffLine("1", t.DiffContextBg(), t.DiffContextBg(),
+			previewSegment{"  func greet() string {", t.DiffContext()},
+		),
+		diffLine("2", t.DiffRemovedLineNumberBg(), t.DiffRemovedBg(),
+			previewSegment{"-   return ", t.DiffRemoved()},
+			previewSegment{`"hello"`, t.DiffHighlightRemoved()},
+		),
+		diffLine("2", t.DiffAddedLineNumberBg(), t.DiffAddedBg(),
+			previewSegment{"+   return ", t.DiffAdded()},
+			previewSegment{`"hello, world"`, t.DiffHighlightAdded()},
+		),
+		line(),
+		renderPreviewLine(code, width,
+			previewSegment{"// greet returns a greeting", t.SyntaxComment()},
+		),
+		renderPreviewLine(code, width,
+			previewSegment{"func ", t.SyntaxKeyword()},
+			previewSegment{"greet", t.SyntaxFunction()},
+			previewSegment{"(", t.SyntaxPunctuation()},
+			previewSegment{"n ", t.SyntaxVariable()},
+			previewSegment{"int", t.SyntaxType()},
+			previewSegment{") ", t.SyntaxPunctuation()},
+			previewSegment{"string", t.SyntaxType()},
+			previewSegment{" {", t.SyntaxPunctuation()},
+		),
+		renderPreviewLine(code, width,
+			previewSegment{"  return ", t.SyntaxKeyword()},
+			previewSegment{`"hi" `, t.SyntaxString()},
+			previewSegment{"+ ", t.SyntaxOperator()},
+			previewSegment{"repeat", t.SyntaxFunction()},
+			previewSegment{"(", t.SyntaxPunctuation()},
+			previewSegment{"n", t.SyntaxVariable()},
+			previewSegment{", ", t.SyntaxPunctuation()},
+			previewSegment{"42", t.SyntaxNumber()},
+			previewSegment{")", t.SyntaxPunctuation()},
+		),
+		renderPreviewLine(code, width,
+			previewSegment{"}", t.SyntaxPunctuation()},
+		),
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package dialog
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	list "github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/util"
+)
+
+// TocHeading is a markdown heading and the viewport line it was rendered on
+type TocHeading struct {
+	Level int
+	Title string
+	Line  int
+}
+
+// TocSelectedMsg is sent when a heading is chosen from the table of contents
+type TocSelectedMsg struct {
+	Line int
+}
+
+// TocDialog interface for the table of contents dialog
+type TocDialog interface {
+	layout.Modal
+}
+
+type tocDialog struct {
+	width    int
+	height   int
+	modal    *modal.Modal
+	list     list.List[list.Item]
+	headings []TocHeading
+}
+
+func (t *tocDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (t *tocDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width = msg.Width
+		t.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := t.list.GetSelectedItem(); idx >= 0 && idx < len(t.headings) {
+				return t, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(TocSelectedMsg{Line: t.headings[idx].Line}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := t.list.Update(msg)
+	t.list = listModel.(list.List[list.Item])
+	return t, cmd
+}
+
+func (t *tocDialog) Render(background string) string {
+	return t.modal.Render(t.list.View(), background)
+}
+
+func (t *tocDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewTocDialog creates a dialog listing the given headings, indented by level
+func NewTocDialog(headings []TocHeading) TocDialog {
+	minLevel := 6
+	for _, heading := range headings {
+		minLevel = min(minLevel, heading.Level)
+	}
+
+	items := make([]list.Item, len(headings))
+	for i, heading := range headings {
+		indent := strings.Repeat("  ", heading.Level-minLevel)
+		items[i] = list.StringItem(indent + heading.Title)
+	}
+
+	listComponent := list.NewListComponent(
+		list.WithItems(items),
+		list.WithMaxVisibleHeight[list.Item](10),
+		list.WithFallbackMessage[list.Item]("No headings"),
+		list.WithAlphaNumericKeys[list.Item](true),
+		list.WithRenderFunc(func(item list.Item, selected bool, width int, baseStyle styles.Style) string {
+			return item.Render(selected, width, baseStyle)
+		}),
+		list.WithSelectableFunc(func(item list.Item) bool {
+			return item.Selectable()
+		}),
+	)
+	listComponent.SetMaxWidth(56)
+
+	return &tocDialog{
+		list:     listComponent,
+		modal:    modal.New(modal.WithTitle("Table of Contents"), modal.WithMaxWidth(60)),
+		headings: headings,
+	}
+}
@@ -0,0 +1,149 @@
+package diff
+
+// Layout records where hunks and blocks of changes start in rendered diff
+// output, so callers can navigate between them
+type Layout struct {
+	// Hunks holds the first rendered row of each hunk
+	Hunks []int
+	// Changes holds the first rendered row of each block of consecutive changes
+	Changes []int
+	// Lines holds the new file line number shown on each rendered row, zero for
+	// rows only in the old file
+	Lines []int
+}
+
+// HunkAt returns the index of the hunk containing the given rendered row, or
+// -1 when the diff has no hunks
+func (l Layout) HunkAt(row int) int {
+	index := -1
+	for i, start := range l.Hunks {
+		if start > row {
+			break
+		}
+		index = i
+	}
+	if index == -1 && len(l.Hunks) > 0 {
+		index = 0
+	}
+	return index
+}
+
+// LineAt returns the new file line number shown on the given rendered row,
+// taking the closest following row for rows only in the old file. It returns
+// zero when no row shows a line of the new file.
+func (l Layout) LineAt(row int) int {
+	if len(l.Lines) == 0 {
+		return 0
+	}
+	row = max(0, min(row, len(l.Lines)-1))
+	for i := row; i < len(l.Lines); i++ {
+		if l.Lines[i] > 0 {
+			return l.Lines[i]
+		}
+	}
+	for i := row - 1; i >= 0; i-- {
+		if l.Lines[i] > 0 {
+			return l.Lines[i]
+		}
+	}
+	return 0
+}
+
+// layoutRow is a rendered row of a hunk
+type layoutRow struct {
+	changed bool
+	line    int
+}
+
+// layout builds a Layout from the rendered rows of each hunk
+func layout(diffText string, rows func(Hunk) []layoutRow) (Layout, error) {
+	diffResult, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	var l Layout
+	offset := 0
+	for _, h := range diffResult.Hunks {
+		l.Hunks = append(l.Hunks, offset)
+		hunkRows := rows(h)
+		for i, row := range hunkRows {
+			if row.changed && (i == 0 || !hunkRows[i-1].changed) {
+				l.Changes = append(l.Changes, offset+i)
+			}
+			l.Lines = append(l.Lines, row.line)
+		}
+		offset += len(hunkRows)
+	}
+	return l, nil
+}
+
+// UnifiedLayout returns the layout of the output of FormatUnifiedDiff
+func UnifiedLayout(diffText string) (Layout, error) {
+	return layout(diffText, func(h Hunk) []layoutRow {
+		rows := make([]layoutRow, len(h.Lines))
+		for i, line := range h.Lines {
+			rows[i] = layoutRow{changed: line.Kind != LineContext, line: line.NewLineNo}
+		}
+		return rows
+	})
+}
+
+// SideBySideLayout returns the layout of the output of FormatDiff
+func SideBySideLayout(diffText string) (Layout, error) {
+	return layout(diffText, func(h Hunk) []layoutRow {
+		pairs := pairLines(h.Lines)
+		rows := make([]layoutRow, len(pairs))
+		for i, pair := range pairs {
+			rows[i].changed = pair.left == nil || pair.left.Kind != LineContext
+			if pair.right != nil {
+				rows[i].line = pair.right.NewLineNo
+			}
+		}
+		return rows
+	})
+}
+
+// InlineLayout returns the layout of the output of FormatInlineDiff
+func InlineLayout(diffText string) (Layout, error) {
+	return layout(diffText, func(h Hunk) []layoutRow {
+		lines := inlineLines(h.Lines)
+		rows := make([]layoutRow, len(lines))
+		for i, line := range lines {
+			rows[i].changed = line.line.Kind != LineContext
+			rows[i].line = line.line.NewLineNo
+			if line.added != nil {
+				rows[i].line = line.added.NewLineNo
+			}
+		}
+		return rows
+	})
+}
+
+// ChangedLines returns the new file line numbers the diff changed: the added
+// lines, and for removals without a replacement the line that followed them
+func ChangedLines(diffText string) ([]int, error) {
+	diffResult, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		return nil, err
+	}
+	var lines []int
+	for _, h := range diffResult.Hunks {
+		removed := false
+		for _, line := range h.Lines {
+			switch line.Kind {
+			case LineAdded:
+				lines = append(lines, line.NewLineNo)
+				removed = false
+			case LineRemoved:
+				removed = true
+			case LineContext:
+				if removed {
+					lines = append(lines, line.NewLineNo)
+				}
+				removed = false
+			}
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,84 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+var changesFixture = strings.Join([]string{
+	"--- a/main.go",
+	"+++ b/main.go",
+	"@@ -1,4 +1,4 @@",
+	" package main",
+	"-var a = 1",
+	"+var a = 2",
+	" var b = 1",
+	" var c = 1",
+	"@@ -10,4 +10,3 @@",
+	" func f() {",
+	"-	old()",
+	" }",
+	" ",
+	"",
+}, "\n")
+
+func TestChangedLines(t *testing.T) {
+	got, err := ChangedLines(changesFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 11}; !slices.Equal(got, want) {
+		t.Errorf("ChangedLines() = %v, want %v", got, want)
+	}
+}
+
+func TestLayoutLineAt(t *testing.T) {
+	l, err := UnifiedLayout(changesFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		row  int
+		want int
+	}{
+		{0, 1},
+		// the removed line takes the line that replaced it
+		{1, 2},
+		{2, 2},
+		{5, 10},
+		{6, 11},
+		{100, 12},
+	}
+	for _, tc := range cases {
+		if got := l.LineAt(tc.row); got != tc.want {
+			t.Errorf("LineAt(%d) = %d, want %d", tc.row, got, tc.want)
+		}
+	}
+}
+
+func TestGutterMarks(t *testing.T) {
+	diffResult, err := ParseUnifiedDiff(strings.Join([]string{
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1,7 +1,7 @@",
+		" package main",
+		"-var a = 1",
+		"+var a = 2",
+		"+var b = 2",
+		" var c = 1",
+		"-var d = 1",
+		" var e = 1",
+		" var f = 1",
+		"-var g = 1",
+		"",
+	}, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := gutterMarks(diffResult.Hunks)
+	want := []GutterMark{GutterNone, GutterModified, GutterAdded, GutterNone, GutterRemoved, GutterRemoved}
+	if !slices.Equal(got, want) {
+		t.Errorf("gutterMarks() = %v, want %v", got, want)
+	}
+}
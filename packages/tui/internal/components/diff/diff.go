@@ -9,7 +9,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 	"unicode/utf8"
 
 	"github.com/alecthomas/chroma/v2"
@@ -80,7 +79,8 @@ type linePair struct {
 
 // UnifiedConfig configures the rendering of unified diffs
 type UnifiedConfig struct {
-	Width int
+	Width           int
+	LineNumberWidth int
 }
 
 // UnifiedOption modifies a UnifiedConfig
@@ -208,6 +208,31 @@ func ParseUnifiedDiff(diff string) (DiffResult, error) {
 	return result, scanner.Err()
 }
 
+// NewContent reconstructs the new side of a diff that was generated with full
+// context, dropping removed lines and diff markers
+func NewContent(diff string) (string, error) {
+	parsed, err := ParseUnifiedDiff(diff)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for _, hunk := range parsed.Hunks {
+		for _, line := range hunk.Lines {
+			switch line.Kind {
+			case LineRemoved:
+				continue
+			case LineContext:
+				// context lines keep their leading space marker when parsed
+				lines = append(lines, strings.TrimPrefix(line.Content, " "))
+			default:
+				lines = append(lines, line.Content)
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
 // HighlightIntralineChanges updates lines in a hunk to show character-level differences
 func HighlightIntralineChanges(h *Hunk) {
 	var updated []DiffLine
@@ -305,8 +330,14 @@ func pairLines(lines []DiffLine) []linePair {
 func SyntaxHighlight(w io.Writer, source, fileName, formatter string, bg color.Color) error {
 	t := theme.CurrentTheme()
 
-	// Determine the language lexer to use
-	l := lexers.Match(fileName)
+	// Determine the language lexer to use, preferring a configured override
+	var l chroma.Lexer
+	if language, ok := util.LanguageOverride(util.Extension(fileName)); ok {
+		l = lexers.Get(language)
+	}
+	if l == nil {
+		l = lexers.Match(fileName)
+	}
 	if l == nil {
 		l = lexers.Analyse(source)
 	}
@@ -536,13 +567,39 @@ func highlightLine(fileName string, line string, bg color.Color) string {
 	return buf.String()
 }
 
-// createStyles generates the lipgloss styles needed for rendering diffs
-func createStyles(t theme.Theme) (removedLineStyle, addedLineStyle, contextLineStyle, lineNumberStyle stylesi.Style) {
-	removedLineStyle = stylesi.NewStyle().Background(t.DiffRemovedBg())
-	addedLineStyle = stylesi.NewStyle().Background(t.DiffAddedBg())
-	contextLineStyle = stylesi.NewStyle().Background(t.DiffContextBg())
-	lineNumberStyle = stylesi.NewStyle().Foreground(t.TextMuted()).Background(t.DiffLineNumber())
-	return
+// diffStyles holds the styles and colors diff lines are rendered with
+type diffStyles struct {
+	removedLine, addedLine, contextLine       stylesi.Style
+	lineNumber                                stylesi.Style
+	removedLineNumber, addedLineNumber        stylesi.Style
+	removedMarker, addedMarker, contextMarker stylesi.Style
+	removed, added                            compat.AdaptiveColor
+	highlightRemoved, highlightAdded          compat.AdaptiveColor
+}
+
+// createStyles builds the diff styles from the theme, with the colors of the
+// selected diff preset in place of the theme's own
+func createStyles(t theme.Theme) diffStyles {
+	p := themePalette(t)
+	if palette, ok := palettes[preset]; ok {
+		p = palette
+	}
+	lineNumber := stylesi.NewStyle().Foreground(t.TextMuted()).Background(t.DiffLineNumber())
+	return diffStyles{
+		removedLine:       stylesi.NewStyle().Background(p.removedBg),
+		addedLine:         stylesi.NewStyle().Background(p.addedBg),
+		contextLine:       stylesi.NewStyle().Background(t.DiffContextBg()),
+		lineNumber:        lineNumber,
+		removedLineNumber: lineNumber.Background(p.removedLineNumberBg).Foreground(p.removed),
+		addedLineNumber:   lineNumber.Background(p.addedLineNumberBg).Foreground(p.added),
+		removedMarker:     stylesi.NewStyle().Foreground(p.removed).Background(p.removedBg).Bold(symbols),
+		addedMarker:       stylesi.NewStyle().Foreground(p.added).Background(p.addedBg).Bold(symbols),
+		contextMarker:     stylesi.NewStyle().Foreground(t.TextMuted()).Background(t.DiffContextBg()),
+		removed:           p.removed,
+		added:             p.added,
+		highlightRemoved:  p.highlightRemoved,
+		highlightAdded:    p.highlightAdded,
+	}
 }
 
 // -------------------------------------------------------------------------
@@ -652,6 +709,7 @@ func applyHighlighting(content string, segments []Segment, segmentType LineType,
 			} else {
 				sb.WriteString("\x1b[39m")
 			}
+			sb.WriteString(segmentPattern(segmentType))
 			sb.WriteString(char)
 
 			// Full reset of all attributes to ensure clean state
@@ -672,16 +730,16 @@ func applyHighlighting(content string, segments []Segment, segmentType LineType,
 }
 
 // renderLinePrefix renders the line number and marker prefix for a diff line
-func renderLinePrefix(dl DiffLine, lineNum string, marker string, lineNumberStyle stylesi.Style, t theme.Theme) string {
+func renderLinePrefix(dl DiffLine, lineNum string, marker string, lineNumberStyle stylesi.Style, s diffStyles) string {
 	// Style the marker based on line type
 	var styledMarker string
 	switch dl.Kind {
 	case LineRemoved:
-		styledMarker = stylesi.NewStyle().Foreground(t.DiffRemoved()).Background(t.DiffRemovedBg()).Render(marker)
+		styledMarker = s.removedMarker.Render(marker)
 	case LineAdded:
-		styledMarker = stylesi.NewStyle().Foreground(t.DiffAdded()).Background(t.DiffAddedBg()).Render(marker)
+		styledMarker = s.addedMarker.Render(marker)
 	case LineContext:
-		styledMarker = stylesi.NewStyle().Foreground(t.TextMuted()).Background(t.DiffContextBg()).Render(marker)
+		styledMarker = s.contextMarker.Render(marker)
 	default:
 		styledMarker = marker
 	}
@@ -715,8 +773,9 @@ func renderLineContent(fileName string, dl DiffLine, bgStyle stylesi.Style, high
 }
 
 // renderUnifiedLine renders a single line in unified diff format
-func renderUnifiedLine(fileName string, dl DiffLine, width int, t theme.Theme) string {
-	removedLineStyle, addedLineStyle, contextLineStyle, lineNumberStyle := createStyles(t)
+func renderUnifiedLine(fileName string, dl DiffLine, width, numberWidth int, t theme.Theme) string {
+	s := createStyles(t)
+	lineNumberStyle := s.lineNumber
 
 	// Determine line style and marker based on line type
 	var marker string
@@ -727,36 +786,24 @@ func renderUnifiedLine(fileName string, dl DiffLine, width int, t theme.Theme) s
 	switch dl.Kind {
 	case LineRemoved:
 		marker = "-"
-		bgStyle = removedLineStyle
-		lineNumberStyle = lineNumberStyle.Background(t.DiffRemovedLineNumberBg()).Foreground(t.DiffRemoved())
-		highlightColor = t.DiffHighlightRemoved() // TODO: handle "none"
-		if dl.OldLineNo > 0 {
-			lineNum = fmt.Sprintf("%6d       ", dl.OldLineNo)
-		} else {
-			lineNum = "            "
-		}
+		bgStyle = s.removedLine
+		lineNumberStyle = s.removedLineNumber
+		highlightColor = s.highlightRemoved // TODO: handle "none"
+		lineNum = formatLineNumbers(dl.OldLineNo, 0, numberWidth)
 	case LineAdded:
 		marker = "+"
-		bgStyle = addedLineStyle
-		lineNumberStyle = lineNumberStyle.Background(t.DiffAddedLineNumberBg()).Foreground(t.DiffAdded())
-		highlightColor = t.DiffHighlightAdded() // TODO: handle "none"
-		if dl.NewLineNo > 0 {
-			lineNum = fmt.Sprintf("      %7d", dl.NewLineNo)
-		} else {
-			lineNum = "            "
-		}
+		bgStyle = s.addedLine
+		lineNumberStyle = s.addedLineNumber
+		highlightColor = s.highlightAdded // TODO: handle "none"
+		lineNum = formatLineNumbers(0, dl.NewLineNo, numberWidth)
 	case LineContext:
 		marker = " "
-		bgStyle = contextLineStyle
-		if dl.OldLineNo > 0 && dl.NewLineNo > 0 {
-			lineNum = fmt.Sprintf("%6d %6d", dl.OldLineNo, dl.NewLineNo)
-		} else {
-			lineNum = "            "
-		}
+		bgStyle = s.contextLine
+		lineNum = formatLineNumbers(dl.OldLineNo, dl.NewLineNo, numberWidth)
 	}
 
 	// Create the line prefix
-	prefix := renderLinePrefix(dl, lineNum, marker, lineNumberStyle, t)
+	prefix := renderLinePrefix(dl, lineNum, marker, lineNumberStyle, s)
 
 	// Render the content
 	prefixWidth := ansi.StringWidth(prefix)
@@ -771,15 +818,15 @@ func renderDiffColumnLine(
 	fileName string,
 	dl *DiffLine,
 	colWidth int,
+	numberWidth int,
 	isLeftColumn bool,
 	t theme.Theme,
 ) string {
+	s := createStyles(t)
 	if dl == nil {
-		contextLineStyle := stylesi.NewStyle().Background(t.DiffContextBg())
-		return contextLineStyle.Width(colWidth).Render("")
+		return s.contextLine.Width(colWidth).Render("")
 	}
-
-	removedLineStyle, addedLineStyle, contextLineStyle, lineNumberStyle := createStyles(t)
+	lineNumberStyle := s.lineNumber
 
 	// Determine line style based on line type and column
 	var marker string
@@ -792,45 +839,45 @@ func renderDiffColumnLine(
 		switch dl.Kind {
 		case LineRemoved:
 			marker = "-"
-			bgStyle = removedLineStyle
-			lineNumberStyle = lineNumberStyle.Background(t.DiffRemovedLineNumberBg()).Foreground(t.DiffRemoved())
-			highlightColor = t.DiffHighlightRemoved() // TODO: handle "none"
+			bgStyle = s.removedLine
+			lineNumberStyle = s.removedLineNumber
+			highlightColor = s.highlightRemoved // TODO: handle "none"
 		case LineAdded:
 			marker = "?"
-			bgStyle = contextLineStyle
+			bgStyle = s.contextLine
 		case LineContext:
 			marker = " "
-			bgStyle = contextLineStyle
+			bgStyle = s.contextLine
 		}
 
 		// Format line number for left column
-		if dl.OldLineNo > 0 {
-			lineNum = fmt.Sprintf("%6d", dl.OldLineNo)
+		if numberWidth > 0 {
+			lineNum = formatLineNumber(dl.OldLineNo, numberWidth)
 		}
 	} else {
 		// Right column logic
 		switch dl.Kind {
 		case LineAdded:
 			marker = "+"
-			bgStyle = addedLineStyle
-			lineNumberStyle = lineNumberStyle.Background(t.DiffAddedLineNumberBg()).Foreground(t.DiffAdded())
-			highlightColor = t.DiffHighlightAdded()
+			bgStyle = s.addedLine
+			lineNumberStyle = s.addedLineNumber
+			highlightColor = s.highlightAdded
 		case LineRemoved:
 			marker = "?"
-			bgStyle = contextLineStyle
+			bgStyle = s.contextLine
 		case LineContext:
 			marker = " "
-			bgStyle = contextLineStyle
+			bgStyle = s.contextLine
 		}
 
 		// Format line number for right column
-		if dl.NewLineNo > 0 {
-			lineNum = fmt.Sprintf("%6d", dl.NewLineNo)
+		if numberWidth > 0 {
+			lineNum = formatLineNumber(dl.NewLineNo, numberWidth)
 		}
 	}
 
 	// Create the line prefix
-	prefix := renderLinePrefix(*dl, lineNum, marker, lineNumberStyle, t)
+	prefix := renderLinePrefix(*dl, lineNum, marker, lineNumberStyle, s)
 
 	// Determine if we should render content
 	shouldRenderContent := (dl.Kind == LineRemoved && isLeftColumn) ||
@@ -850,13 +897,13 @@ func renderDiffColumnLine(
 }
 
 // renderLeftColumn formats the left side of a side-by-side diff
-func renderLeftColumn(fileName string, dl *DiffLine, colWidth int) string {
-	return renderDiffColumnLine(fileName, dl, colWidth, true, theme.CurrentTheme())
+func renderLeftColumn(fileName string, dl *DiffLine, colWidth, numberWidth int) string {
+	return renderDiffColumnLine(fileName, dl, colWidth, numberWidth, true, theme.CurrentTheme())
 }
 
 // renderRightColumn formats the right side of a side-by-side diff
-func renderRightColumn(fileName string, dl *DiffLine, colWidth int) string {
-	return renderDiffColumnLine(fileName, dl, colWidth, false, theme.CurrentTheme())
+func renderRightColumn(fileName string, dl *DiffLine, colWidth, numberWidth int) string {
+	return renderDiffColumnLine(fileName, dl, colWidth, numberWidth, false, theme.CurrentTheme())
 }
 
 // -------------------------------------------------------------------------
@@ -875,11 +922,13 @@ func RenderUnifiedHunk(fileName string, h Hunk, opts ...UnifiedOption) string {
 	// Highlight changes within lines
 	HighlightIntralineChanges(&hunkCopy)
 
+	numberWidth := lineNumberWidth(config, hunkCopy)
+
 	var sb strings.Builder
 	sb.Grow(len(hunkCopy.Lines) * config.Width)
 
 	util.WriteStringsPar(&sb, hunkCopy.Lines, func(line DiffLine) string {
-		return renderUnifiedLine(fileName, line, config.Width, theme.CurrentTheme()) + "\n"
+		return renderUnifiedLine(fileName, line, config.Width, numberWidth, theme.CurrentTheme()) + "\n"
 	})
 
 	return sb.String()
@@ -905,22 +954,12 @@ func RenderSideBySideHunk(fileName string, h Hunk, opts ...UnifiedOption) string
 
 	leftWidth := colWidth
 	rightWidth := config.Width - colWidth
+	numberWidth := lineNumberWidth(config, hunkCopy)
 	var sb strings.Builder
 
 	util.WriteStringsPar(&sb, pairs, func(p linePair) string {
-		wg := &sync.WaitGroup{}
-		var leftStr, rightStr string
-		wg.Add(2)
-		go func() {
-			defer wg.Done()
-			leftStr = renderLeftColumn(fileName, p.left, leftWidth)
-		}()
-		go func() {
-			defer wg.Done()
-			rightStr = renderRightColumn(fileName, p.right, rightWidth)
-		}()
-		wg.Wait()
-		return leftStr + rightStr + "\n"
+		return renderLeftColumn(fileName, p.left, leftWidth, numberWidth) +
+			renderRightColumn(fileName, p.right, rightWidth, numberWidth) + "\n"
 	})
 
 	return sb.String()
@@ -928,30 +967,44 @@ func RenderSideBySideHunk(fileName string, h Hunk, opts ...UnifiedOption) string
 
 // FormatUnifiedDiff creates a unified formatted view of a diff
 func FormatUnifiedDiff(filename string, diffText string, opts ...UnifiedOption) (string, error) {
+	measure := util.Profile("diff.FormatUnifiedDiff")
+	defer measure("file", filename)
 	diffResult, err := ParseUnifiedDiff(diffText)
 	if err != nil {
 		return "", err
 	}
 
+	// size line numbers for the whole diff so hunks line up
+	opts = append([]UnifiedOption{WithLineNumberWidth(maxLineNumberWidth(diffResult.Hunks))}, opts...)
+
+	opts, center := pinWidth(opts, 1)
+
 	var sb strings.Builder
 	util.WriteStringsPar(&sb, diffResult.Hunks, func(h Hunk) string {
 		return RenderUnifiedHunk(filename, h, opts...)
 	})
 
-	return sb.String(), nil
+	return center(sb.String()), nil
 }
 
 // FormatDiff creates a side-by-side formatted view of a diff
 func FormatDiff(filename string, diffText string, opts ...UnifiedOption) (string, error) {
+	measure := util.Profile("diff.FormatDiff")
+	defer measure("file", filename)
 	diffResult, err := ParseUnifiedDiff(diffText)
 	if err != nil {
 		return "", err
 	}
 
+	// size line numbers for the whole diff so hunks line up
+	opts = append([]UnifiedOption{WithLineNumberWidth(maxLineNumberWidth(diffResult.Hunks))}, opts...)
+
+	opts, center := pinWidth(opts, 2)
+
 	var sb strings.Builder
 	util.WriteStringsPar(&sb, diffResult.Hunks, func(h Hunk) string {
 		return RenderSideBySideHunk(filename, h, opts...)
 	})
 
-	return sb.String(), nil
+	return center(sb.String()), nil
 }
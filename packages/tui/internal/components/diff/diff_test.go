@@ -0,0 +1,174 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sst/opencode/internal/theme"
+)
+
+func TestMain(m *testing.M) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	theme.SetTheme("opencode")
+	os.Exit(m.Run())
+}
+
+var fixtureLines = []string{
+	`package main`,
+	`import "fmt"`,
+	`func main() {`,
+	`	fmt.Println("hello, world")`,
+	`	for i := 0; i < 10; i++ {`,
+	`		total += values[i] * weight`,
+	`	}`,
+	`	return nil`,
+	`}`,
+	`// a comment that is long enough to be truncated when the diff is rendered in a narrow column`,
+	``,
+	`	if err != nil { return fmt.Errorf("failed: %w", err) }`,
+}
+
+// generateDiff builds a unified diff with a random mix of context, removed
+// and added lines across several hunks
+func generateDiff(seed int64) string {
+	r := rand.New(rand.NewSource(seed))
+	var sb strings.Builder
+	sb.WriteString("diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n")
+
+	oldStart, newStart := 1, 1
+	for range 1 + r.Intn(4) {
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		for range 2 + r.Intn(12) {
+			line := fixtureLines[r.Intn(len(fixtureLines))]
+			switch r.Intn(3) {
+			case 0:
+				body.WriteString(" " + line + "\n")
+				oldCount++
+				newCount++
+			case 1:
+				body.WriteString("-" + line + "\n")
+				oldCount++
+			default:
+				body.WriteString("+" + line + "\n")
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		sb.WriteString(body.String())
+		oldStart += oldCount + 10 + r.Intn(500)
+		newStart += newCount + 10 + r.Intn(500)
+	}
+	return sb.String()
+}
+
+// sequentialUnified renders a unified diff one line at a time as a reference
+// for the parallel renderer
+func sequentialUnified(t *testing.T, diffText string, width int) string {
+	result, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := NewUnifiedConfig(WithWidth(width), WithLineNumberWidth(maxLineNumberWidth(result.Hunks)))
+	var sb strings.Builder
+	for _, h := range result.Hunks {
+		hunkCopy := Hunk{Lines: make([]DiffLine, len(h.Lines))}
+		copy(hunkCopy.Lines, h.Lines)
+		HighlightIntralineChanges(&hunkCopy)
+		numberWidth := lineNumberWidth(config, hunkCopy)
+		for _, line := range hunkCopy.Lines {
+			sb.WriteString(renderUnifiedLine("main.go", line, config.Width, numberWidth, theme.CurrentTheme()) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// sequentialSideBySide renders a side-by-side diff one column at a time as a
+// reference for the parallel renderer
+func sequentialSideBySide(t *testing.T, diffText string, width int) string {
+	result, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := NewSideBySideConfig(WithWidth(width), WithLineNumberWidth(maxLineNumberWidth(result.Hunks)))
+	var sb strings.Builder
+	for _, h := range result.Hunks {
+		hunkCopy := Hunk{Lines: make([]DiffLine, len(h.Lines))}
+		copy(hunkCopy.Lines, h.Lines)
+		HighlightIntralineChanges(&hunkCopy)
+		numberWidth := lineNumberWidth(config, hunkCopy)
+		leftWidth := config.Width / 2
+		rightWidth := config.Width - leftWidth
+		for _, p := range pairLines(hunkCopy.Lines) {
+			sb.WriteString(renderLeftColumn("main.go", p.left, leftWidth, numberWidth))
+			sb.WriteString(renderRightColumn("main.go", p.right, rightWidth, numberWidth))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+func TestFormatUnifiedDiffMatchesSequential(t *testing.T) {
+	for seed := range int64(10) {
+		diffText := generateDiff(seed)
+		width := 40 + int(seed)*3
+		expected := sequentialUnified(t, diffText, width)
+		// render repeatedly so scheduling differences get a chance to reorder output
+		for range 3 {
+			got, err := FormatUnifiedDiff("main.go", diffText, WithWidth(width))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("seed %d: parallel unified output differs from sequential reference", seed)
+			}
+		}
+	}
+}
+
+func TestFormatDiffMatchesSequential(t *testing.T) {
+	for seed := range int64(10) {
+		diffText := generateDiff(seed)
+		width := 80 + int(seed)*5
+		expected := sequentialSideBySide(t, diffText, width)
+		for range 3 {
+			got, err := FormatDiff("main.go", diffText, WithWidth(width))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("seed %d: parallel side-by-side output differs from sequential reference", seed)
+			}
+		}
+	}
+}
+
+func BenchmarkFormatUnifiedDiff(b *testing.B) {
+	diffText := generateDiff(1)
+	for b.Loop() {
+		FormatUnifiedDiff("main.go", diffText, WithWidth(120))
+	}
+}
+
+func BenchmarkFormatDiff(b *testing.B) {
+	diffText := generateDiff(1)
+	for b.Loop() {
+		FormatDiff("main.go", diffText, WithWidth(200))
+	}
+}
+
+func BenchmarkRenderSideBySideHunk(b *testing.B) {
+	result, err := ParseUnifiedDiff(generateDiff(2))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		RenderSideBySideHunk("main.go", result.Hunks[0], WithWidth(200))
+	}
+}
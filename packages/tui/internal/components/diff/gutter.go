@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// GutterMark is the change marker shown beside a line of a modified file
+type GutterMark int
+
+const (
+	GutterNone     GutterMark = iota // Line is unchanged
+	GutterAdded                      // Line was added
+	GutterModified                   // Line replaced a removed line
+	GutterRemoved                    // Lines were removed just above the line
+)
+
+// gutterMarks returns the marker of each line in the new side of the hunks,
+// in order. Removals without a replacement are marked on the line that
+// followed them, or on the last line when they were at the end of the file.
+func gutterMarks(hunks []Hunk) []GutterMark {
+	var marks []GutterMark
+	removed := 0
+	replaced := false
+	for _, h := range hunks {
+		for _, line := range h.Lines {
+			switch line.Kind {
+			case LineRemoved:
+				removed++
+			case LineAdded:
+				if removed > 0 {
+					marks = append(marks, GutterModified)
+					removed--
+					replaced = true
+				} else {
+					marks = append(marks, GutterAdded)
+				}
+			case LineContext:
+				mark := GutterNone
+				if removed > 0 && !replaced {
+					mark = GutterRemoved
+				}
+				marks = append(marks, mark)
+				removed = 0
+				replaced = false
+			}
+		}
+	}
+	if n := len(marks); removed > 0 && !replaced && n > 0 && marks[n-1] == GutterNone {
+		marks[n-1] = GutterRemoved
+	}
+	return marks
+}
+
+// FormatGutterFile renders the new side of a diff generated with full context
+// as a plain file, with a gutter marking the changed lines when gutter is
+// set. It returns the layout of the output so changes can be navigated.
+func FormatGutterFile(filename string, diffText string, gutter bool, opts ...UnifiedOption) (string, Layout, error) {
+	measure := util.Profile("diff.FormatGutterFile")
+	defer measure("file", filename)
+	diffResult, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		return "", Layout{}, err
+	}
+
+	var lines []DiffLine
+	for _, h := range diffResult.Hunks {
+		for _, line := range h.Lines {
+			if line.Kind == LineRemoved {
+				continue
+			}
+			// context lines keep their leading space marker when parsed
+			content := line.Content
+			if line.Kind == LineContext {
+				content = strings.TrimPrefix(content, " ")
+			}
+			lines = append(lines, DiffLine{
+				NewLineNo: line.NewLineNo,
+				Kind:      LineContext,
+				Content:   " " + content,
+			})
+		}
+	}
+	marks := gutterMarks(diffResult.Hunks)
+
+	var l Layout
+	for i, mark := range marks {
+		if mark != GutterNone && (i == 0 || marks[i-1] == GutterNone) {
+			l.Changes = append(l.Changes, i)
+		}
+		l.Lines = append(l.Lines, i+1)
+	}
+
+	opts = append([]UnifiedOption{WithLineNumberWidth(maxLineNumberWidth(diffResult.Hunks))}, opts...)
+	opts, center := pinWidth(opts, 1)
+	config := NewUnifiedConfig(opts...)
+	numberWidth := lineNumberWidth(config, diffResult.Hunks...)
+
+	rows := make([]int, len(lines))
+	for i := range rows {
+		rows[i] = i
+	}
+	var sb strings.Builder
+	util.WriteStringsPar(&sb, rows, func(i int) string {
+		mark := GutterNone
+		if gutter && i < len(marks) {
+			mark = marks[i]
+		}
+		return renderGutterLine(filename, lines[i], mark, gutter, config.Width, numberWidth) + "\n"
+	})
+	return center(sb.String()), l, nil
+}
+
+// renderGutterLine renders a line of a file with its line number and, when
+// gutter is set, its change marker
+func renderGutterLine(fileName string, dl DiffLine, mark GutterMark, gutter bool, width, numberWidth int) string {
+	t := theme.CurrentTheme()
+	s := createStyles(t)
+	contextLineStyle, lineNumberStyle := s.contextLine, s.lineNumber
+
+	prefix := formatLineNumber(dl.NewLineNo, numberWidth)
+	if numberWidth == 0 {
+		prefix = ""
+	}
+	if gutter {
+		marker := " "
+		var color compat.AdaptiveColor
+		switch mark {
+		case GutterAdded:
+			marker, color = "▎", s.added
+		case GutterModified:
+			marker, color = "▎", t.Warning()
+		case GutterRemoved:
+			marker, color = "▔", s.removed
+		}
+		style := contextLineStyle
+		if mark != GutterNone {
+			style = style.Foreground(color)
+		}
+		prefix = style.Render(marker) + lineNumberStyle.Render(prefix)
+	} else {
+		prefix = lineNumberStyle.Render(prefix)
+	}
+
+	contentWidth := width - ansi.StringWidth(prefix)
+	return prefix + renderLineContent(fileName, dl, contextLineStyle, compat.AdaptiveColor{}, contentWidth)
+}
@@ -0,0 +1,143 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	stylesi "github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// minInlineSimilarity is the fraction of a changed line pair that must be
+// unchanged for it to be merged into a single word diff line. Pairs that
+// changed more than that read better as separate removed and added lines.
+const minInlineSimilarity = 0.4
+
+// inlineLine is either a single diff line or a merged removed/added pair
+type inlineLine struct {
+	line    DiffLine
+	added   *DiffLine
+	patches []diffmatchpatch.Diff
+}
+
+// inlineLines merges removed lines directly followed by a similar added line
+func inlineLines(lines []DiffLine) []inlineLine {
+	dmp := diffmatchpatch.New()
+	var result []inlineLine
+	for i := 0; i < len(lines); i++ {
+		if i+1 < len(lines) && lines[i].Kind == LineRemoved && lines[i+1].Kind == LineAdded {
+			patches := dmp.DiffMain(lines[i].Content, lines[i+1].Content, false)
+			patches = dmp.DiffCleanupSemantic(patches)
+			if similarity(patches, lines[i].Content, lines[i+1].Content) >= minInlineSimilarity {
+				result = append(result, inlineLine{line: lines[i], added: &lines[i+1], patches: patches})
+				i++
+				continue
+			}
+		}
+		result = append(result, inlineLine{line: lines[i]})
+	}
+	return result
+}
+
+// similarity reports the share of both lines covered by unchanged text
+func similarity(patches []diffmatchpatch.Diff, old, new string) float64 {
+	total := len(old) + len(new)
+	if total == 0 {
+		return 1
+	}
+	equal := 0
+	for _, patch := range patches {
+		if patch.Type == diffmatchpatch.DiffEqual {
+			equal += len(patch.Text)
+		}
+	}
+	return float64(2*equal) / float64(total)
+}
+
+// renderWordDiffLine renders a removed/added pair as one line with removed
+// words struck through and added words highlighted
+func renderWordDiffLine(l inlineLine, width, numberWidth int, t theme.Theme) string {
+	s := createStyles(t)
+	contextLineStyle, lineNumberStyle := s.contextLine, s.lineNumber
+	removedStyle := stylesi.NewStyle().
+		Foreground(t.BackgroundPanel()).
+		Background(s.highlightRemoved).
+		Strikethrough(true)
+	addedStyle := stylesi.NewStyle().
+		Foreground(t.BackgroundPanel()).
+		Background(s.highlightAdded).
+		Underline(symbols)
+	equalStyle := contextLineStyle.Foreground(t.Text())
+
+	var content strings.Builder
+	content.WriteString(contextLineStyle.Render(" "))
+	for _, patch := range l.patches {
+		text := strings.ReplaceAll(patch.Text, "\t", "    ")
+		switch patch.Type {
+		case diffmatchpatch.DiffDelete:
+			content.WriteString(removedStyle.Render(text))
+		case diffmatchpatch.DiffInsert:
+			content.WriteString(addedStyle.Render(text))
+		default:
+			content.WriteString(equalStyle.Render(text))
+		}
+	}
+
+	lineNum := formatLineNumbers(l.line.OldLineNo, l.added.NewLineNo, numberWidth)
+	marker := stylesi.NewStyle().Foreground(t.Warning()).Background(t.DiffContextBg()).Bold(symbols).Render("~")
+	prefix := lineNumberStyle.Render(lineNum + " " + marker)
+
+	contentWidth := width - ansi.StringWidth(prefix)
+	return prefix + contextLineStyle.MaxHeight(1).Width(contentWidth).Render(
+		ansi.Truncate(content.String(), contentWidth, "..."),
+	)
+}
+
+// RenderInlineWordDiff formats a hunk as a unified diff where each changed
+// line that is similar to its replacement is shown once, with the removed
+// words struck through and the added words highlighted in place
+func RenderInlineWordDiff(fileName string, h Hunk, opts ...UnifiedOption) string {
+	config := NewUnifiedConfig(opts...)
+
+	hunkCopy := Hunk{Lines: make([]DiffLine, len(h.Lines))}
+	copy(hunkCopy.Lines, h.Lines)
+	HighlightIntralineChanges(&hunkCopy)
+
+	numberWidth := lineNumberWidth(config, hunkCopy)
+
+	var sb strings.Builder
+	sb.Grow(len(hunkCopy.Lines) * config.Width)
+
+	util.WriteStringsPar(&sb, inlineLines(hunkCopy.Lines), func(l inlineLine) string {
+		if l.added != nil {
+			return renderWordDiffLine(l, config.Width, numberWidth, theme.CurrentTheme()) + "\n"
+		}
+		return renderUnifiedLine(fileName, l.line, config.Width, numberWidth, theme.CurrentTheme()) + "\n"
+	})
+
+	return sb.String()
+}
+
+// FormatInlineDiff creates a word diff view of a diff
+func FormatInlineDiff(filename string, diffText string, opts ...UnifiedOption) (string, error) {
+	measure := util.Profile("diff.FormatInlineDiff")
+	defer measure("file", filename)
+	diffResult, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		return "", err
+	}
+
+	// size line numbers for the whole diff so hunks line up
+	opts = append([]UnifiedOption{WithLineNumberWidth(maxLineNumberWidth(diffResult.Hunks))}, opts...)
+
+	opts, center := pinWidth(opts, 1)
+
+	var sb strings.Builder
+	util.WriteStringsPar(&sb, diffResult.Hunks, func(h Hunk) string {
+		return RenderInlineWordDiff(filename, h, opts...)
+	})
+
+	return center(sb.String()), nil
+}
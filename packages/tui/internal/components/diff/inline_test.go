@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestInlineLines(t *testing.T) {
+	lines := []DiffLine{
+		{Kind: LineContext, Content: "func main() {"},
+		{Kind: LineRemoved, Content: `	fmt.Println("hello")`},
+		{Kind: LineAdded, Content: `	fmt.Println("hello, world")`},
+		{Kind: LineRemoved, Content: "	return nil"},
+		{Kind: LineAdded, Content: "	os.Exit(1) // something else entirely"},
+	}
+	got := inlineLines(lines)
+	if len(got) != 4 {
+		t.Fatalf("inlineLines() returned %d lines, want 4", len(got))
+	}
+	if got[1].added == nil || got[1].added.Content != lines[2].Content {
+		t.Errorf("similar pair was not merged: %+v", got[1])
+	}
+	if got[2].added != nil || got[3].added != nil {
+		t.Errorf("dissimilar pair was merged: %+v %+v", got[2], got[3])
+	}
+}
+
+func TestFormatInlineDiff(t *testing.T) {
+	diffText := strings.Join([]string{
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1,2 +1,2 @@",
+		" func main() {",
+		`-	fmt.Println("hello")`,
+		`+	fmt.Println("hello, world")`,
+		"",
+	}, "\n")
+	rendered, err := FormatInlineDiff("main.go", diffText, WithWidth(60))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(ansi.Strip(rendered), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("rendered %d lines, want 2:\n%s", len(lines), ansi.Strip(rendered))
+	}
+	if !strings.Contains(lines[1], "~") || !strings.Contains(lines[1], `fmt.Println("hello, world")`) {
+		t.Errorf("changed line = %q, want one word diff line", lines[1])
+	}
+	for _, line := range lines {
+		if width := ansi.StringWidth(line); width != 60 {
+			t.Errorf("line %q is %d wide, want 60", line, width)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LineNumbers selects how line numbers are shown in the diff gutter
+type LineNumbers string
+
+const (
+	// LineNumbersAdaptive sizes the gutter to the largest line number in the diff
+	LineNumbersAdaptive LineNumbers = "adaptive"
+	// LineNumbersFixed always uses a six column gutter
+	LineNumbersFixed LineNumbers = "fixed"
+	// LineNumbersNone hides line numbers
+	LineNumbersNone LineNumbers = "none"
+)
+
+const fixedLineNumberWidth = 6
+
+var lineNumbers = LineNumbersAdaptive
+
+// SetLineNumbers selects the line number format, falling back to adaptive
+// for unknown names
+func SetLineNumbers(name string) {
+	switch LineNumbers(name) {
+	case LineNumbersFixed, LineNumbersNone:
+		lineNumbers = LineNumbers(name)
+	default:
+		lineNumbers = LineNumbersAdaptive
+	}
+}
+
+// WithLineNumberWidth sizes the line number gutter for adaptive line numbers,
+// so every hunk of a diff lines up. Without it each hunk is sized on its own.
+func WithLineNumberWidth(width int) UnifiedOption {
+	return func(u *UnifiedConfig) {
+		if width > 0 {
+			u.LineNumberWidth = width
+		}
+	}
+}
+
+// lineNumberWidth returns the width of a single line number column, or 0 when
+// line numbers are hidden
+func lineNumberWidth(config UnifiedConfig, hunks ...Hunk) int {
+	switch lineNumbers {
+	case LineNumbersNone:
+		return 0
+	case LineNumbersFixed:
+		return fixedLineNumberWidth
+	}
+	if config.LineNumberWidth > 0 {
+		return config.LineNumberWidth
+	}
+	return maxLineNumberWidth(hunks)
+}
+
+// maxLineNumberWidth returns the number of digits in the largest line number
+// of the given hunks
+func maxLineNumberWidth(hunks []Hunk) int {
+	maxLine := 0
+	for _, h := range hunks {
+		for _, line := range h.Lines {
+			maxLine = max(maxLine, line.OldLineNo, line.NewLineNo)
+		}
+	}
+	return len(strconv.Itoa(maxLine))
+}
+
+// formatLineNumber pads a line number to width, leaving it blank when the
+// line doesn't exist on that side
+func formatLineNumber(n, width int) string {
+	if n <= 0 {
+		return strings.Repeat(" ", width)
+	}
+	return fmt.Sprintf("%*d", width, n)
+}
+
+// formatLineNumbers formats the old and new line numbers of a unified diff
+// line, returning an empty gutter when line numbers are hidden
+func formatLineNumbers(oldLineNo, newLineNo, width int) string {
+	if width == 0 {
+		return ""
+	}
+	return formatLineNumber(oldLineNo, width) + " " + formatLineNumber(newLineNo, width)
+}
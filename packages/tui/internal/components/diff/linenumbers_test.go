@@ -0,0 +1,46 @@
+package diff
+
+import "testing"
+
+func TestLineNumberWidth(t *testing.T) {
+	defer SetLineNumbers("")
+	hunks := []Hunk{{Lines: []DiffLine{
+		{Kind: LineContext, OldLineNo: 9, NewLineNo: 9},
+		{Kind: LineAdded, NewLineNo: 120},
+	}}}
+
+	tests := []struct {
+		lineNumbers string
+		config      UnifiedConfig
+		want        int
+	}{
+		{"adaptive", UnifiedConfig{}, 3},
+		{"adaptive", UnifiedConfig{LineNumberWidth: 5}, 5},
+		{"unknown", UnifiedConfig{}, 3},
+		{"fixed", UnifiedConfig{}, fixedLineNumberWidth},
+		{"none", UnifiedConfig{LineNumberWidth: 5}, 0},
+	}
+	for _, tt := range tests {
+		SetLineNumbers(tt.lineNumbers)
+		if got := lineNumberWidth(tt.config, hunks...); got != tt.want {
+			t.Errorf("%s with %+v: lineNumberWidth() = %d, want %d", tt.lineNumbers, tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestFormatLineNumbers(t *testing.T) {
+	tests := []struct {
+		oldLineNo, newLineNo, width int
+		want                        string
+	}{
+		{9, 10, 2, " 9 10"},
+		{0, 120, 3, "    120"},
+		{7, 0, 1, "7  "},
+		{7, 7, 0, ""},
+	}
+	for _, tt := range tests {
+		if got := formatLineNumbers(tt.oldLineNo, tt.newLineNo, tt.width); got != tt.want {
+			t.Errorf("formatLineNumbers(%d, %d, %d) = %q, want %q", tt.oldLineNo, tt.newLineNo, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HunkPatch returns a single hunk of a diff as a standalone unified diff,
+// with the file header, that can be applied with git apply or patch
+func HunkPatch(diffText string, index int) (string, error) {
+	diffResult, err := ParseUnifiedDiff(diffText)
+	if err != nil {
+		return "", err
+	}
+	if index < 0 || index >= len(diffResult.Hunks) {
+		return "", fmt.Errorf("hunk %d out of range", index)
+	}
+
+	var sb strings.Builder
+	if diffResult.OldFile != "" || diffResult.NewFile != "" {
+		sb.WriteString("--- " + patchFile("a/", diffResult.OldFile) + "\n")
+		sb.WriteString("+++ " + patchFile("b/", diffResult.NewFile) + "\n")
+	}
+	h := diffResult.Hunks[index]
+	sb.WriteString(h.Header + "\n")
+	for _, line := range h.Lines {
+		switch line.Kind {
+		case LineAdded:
+			sb.WriteString("+" + line.Content)
+		case LineRemoved:
+			sb.WriteString("-" + line.Content)
+		default:
+			// context lines keep their leading space, except when blank
+			sb.WriteString(" " + strings.TrimPrefix(line.Content, " "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// patchFile returns the file header path for one side of a patch, keeping
+// /dev/null for files that are created or deleted
+func patchFile(prefix, file string) string {
+	if file == "" || file == "/dev/null" {
+		return "/dev/null"
+	}
+	return prefix + file
+}
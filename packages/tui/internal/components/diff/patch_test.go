@@ -0,0 +1,30 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHunkPatchKeepsDevNull(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+	}{
+		{"modified", []string{"--- a/a.md", "+++ b/a.md"}},
+		{"created", []string{"--- /dev/null", "+++ b/a.md"}},
+		{"deleted", []string{"--- a/a.md", "+++ /dev/null"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hunk := []string{"@@ -1 +1 @@", "-one", "+two", ""}
+			diffText := strings.Join(append(tt.header, hunk...), "\n")
+			got, err := HunkPatch(diffText, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != diffText {
+				t.Errorf("HunkPatch() =\n%s\nwant\n%s", got, diffText)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package diff
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// Preset selects a diff color scheme independent of the UI theme
+type Preset string
+
+const (
+	PresetTheme        Preset = "theme"
+	PresetGitHub       Preset = "github"
+	PresetHighContrast Preset = "high-contrast"
+	PresetColorblind   Preset = "colorblind"
+)
+
+// diffPalette holds the colors a preset overrides
+type diffPalette struct {
+	added, removed                         compat.AdaptiveColor
+	highlightAdded, highlightRemoved       compat.AdaptiveColor
+	addedBg, removedBg                     compat.AdaptiveColor
+	addedLineNumberBg, removedLineNumberBg compat.AdaptiveColor
+}
+
+func adaptive(dark, light string) compat.AdaptiveColor {
+	return compat.AdaptiveColor{Dark: lipgloss.Color(dark), Light: lipgloss.Color(light)}
+}
+
+var palettes = map[Preset]diffPalette{
+	PresetGitHub: {
+		added:               adaptive("#3fb950", "#1a7f37"),
+		removed:             adaptive("#f85149", "#cf222e"),
+		highlightAdded:      adaptive("#1f4a2c", "#aceebb"),
+		highlightRemoved:    adaptive("#5c2a2d", "#ffcecb"),
+		addedBg:             adaptive("#12261e", "#dafbe1"),
+		removedBg:           adaptive("#25171c", "#ffebe9"),
+		addedLineNumberBg:   adaptive("#1b4721", "#ccffd8"),
+		removedLineNumberBg: adaptive("#542426", "#ffd7d5"),
+	},
+	PresetHighContrast: {
+		added:               adaptive("#00ff00", "#005f00"),
+		removed:             adaptive("#ff0000", "#af0000"),
+		highlightAdded:      adaptive("#008700", "#87ff87"),
+		highlightRemoved:    adaptive("#870000", "#ff8787"),
+		addedBg:             adaptive("#003300", "#d7ffd7"),
+		removedBg:           adaptive("#330000", "#ffd7d7"),
+		addedLineNumberBg:   adaptive("#005f00", "#afffaf"),
+		removedLineNumberBg: adaptive("#5f0000", "#ffafaf"),
+	},
+	// blue and orange stay distinguishable with red-green color blindness
+	PresetColorblind: {
+		added:               adaptive("#56b4e9", "#0072b2"),
+		removed:             adaptive("#e69f00", "#d55e00"),
+		highlightAdded:      adaptive("#0a3d62", "#bfe3f7"),
+		highlightRemoved:    adaptive("#6b4a00", "#fbdcb3"),
+		addedBg:             adaptive("#0d2233", "#e6f3fb"),
+		removedBg:           adaptive("#2e2200", "#fdf1e0"),
+		addedLineNumberBg:   adaptive("#123a55", "#cde8f7"),
+		removedLineNumberBg: adaptive("#4a3600", "#f8dfbd"),
+	},
+}
+
+var preset = PresetTheme
+
+// SetPreset selects the diff color scheme, falling back to the theme's own
+// diff colors for unknown names
+func SetPreset(name string) {
+	preset = PresetTheme
+	if _, ok := palettes[Preset(name)]; ok {
+		preset = Preset(name)
+	}
+}
+
+// themePalette returns the diff colors of a theme, used when no preset is
+// selected
+func themePalette(t theme.Theme) diffPalette {
+	return diffPalette{
+		added:               t.DiffAdded(),
+		removed:             t.DiffRemoved(),
+		highlightAdded:      t.DiffHighlightAdded(),
+		highlightRemoved:    t.DiffHighlightRemoved(),
+		addedBg:             t.DiffAddedBg(),
+		removedBg:           t.DiffRemovedBg(),
+		addedLineNumberBg:   t.DiffAddedLineNumberBg(),
+		removedLineNumberBg: t.DiffRemovedLineNumberBg(),
+	}
+}
+
+var symbols bool
+
+// SetSymbols enables marking changed text with underline for additions and
+// strikethrough for removals, so diffs stay legible without color
+func SetSymbols(enabled bool) {
+	symbols = enabled
+}
+
+// segmentPattern returns the SGR sequence that marks a changed segment of the
+// given line type when symbols are enabled
+func segmentPattern(kind LineType) string {
+	if !symbols {
+		return ""
+	}
+	switch kind {
+	case LineAdded:
+		return "\x1b[4m"
+	case LineRemoved:
+		return "\x1b[9m"
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// textContextLines is how many unchanged lines surround each change
+const textContextLines = 3
+
+type textLine struct {
+	kind LineType
+	text string
+}
+
+// UnifiedText compares two texts line by line and returns a unified diff in the
+// format the renderers accept, or an empty string when the texts are equal
+func UnifiedText(fileName, before, after string) string {
+	// a missing final newline is not worth a change of its own
+	before = strings.TrimSuffix(before, "\n") + "\n"
+	after = strings.TrimSuffix(after, "\n") + "\n"
+
+	dmp := diffmatchpatch.New()
+	a, b, lineArray := dmp.DiffLinesToChars(before, after)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lineArray)
+
+	var lines []textLine
+	changed := false
+	for _, d := range diffs {
+		kind := LineContext
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			kind = LineRemoved
+			changed = true
+		case diffmatchpatch.DiffInsert:
+			kind = LineAdded
+			changed = true
+		}
+		for line := range strings.Lines(d.Text) {
+			lines = append(lines, textLine{kind: kind, text: strings.TrimSuffix(line, "\n")})
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- a/" + fileName + "\n")
+	sb.WriteString("+++ b/" + fileName + "\n")
+
+	// oldLine and newLine are the line numbers of lines[i] on each side
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(lines); {
+		if lines[i].kind == LineContext {
+			oldLine++
+			newLine++
+			i++
+			continue
+		}
+
+		// a hunk starts a few lines before the change and runs until a long
+		// enough stretch of unchanged lines
+		start := max(0, i-textContextLines)
+		end := i
+		for end < len(lines) {
+			if lines[end].kind != LineContext {
+				end++
+				continue
+			}
+			run := end
+			for run < len(lines) && lines[run].kind == LineContext {
+				run++
+			}
+			if run == len(lines) || run-end > 2*textContextLines {
+				end = min(end+textContextLines, len(lines))
+				break
+			}
+			end = run
+		}
+
+		oldStart, newStart := oldLine-(i-start), newLine-(i-start)
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		for _, line := range lines[start:end] {
+			switch line.kind {
+			case LineRemoved:
+				body.WriteString("-" + line.text + "\n")
+				oldCount++
+			case LineAdded:
+				body.WriteString("+" + line.text + "\n")
+				newCount++
+			default:
+				body.WriteString(" " + line.text + "\n")
+				oldCount++
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		sb.WriteString(body.String())
+
+		// continue after the hunk, counting the lines it covered from i
+		for _, line := range lines[i:end] {
+			if line.kind != LineAdded {
+				oldLine++
+			}
+			if line.kind != LineRemoved {
+				newLine++
+			}
+		}
+		i = end
+	}
+	return sb.String()
+}
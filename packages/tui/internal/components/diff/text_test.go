@@ -0,0 +1,47 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedText(t *testing.T) {
+	if got := UnifiedText("a.md", "same\ntext", "same\ntext\n"); got != "" {
+		t.Errorf("equal texts produced a diff:\n%s", got)
+	}
+
+	before := strings.Join([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, "\n")
+	after := strings.Join([]string{"1", "two", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13"}, "\n")
+	want := strings.Join([]string{
+		"--- a/a.md",
+		"+++ b/a.md",
+		"@@ -1,5 +1,5 @@",
+		" 1",
+		"-2",
+		"+two",
+		" 3",
+		" 4",
+		" 5",
+		"@@ -10,3 +10,4 @@",
+		" 10",
+		" 11",
+		" 12",
+		"+13",
+		"",
+	}, "\n")
+	got := UnifiedText("a.md", before, after)
+	if got != want {
+		t.Errorf("UnifiedText() =\n%s\nwant\n%s", got, want)
+	}
+
+	parsed, err := ParseUnifiedDiff(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Hunks) != 2 {
+		t.Fatalf("parsed %d hunks, want 2", len(parsed.Hunks))
+	}
+	if line := parsed.Hunks[1].Lines[3]; line.Kind != LineAdded || line.NewLineNo != 13 {
+		t.Errorf("added line = %+v, want new line 13", line)
+	}
+}
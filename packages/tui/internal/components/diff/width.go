@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	stylesi "github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// pinnedWidth is the width diffs render at regardless of the space available,
+// zero to fill it
+var pinnedWidth int
+
+// SetWidth pins diffs to the width in columns, centered in the space
+// available. Side-by-side diffs use the width for each side. Zero fills the
+// available width.
+func SetWidth(width int) {
+	pinnedWidth = max(width, 0)
+}
+
+// pinWidth narrows the options to the pinned width for a diff with the given
+// number of side-by-side panes, returning a function that centers the rendered
+// diff in the width originally available
+func pinWidth(opts []UnifiedOption, panes int) ([]UnifiedOption, func(string) string) {
+	noop := func(rendered string) string { return rendered }
+	if pinnedWidth == 0 {
+		return opts, noop
+	}
+	var config UnifiedConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	available := config.Width
+	width := pinnedWidth * panes
+	if available <= width {
+		return opts, noop
+	}
+
+	left := (available - width) / 2
+	style := stylesi.NewStyle().Background(theme.CurrentTheme().Background())
+	center := func(rendered string) string {
+		lines := strings.Split(rendered, "\n")
+		for i, line := range lines {
+			if line == "" {
+				continue
+			}
+			right := max(available-left-ansi.StringWidth(line), 0)
+			lines[i] = style.Render(strings.Repeat(" ", left)) + line + style.Render(strings.Repeat(" ", right))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return append(opts, WithWidth(width)), center
+}
@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPinnedWidth(t *testing.T) {
+	defer SetWidth(0)
+	diffText := strings.Join([]string{
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1 +1 @@",
+		"-one",
+		"+two",
+		"",
+	}, "\n")
+
+	tests := []struct {
+		pinned, available int
+		left              int
+	}{
+		{0, 100, 0},
+		{40, 100, 30},
+		{120, 100, 0},
+	}
+	for _, tt := range tests {
+		SetWidth(tt.pinned)
+		rendered, err := FormatUnifiedDiff("main.go", diffText, WithWidth(tt.available))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(ansi.Strip(rendered), "\n"), "\n") {
+			if width := ansi.StringWidth(line); width != tt.available {
+				t.Errorf("pinned %d: line %q is %d wide, want %d", tt.pinned, line, width, tt.available)
+			}
+			if indent := len(line) - len(strings.TrimLeft(line, " ")); indent < tt.left {
+				t.Errorf("pinned %d: line %q is indented %d, want at least %d", tt.pinned, line, indent, tt.left)
+			}
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package fileviewer
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 
+	"github.com/sst/opencode-sdk-go"
 	"github.com/sst/opencode/internal/app"
 	"github.com/sst/opencode/internal/commands"
 	"github.com/sst/opencode/internal/components/dialog"
@@ -22,20 +26,38 @@ type DiffStyle int
 const (
 	DiffStyleSplit DiffStyle = iota
 	DiffStyleUnified
+	DiffStyleInline
+	// DiffStyleGutter shows the current contents of the file, marking the
+	// changed lines in a gutter
+	DiffStyleGutter
 )
 
+// tab holds the state of a single open file so switching between files
+// preserves scroll position and diff mode
+type tab struct {
+	filename  string
+	content   string
+	isDiff    bool
+	diffStyle DiffStyle
+	yOffset   int
+	// layout records where hunks and changes start in the rendered diff
+	layout diff.Layout
+}
+
 type Model struct {
 	app           *app.App
 	width, height int
 	viewport      viewport.Model
-	filename      *string
-	content       *string
-	isDiff        *bool
+	tabs          []tab
+	active        int
 	diffStyle     DiffStyle
+	focused       bool
 }
 
 type fileRenderedMsg struct {
-	content string
+	filename string
+	content  string
+	layout   diff.Layout
 }
 
 func New(app *app.App) Model {
@@ -47,6 +69,10 @@ func New(app *app.App) Model {
 	}
 	if app.State.SplitDiff {
 		m.diffStyle = DiffStyleSplit
+	} else if app.State.InlineDiff {
+		m.diffStyle = DiffStyleInline
+	} else if app.State.GutterDiff {
+		m.diffStyle = DiffStyleGutter
 	}
 	return m
 }
@@ -60,9 +86,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case fileRenderedMsg:
+		// ignore renders for a tab that is no longer active
+		if !m.HasFile() || m.tabs[m.active].filename != msg.filename {
+			return m, nil
+		}
+		m.tabs[m.active].layout = msg.layout
 		m.viewport.SetContent(msg.content)
+		m.viewport.SetYOffset(m.tabs[m.active].yOffset)
 		return m, util.CmdHandler(app.FileRenderedMsg{
-			FilePath: *m.filename,
+			FilePath: msg.filename,
 		})
 	case dialog.ThemeSelectedMsg:
 		return m, m.render()
@@ -84,21 +116,52 @@ func (m Model) View() string {
 		return ""
 	}
 
-	header := *m.filename
+	t := theme.CurrentTheme()
+	activeColor := t.Text()
+	if m.focused {
+		activeColor = t.Primary()
+	}
+	active := styles.NewStyle().
+		Background(t.BackgroundElement()).
+		Foreground(activeColor).
+		Bold(true).
+		Render
+	inactive := styles.NewStyle().
+		Background(t.BackgroundElement()).
+		Foreground(t.TextMuted()).
+		Render
+	separator := inactive("  ")
+
+	names := []string{}
+	for i, tab := range m.tabs {
+		name := util.Relative(tab.filename)
+		if len(m.tabs) > 1 {
+			name = filepath.Base(tab.filename)
+		}
+		if i == m.active {
+			names = append(names, active(name))
+		} else {
+			names = append(names, inactive(name))
+		}
+	}
+
+	header := strings.Join(names, separator)
 	header = styles.NewStyle().
 		Padding(1, 2).
 		Width(m.width).
-		Background(theme.CurrentTheme().BackgroundElement()).
-		Foreground(theme.CurrentTheme().Text()).
+		Background(t.BackgroundElement()).
+		Foreground(t.Text()).
 		Render(header)
 
-	t := theme.CurrentTheme()
-
 	close := m.app.Key(commands.FileCloseCommand)
 	diffToggle := m.app.Key(commands.FileDiffToggleCommand)
-	if m.isDiff == nil || *m.isDiff == false {
+	if !m.tabs[m.active].isDiff {
 		diffToggle = ""
 	}
+	nextTab := m.app.Key(commands.FileNextCommand)
+	if len(m.tabs) < 2 {
+		nextTab = ""
+	}
 	layoutToggle := m.app.Key(commands.MessagesLayoutToggleCommand)
 
 	background := t.Background()
@@ -114,6 +177,9 @@ func (m Model) View() string {
 		layout.FlexItem{
 			View: close,
 		},
+		layout.FlexItem{
+			View: nextTab,
+		},
 		layout.FlexItem{
 			View: layoutToggle,
 		},
@@ -126,36 +192,61 @@ func (m Model) View() string {
 	return header + "\n" + m.viewport.View() + "\n" + footer
 }
 
+// Clear closes the active tab, activating the tab to its left
 func (m *Model) Clear() (Model, tea.Cmd) {
-	m.filename = nil
-	m.content = nil
-	m.isDiff = nil
+	if !m.HasFile() {
+		return *m, nil
+	}
+	m.tabs = append(m.tabs[:m.active], m.tabs[m.active+1:]...)
+	if m.active > 0 {
+		m.active--
+	}
 	return *m, m.render()
 }
 
 func (m *Model) ToggleDiff() (Model, tea.Cmd) {
-	switch m.diffStyle {
+	switch m.DiffStyle() {
 	case DiffStyleSplit:
+		m.diffStyle = DiffStyleInline
+	case DiffStyleInline:
 		m.diffStyle = DiffStyleUnified
+	case DiffStyleUnified:
+		m.diffStyle = DiffStyleGutter
 	default:
 		m.diffStyle = DiffStyleSplit
 	}
+	if m.HasFile() {
+		m.tabs[m.active].diffStyle = m.diffStyle
+		m.tabs[m.active].yOffset = m.viewport.YOffset
+	}
 	return *m, m.render()
 }
 
 func (m *Model) DiffStyle() DiffStyle {
+	if m.HasFile() {
+		return m.tabs[m.active].diffStyle
+	}
 	return m.diffStyle
 }
 
 func (m Model) HasFile() bool {
-	return m.filename != nil && m.content != nil
+	return len(m.tabs) > 0
 }
 
 func (m Model) Filename() string {
-	if m.filename == nil {
+	if !m.HasFile() {
 		return ""
 	}
-	return *m.filename
+	return m.tabs[m.active].filename
+}
+
+// Filenames returns the paths of all open tabs in order
+func (m Model) Filenames() []string {
+	filenames := make([]string, 0, len(m.tabs))
+	for _, tab := range m.tabs {
+		filenames = append(filenames, tab.filename)
+	}
+	return filenames
 }
 
 func (m *Model) SetSize(width, height int) (Model, tea.Cmd) {
@@ -169,37 +260,103 @@ func (m *Model) SetSize(width, height int) (Model, tea.Cmd) {
 	return *m, nil
 }
 
+// SetFile opens a file in a new tab, or refreshes and activates the tab
+// already showing it
 func (m *Model) SetFile(filename string, content string, isDiff bool) (Model, tea.Cmd) {
-	m.filename = &filename
-	m.content = &content
-	m.isDiff = &isDiff
+	if m.HasFile() {
+		m.tabs[m.active].yOffset = m.viewport.YOffset
+	}
+	for i, tab := range m.tabs {
+		if tab.filename == filename {
+			m.tabs[i].content = content
+			m.tabs[i].isDiff = isDiff
+			m.active = i
+			return *m, m.render()
+		}
+	}
+	m.tabs = append(m.tabs, tab{
+		filename:  filename,
+		content:   content,
+		isDiff:    isDiff,
+		diffStyle: m.diffStyle,
+	})
+	m.active = len(m.tabs) - 1
+	return *m, m.render()
+}
+
+// UpdateFile replaces the content of an open tab without activating it
+func (m Model) UpdateFile(filename string, content string, isDiff bool) Model {
+	for i, tab := range m.tabs {
+		if tab.filename == filename && i != m.active {
+			m.tabs[i].content = content
+			m.tabs[i].isDiff = isDiff
+		}
+	}
+	return m
+}
+
+// NextTab activates the tab to the right of the current one, wrapping around
+func (m *Model) NextTab() (Model, tea.Cmd) {
+	return m.switchTab(1)
+}
+
+// PreviousTab activates the tab to the left of the current one, wrapping around
+func (m *Model) PreviousTab() (Model, tea.Cmd) {
+	return m.switchTab(-1)
+}
+
+func (m *Model) switchTab(delta int) (Model, tea.Cmd) {
+	if len(m.tabs) < 2 {
+		return *m, nil
+	}
+	m.tabs[m.active].yOffset = m.viewport.YOffset
+	m.active = (m.active + delta + len(m.tabs)) % len(m.tabs)
 	return *m, m.render()
 }
 
 func (m *Model) render() tea.Cmd {
-	if m.filename == nil || m.content == nil {
+	if !m.HasFile() {
 		m.viewport.SetContent("")
 		return nil
 	}
 
+	tab := m.tabs[m.active]
+	width := m.width
 	return func() tea.Msg {
 		t := theme.CurrentTheme()
 		var rendered string
+		var diffLayout diff.Layout
 
-		if m.isDiff != nil && *m.isDiff {
+		if tab.isDiff {
 			diffResult := ""
 			var err error
-			if m.diffStyle == DiffStyleSplit {
+			if tab.diffStyle == DiffStyleSplit {
 				diffResult, err = diff.FormatDiff(
-					*m.filename,
-					*m.content,
-					diff.WithWidth(m.width),
+					tab.filename,
+					tab.content,
+					diff.WithWidth(width),
 				)
-			} else if m.diffStyle == DiffStyleUnified {
+				diffLayout, _ = diff.SideBySideLayout(tab.content)
+			} else if tab.diffStyle == DiffStyleUnified {
 				diffResult, err = diff.FormatUnifiedDiff(
-					*m.filename,
-					*m.content,
-					diff.WithWidth(m.width),
+					tab.filename,
+					tab.content,
+					diff.WithWidth(width),
+				)
+				diffLayout, _ = diff.UnifiedLayout(tab.content)
+			} else if tab.diffStyle == DiffStyleInline {
+				diffResult, err = diff.FormatInlineDiff(
+					tab.filename,
+					tab.content,
+					diff.WithWidth(width),
+				)
+				diffLayout, _ = diff.InlineLayout(tab.content)
+			} else if tab.diffStyle == DiffStyleGutter {
+				diffResult, diffLayout, err = diff.FormatGutterFile(
+					tab.filename,
+					tab.content,
+					changeGutter(m.app.Config.Tui),
+					diff.WithWidth(width),
 				)
 			}
 			if err != nil {
@@ -209,25 +366,74 @@ func (m *Model) render() tea.Cmd {
 			} else {
 				rendered = strings.TrimRight(diffResult, "\n")
 			}
+		} else if util.IsBinary(tab.content) {
+			rendered = renderBinary(tab.content, width)
 		} else {
 			rendered = util.RenderFile(
-				*m.filename,
-				*m.content,
-				m.width,
+				tab.filename,
+				tab.content,
+				width,
 			)
 		}
 
 		rendered = styles.NewStyle().
-			Width(m.width).
+			Width(width).
 			Background(t.BackgroundPanel()).
 			Render(rendered)
 
 		return fileRenderedMsg{
-			content: rendered,
+			filename: tab.filename,
+			content:  rendered,
+			layout:   diffLayout,
 		}
 	}
 }
 
+// changeGutter reports whether changed lines are marked when showing a
+// modified file's contents, defaulting to true
+func changeGutter(cfg opencode.ConfigTui) bool {
+	return cfg.JSON.ChangeGutter.IsNull() || cfg.ChangeGutter
+}
+
+// binaryPreviewSize is how many leading bytes of a binary file are shown as hex
+const binaryPreviewSize = 512
+
+// renderBinary shows a summary and hex preview in place of binary content,
+// which would garble the terminal if rendered as text
+func renderBinary(content string, width int) string {
+	t := theme.CurrentTheme()
+	summary := fmt.Sprintf(
+		"Binary file · %s · %s",
+		formatSize(len(content)),
+		http.DetectContentType([]byte(content)),
+	)
+	preview := content
+	if len(preview) > binaryPreviewSize {
+		preview = preview[:binaryPreviewSize]
+	}
+	dump := strings.TrimRight(hex.Dump([]byte(preview)), "\n")
+	if len(content) > binaryPreviewSize {
+		dump += "\n..."
+	}
+	return styles.NewStyle().
+		Width(width).
+		Padding(1, 2).
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundPanel()).
+		Render(summary + "\n\n" + dump)
+}
+
+func formatSize(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
 func (m *Model) ScrollTo(line int) {
 	m.viewport.SetYOffset(line)
 }
@@ -240,6 +446,83 @@ func (m *Model) ScrollToTop() {
 	m.viewport.GotoTop()
 }
 
+// SetFocused marks whether the file viewer has keyboard focus, which
+// highlights the active tab
+func (m *Model) SetFocused(focused bool) {
+	m.focused = focused
+}
+
+func (m *Model) ScrollUp(lines int) {
+	m.viewport.LineUp(lines)
+}
+
+func (m *Model) ScrollDown(lines int) {
+	m.viewport.LineDown(lines)
+}
+
+// NextChange centers the next block of changes below the middle of the
+// viewport, doing nothing when there are no more changes
+func (m *Model) NextChange() (Model, tea.Cmd) {
+	if !m.HasFile() {
+		return *m, nil
+	}
+	center := m.viewport.YOffset + m.viewport.Height()/2
+	for _, row := range m.tabs[m.active].layout.Changes {
+		if row > center {
+			m.centerOn(row)
+			break
+		}
+	}
+	return *m, nil
+}
+
+// PreviousChange centers the previous block of changes above the middle of
+// the viewport, doing nothing when there are no earlier changes
+func (m *Model) PreviousChange() (Model, tea.Cmd) {
+	if !m.HasFile() {
+		return *m, nil
+	}
+	center := m.viewport.YOffset + m.viewport.Height()/2
+	changes := m.tabs[m.active].layout.Changes
+	for i := len(changes) - 1; i >= 0; i-- {
+		if changes[i] < center {
+			m.centerOn(changes[i])
+			break
+		}
+	}
+	return *m, nil
+}
+
+// CurrentHunk returns the patch of the hunk in the middle of the viewport as a
+// standalone unified diff, reporting false when no diff is shown
+func (m Model) CurrentHunk() (string, bool) {
+	if !m.HasFile() || !m.tabs[m.active].isDiff {
+		return "", false
+	}
+	tab := m.tabs[m.active]
+	index := tab.layout.HunkAt(m.viewport.YOffset + m.viewport.Height()/2)
+	patch, err := diff.HunkPatch(tab.content, index)
+	if err != nil {
+		return "", false
+	}
+	return patch, true
+}
+
+// CurrentLine returns the line number, in the new version of the file, of
+// the diff row in the middle of the viewport, reporting false when no diff is
+// shown
+func (m Model) CurrentLine() (int, bool) {
+	if !m.HasFile() || !m.tabs[m.active].isDiff {
+		return 0, false
+	}
+	line := m.tabs[m.active].layout.LineAt(m.viewport.YOffset + m.viewport.Height()/2)
+	return line, line > 0
+}
+
+func (m *Model) centerOn(row int) {
+	m.viewport.SetYOffset(max(0, row-m.viewport.Height()/2))
+}
+
 func (m *Model) PageUp() (Model, tea.Cmd) {
 	m.viewport.ViewUp()
 	return *m, nil
@@ -0,0 +1,108 @@
+package inspector
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	opencode "github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// maxEvents is how many events the inspector keeps
+const maxEvents = 200
+
+// EventMsg reports an event received from the server to the inspector
+type EventMsg struct {
+	Time    time.Time
+	Type    string
+	Summary string
+}
+
+// NewEventMsg summarizes an event as its type and compact properties
+func NewEventMsg(event opencode.EventListResponse) EventMsg {
+	return EventMsg{
+		Time:    time.Now(),
+		Type:    string(event.Type),
+		Summary: strings.Join(strings.Fields(event.JSON.Properties.Raw()), " "),
+	}
+}
+
+// Model is a developer overlay listing incoming server events as they arrive
+type Model struct {
+	events  []EventMsg
+	visible bool
+}
+
+func New() *Model {
+	return &Model{}
+}
+
+// Record adds an event, dropping the oldest beyond maxEvents
+func (m *Model) Record(event EventMsg) {
+	m.events = append(m.events, event)
+	if len(m.events) > maxEvents {
+		m.events = m.events[len(m.events)-maxEvents:]
+	}
+}
+
+func (m *Model) Toggle() {
+	m.visible = !m.visible
+}
+
+func (m *Model) Visible() bool {
+	return m.visible
+}
+
+// RenderOverlay draws the latest events over the bottom right of the
+// background, newest last
+func (m *Model) RenderOverlay(background string) string {
+	if !m.visible {
+		return background
+	}
+
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+	accent := styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundPanel())
+
+	bgWidth := lipgloss.Width(background)
+	bgHeight := lipgloss.Height(background)
+	width := min(bgWidth-6, 100)
+	height := max(bgHeight/2-2, 1)
+	if width < 20 {
+		return background
+	}
+
+	events := m.events[max(len(m.events)-height, 0):]
+	lines := make([]string, 0, height)
+	if len(events) == 0 {
+		lines = append(lines, muted.Render("Waiting for events..."))
+	}
+	for _, event := range events {
+		line := muted.Render(event.Time.Format("15:04:05.000")+" ") +
+			accent.Render(event.Type) +
+			base.Render(" "+event.Summary)
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+	}
+
+	content := base.
+		Width(width).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+	title := accent.Bold(true).Render(fmt.Sprintf("Events (%d)", len(m.events)))
+	view := base.Width(width).Render(title) + "\n" + content
+
+	return layout.PlaceOverlay(
+		max(bgWidth-lipgloss.Width(view)-4, 0),
+		max(bgHeight-lipgloss.Height(view)-3, 0),
+		view,
+		background,
+		layout.WithOverlayBorder(),
+		layout.WithOverlayBorderColor(t.Primary()),
+	)
+}
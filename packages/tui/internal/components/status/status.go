@@ -4,11 +4,17 @@ import (
 	"os"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode-sdk-go"
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/commands"
+	"github.com/sst/opencode/internal/components/dialog"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
 )
 
 type StatusComponent interface {
@@ -17,9 +23,13 @@ type StatusComponent interface {
 }
 
 type statusComponent struct {
-	app   *app.App
-	width int
-	cwd   string
+	app     *app.App
+	width   int
+	cwd     string
+	spinner spinner.Model
+	// ticking is set while a spinner tick is scheduled, so the animation only
+	// runs while the session is busy
+	ticking bool
 }
 
 func (m statusComponent) Init() tea.Cmd {
@@ -31,10 +41,55 @@ func (m statusComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		return m, nil
+	case spinner.TickMsg:
+		if msg.ID != m.spinner.ID() {
+			return m, nil
+		}
+		if !m.app.IsBusy() {
+			m.ticking = false
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case dialog.ThemeSelectedMsg:
+		m.spinner.Style = spinnerStyle()
+	}
+	if !m.ticking && m.animated() && m.app.IsBusy() {
+		m.ticking = true
+		return m, m.spinner.Tick
 	}
 	return m, nil
 }
 
+// animated reports whether the busy indicator is drawn with the spinner
+func (m statusComponent) animated() bool {
+	return m.app.Config.Tui.BusyIndicator != opencode.ConfigTuiBusyIndicatorText && !m.app.Config.Tui.ReducedMotion
+}
+
+func spinnerStyle() lipgloss.Style {
+	t := theme.CurrentTheme()
+	return styles.NewStyle().
+		Foreground(t.Primary()).
+		Background(t.BackgroundPanel()).
+		Lipgloss()
+}
+
+// busy renders the activity indicator shown while the session is working,
+// using static text when animation is disabled in config or motion is reduced
+func (m statusComponent) busy() string {
+	if !m.app.IsBusy() {
+		return ""
+	}
+	t := theme.CurrentTheme()
+	style := styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundPanel())
+	label := style.Render("working...")
+	if m.animated() {
+		label = m.spinner.View() + style.Render(" working")
+	}
+	return style.Padding(0, 1).Render(label)
+}
+
 func (m statusComponent) logo() string {
 	t := theme.CurrentTheme()
 	base := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
@@ -53,9 +108,23 @@ func (m statusComponent) logo() string {
 		Render(open + code + version)
 }
 
+// versionBanner warns that the server runs a different version, unless the
+// warning is configured as a toast or ignored
+func (m statusComponent) versionBanner() string {
+	if !m.app.VersionMismatch() || m.app.Config.Tui.VersionMismatch == opencode.ConfigTuiVersionMismatchToast {
+		return ""
+	}
+	t := theme.CurrentTheme()
+	return styles.NewStyle().
+		Foreground(t.BackgroundPanel()).
+		Background(t.Warning()).
+		Padding(0, 1).
+		Render("server " + m.app.Info.Version + ", restart recommended")
+}
+
 func (m statusComponent) View() string {
 	t := theme.CurrentTheme()
-	logo := m.logo()
+	logo := m.logo() + m.versionBanner()
 
 	cwd := styles.NewStyle().
 		Foreground(t.TextMuted()).
@@ -118,22 +187,80 @@ func (m statusComponent) View() string {
 	// 	Render(key+" ") +
 	// 	mode
 
+	busy := m.busy()
+
 	space := max(
 		0,
-		m.width-lipgloss.Width(logo)-lipgloss.Width(cwd),
+		m.width-lipgloss.Width(logo)-lipgloss.Width(cwd)-lipgloss.Width(busy),
 	)
 	spacer := styles.NewStyle().Background(t.BackgroundPanel()).Width(space).Render("")
 
 	// status := logo + cwd + spacer + mode
-	status := logo + cwd + spacer
+	status := logo + cwd + spacer + busy
 
 	blank := styles.NewStyle().Background(t.Background()).Width(m.width).Render("")
-	return blank + "\n" + status
+	if m.app.State.HideHints {
+		return blank + "\n" + status
+	}
+	return blank + "\n" + status + "\n" + m.hints()
+}
+
+// hint is a key and what it does, shown in the hints row
+type hint struct {
+	key         string
+	description string
+}
+
+// hints renders the row of common keybinds below the status bar, taken from
+// the active keymap so rebound or unbound commands are reflected
+func (m statusComponent) hints() string {
+	t := theme.CurrentTheme()
+	keyStyle := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Bold(true)
+	mutedStyle := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background())
+
+	items := []hint{}
+	if key, ok := m.app.KeyText(commands.AppExitCommand); ok {
+		items = append(items, hint{key, "quit"})
+	}
+	items = append(items, hint{"/", "commands"}, hint{"@", "files"})
+	for _, item := range []struct {
+		name        commands.CommandName
+		description string
+	}{
+		{commands.AppHelpCommand, "help"},
+		{commands.SessionNewCommand, "new session"},
+		{commands.ModelListCommand, "models"},
+	} {
+		if key, ok := m.app.KeyText(item.name); ok {
+			items = append(items, hint{key, item.description})
+		}
+	}
+	if key, ok := m.app.KeyText(commands.HintsToggleCommand); ok {
+		items = append(items, hint{key, "hide hints"})
+	} else {
+		items = append(items, hint{"/hints", "hide"})
+	}
+
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, keyStyle.Render(item.key)+mutedStyle.Render(" "+item.description))
+	}
+	row := strings.Join(parts, mutedStyle.Render(" · "))
+	row = ansi.Truncate(row, max(0, m.width-2), "…")
+	return styles.NewStyle().
+		Background(t.Background()).
+		Padding(0, 1).
+		Width(m.width).
+		Render(row)
 }
 
 func NewStatusCmp(app *app.App) StatusComponent {
 	statusComponent := &statusComponent{
 		app: app,
+		spinner: spinner.New(
+			spinner.WithSpinner(util.Spinner(string(app.Config.Tui.Spinner), spinner.MiniDot)),
+			spinner.WithStyle(spinnerStyle()),
+		),
 	}
 
 	homePath, err := os.UserHomeDir()
@@ -19,6 +19,7 @@ import (
 	rw "github.com/mattn/go-runewidth"
 	"github.com/rivo/uniseg"
 	"github.com/sst/opencode/internal/attachment"
+	"github.com/sst/opencode/internal/clipboard"
 )
 
 const (
@@ -28,9 +29,7 @@ const (
 	defaultCharLimit = 0 // no limit
 	defaultMaxHeight = 99
 	defaultMaxWidth  = 500
-
-	// XXX: in v2, make max lines dynamic and default max lines configurable.
-	maxLines = 10000
+	defaultMaxLines  = 10000
 )
 
 // Helper functions for converting between runes and any slices
@@ -112,21 +111,33 @@ func (m Model) isAttachmentAtCursor() (*attachment.Attachment, int, int) {
 	return nil, -1, -1
 }
 
-// renderLineWithAttachments renders a line with proper attachment highlighting
+// renderLineWithAttachments renders a line with proper attachment and
+// selection highlighting. The items start at col of row in the value.
 func (m Model) renderLineWithAttachments(
 	items []any,
 	style lipgloss.Style,
+	row, col int,
 ) string {
 	var s strings.Builder
 	currentAttachment, _, _ := m.isAttachmentAtCursor()
+	selStart, selEnd, selecting := m.selectionRange()
+	selectionStyle := m.Styles.Selection.Inherit(style)
 
-	for _, item := range items {
+	for i, item := range items {
+		pos := Position{Row: row, Col: col + i}
+		selected := selecting && !pos.before(selStart) && pos.before(selEnd)
 		switch val := item.(type) {
 		case rune:
-			s.WriteString(style.Render(string(val)))
+			if selected {
+				s.WriteString(selectionStyle.Render(string(val)))
+			} else {
+				s.WriteString(style.Render(string(val)))
+			}
 		case *attachment.Attachment:
 			// Check if this is the attachment the cursor is currently on
-			if currentAttachment != nil && currentAttachment.ID == val.ID {
+			if selected {
+				s.WriteString(m.Styles.Selection.Render(val.Display))
+			} else if currentAttachment != nil && currentAttachment.ID == val.ID {
 				// Cursor is on this attachment, highlight it
 				s.WriteString(m.Styles.SelectedAttachment.Render(val.Display))
 			} else {
@@ -169,6 +180,12 @@ type (
 	pasteErrMsg struct{ error }
 )
 
+// LineLimitReachedMsg reports that pasted text had more lines than MaxLines
+// allows, and how many of them were dropped.
+type LineLimitReachedMsg struct {
+	Dropped int
+}
+
 // KeyMap is the key bindings for different actions within the textarea.
 type KeyMap struct {
 	CharacterBackward       key.Binding
@@ -195,6 +212,14 @@ type KeyMap struct {
 	CapitalizeWordForward key.Binding
 
 	TransposeCharacterBackward key.Binding
+
+	SelectCharacterBackward key.Binding
+	SelectCharacterForward  key.Binding
+	SelectWordBackward      key.Binding
+	SelectWordForward       key.Binding
+	SelectLineNext          key.Binding
+	SelectLinePrevious      key.Binding
+	Copy                    key.Binding
 }
 
 // DefaultKeyMap returns the default set of key bindings for navigating and acting
@@ -291,6 +316,35 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+t"),
 			key.WithHelp("ctrl+t", "transpose character backward"),
 		),
+
+		SelectCharacterForward: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+right", "select character forward"),
+		),
+		SelectCharacterBackward: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+left", "select character backward"),
+		),
+		SelectWordForward: key.NewBinding(
+			key.WithKeys("ctrl+shift+right", "alt+shift+right"),
+			key.WithHelp("ctrl+shift+right", "select word forward"),
+		),
+		SelectWordBackward: key.NewBinding(
+			key.WithKeys("ctrl+shift+left", "alt+shift+left"),
+			key.WithHelp("ctrl+shift+left", "select word backward"),
+		),
+		SelectLineNext: key.NewBinding(
+			key.WithKeys("shift+down"),
+			key.WithHelp("shift+down", "select next line"),
+		),
+		SelectLinePrevious: key.NewBinding(
+			key.WithKeys("shift+up"),
+			key.WithHelp("shift+up", "select previous line"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "copy selection"),
+		),
 	}
 }
 
@@ -339,7 +393,8 @@ type CursorStyle struct {
 	// - tea.CursorUnderline
 	// - tea.CursorBar
 	//
-	// This is only used for real cursors.
+	// The virtual cursor cannot draw a bar, so it underlines the character for
+	// both tea.CursorUnderline and tea.CursorBar.
 	Shape tea.CursorShape
 
 	// CursorBlink determines whether or not the cursor should blink.
@@ -362,6 +417,7 @@ type Styles struct {
 	Cursor             CursorStyle
 	Attachment         lipgloss.Style
 	SelectedAttachment lipgloss.Style
+	Selection          lipgloss.Style
 }
 
 // StyleState that will be applied to the text area.
@@ -435,6 +491,18 @@ func (w line) Hash() string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(v)))
 }
 
+// Position is a location in the value of the textarea, as a row and an
+// index into that row.
+type Position struct {
+	Row int
+	Col int
+}
+
+// before reports whether p comes before other.
+func (p Position) before(other Position) bool {
+	return p.Row < other.Row || (p.Row == other.Row && p.Col < other.Col)
+}
+
 // Model is the Bubble Tea model for this text area element.
 type Model struct {
 	Err error
@@ -458,6 +526,11 @@ type Model struct {
 	// after the prompt.
 	ShowLineNumbers bool
 
+	// RelativeLineNumbers, if enabled along with ShowLineNumbers, numbers
+	// lines by their distance from the cursor line, which keeps its own
+	// number.
+	RelativeLineNumbers bool
+
 	// EndOfBufferCharacter is displayed at the end of the input.
 	EndOfBufferCharacter rune
 
@@ -487,6 +560,15 @@ type Model struct {
 	// there's no limit.
 	MaxWidth int
 
+	// MaxLines is the maximum number of lines the value can have. If 0 or
+	// less, there's no limit.
+	MaxLines int
+
+	// AutoPair, if enabled, inserts the closing bracket or quote along with a
+	// typed opener, types over a closer that is already next, and deletes both
+	// when the opener of an empty pair is deleted.
+	AutoPair bool
+
 	// If promptFunc is set, it replaces Prompt as a generator for
 	// prompt strings at the beginning of each line.
 	promptFunc func(line int) string
@@ -516,6 +598,10 @@ type Model struct {
 	// Cursor row.
 	row int
 
+	// SelectionStart is the anchor of the selection, which spans from it to
+	// the cursor. It is nil when nothing is selected.
+	SelectionStart *Position
+
 	// Last character offset, used to maintain state when the cursor is moved
 	// vertically such that we can maintain the same navigating position.
 	lastCharOffset int
@@ -536,14 +622,15 @@ func New() Model {
 		MaxWidth:             defaultMaxWidth,
 		Prompt:               lipgloss.ThickBorder().Left + " ",
 		Styles:               styles,
-		cache:                NewMemoCache[line, [][]any](maxLines),
+		MaxLines:             defaultMaxLines,
+		cache:                NewMemoCache[line, [][]any](defaultMaxLines),
 		EndOfBufferCharacter: ' ',
 		ShowLineNumbers:      true,
 		VirtualCursor:        true,
 		virtualCursor:        cur,
 		KeyMap:               DefaultKeyMap(),
 
-		value: make([][]any, minHeight, maxLines),
+		value: make([][]any, minHeight, defaultMaxLines),
 		focus: false,
 		col:   0,
 		row:   0,
@@ -596,6 +683,7 @@ func DefaultStyles(isDark bool) Styles {
 	s.SelectedAttachment = lipgloss.NewStyle().
 		Background(lipgloss.Color("11")).
 		Foreground(lipgloss.Color("0"))
+	s.Selection = lipgloss.NewStyle().Reverse(true)
 	s.Cursor = CursorStyle{
 		Color: lipgloss.Color("7"),
 		Shape: tea.CursorBlock,
@@ -636,6 +724,20 @@ func (m *Model) updateVirtualCursorStyle() {
 	m.virtualCursor.SetMode(cursor.CursorStatic)
 }
 
+// virtualCursorView renders the virtual cursor over char, reversing it for the
+// block shape and underlining it otherwise.
+func (m Model) virtualCursorView(char string) string {
+	m.virtualCursor.SetChar(char)
+	if m.Styles.Cursor.Shape == tea.CursorBlock || m.virtualCursor.Blink {
+		return m.virtualCursor.View()
+	}
+	return m.virtualCursor.TextStyle.
+		Inline(true).
+		Foreground(m.Styles.Cursor.Color).
+		Underline(true).
+		Render(char)
+}
+
 // SetValue sets the value of the text input.
 func (m *Model) SetValue(s string) {
 	m.Reset()
@@ -741,8 +843,108 @@ func (m Model) GetAttachments() []*attachment.Attachment {
 	return attachments
 }
 
+// RemoveAttachment removes the attachment with the given ID, keeping the
+// cursor and the selection anchor on the same items. It reports whether the
+// attachment was found.
+func (m *Model) RemoveAttachment(id string) bool {
+	for rowIdx, row := range m.value {
+		for colIdx, item := range row {
+			att, ok := item.(*attachment.Attachment)
+			if !ok || att.ID != id {
+				continue
+			}
+			m.value[rowIdx] = append(row[:colIdx], row[colIdx+1:]...)
+			if rowIdx == m.row && m.col > colIdx {
+				m.SetCursorColumn(m.col - 1)
+			}
+			if start := m.SelectionStart; start != nil && start.Row == rowIdx && start.Col > colIdx {
+				start.Col--
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // InsertRunesFromUserInput inserts runes at the current cursor position.
 func (m *Model) InsertRunesFromUserInput(runes []rune) {
+	m.insertRunes(runes)
+}
+
+// autoPairs maps the openers completed by AutoPair to their closers.
+var autoPairs = map[rune]rune{
+	'(': ')',
+	'[': ']',
+	'{': '}',
+	'"': '"',
+	'`': '`',
+}
+
+// isAutoPairCloser reports whether r closes one of the autoPairs.
+func isAutoPairCloser(r rune) bool {
+	for _, closer := range autoPairs {
+		if closer == r {
+			return true
+		}
+	}
+	return false
+}
+
+// insertPair types over r when it closes a pair and is already next, or
+// inserts it along with its closer, leaving the cursor between them. It
+// reports false when r should be inserted as usual. Only typed keys are
+// paired, text inserted by the program goes in as it is.
+func (m *Model) insertPair(r rune) bool {
+	if getRuneAt(m.value[m.row], m.col) == r && isAutoPairCloser(r) {
+		m.SetCursorColumn(m.col + 1)
+		return true
+	}
+	closer, ok := autoPairs[r]
+	if !ok {
+		return false
+	}
+	// a backtick after another one is typing a code fence
+	if r == '`' && getRuneAt(m.value[m.row], m.col-1) == '`' {
+		return false
+	}
+	// Without room for both, the opener is inserted alone
+	if m.CharLimit > 0 && m.CharLimit-m.Length() < 2 {
+		return false
+	}
+	m.insertRunes([]rune{r, closer})
+	m.SetCursorColumn(m.col - 1)
+	return true
+}
+
+// deletePair deletes an opener before the cursor together with its closer
+// right after it, reporting whether there was such a pair.
+func (m *Model) deletePair() bool {
+	row := m.value[m.row]
+	closer, ok := autoPairs[getRuneAt(row, m.col-1)]
+	if !ok || getRuneAt(row, m.col) != closer {
+		return false
+	}
+	m.value[m.row] = slices.Delete(row, m.col-1, m.col+1)
+	m.SetCursorColumn(m.col - 1)
+	return true
+}
+
+// PasteRunes inserts pasted runes at the current cursor position, returning
+// a command that reports a LineLimitReachedMsg when lines beyond MaxLines had
+// to be dropped.
+func (m *Model) PasteRunes(runes []rune) tea.Cmd {
+	dropped := m.insertRunes(runes)
+	if dropped == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		return LineLimitReachedMsg{Dropped: dropped}
+	}
+}
+
+// insertRunes inserts runes at the current cursor position, returning how
+// many lines were dropped to stay within MaxLines.
+func (m *Model) insertRunes(runes []rune) int {
 	// Clean up any special characters in the input provided by the
 	// clipboard. This avoids bugs due to e.g. tab characters and
 	// whatnot.
@@ -752,7 +954,7 @@ func (m *Model) InsertRunesFromUserInput(runes []rune) {
 		availSpace := m.CharLimit - m.Length()
 		// If the char limit's been reached, cancel.
 		if availSpace <= 0 {
-			return
+			return 0
 		}
 		// If there's not enough space to paste the whole thing cut the pasted
 		// runes down so they'll fit.
@@ -781,14 +983,16 @@ func (m *Model) InsertRunesFromUserInput(runes []rune) {
 	}
 
 	// Obey the maximum line limit.
-	if maxLines > 0 && len(m.value)+len(lines)-1 > maxLines {
-		allowedHeight := max(0, maxLines-len(m.value)+1)
+	dropped := 0
+	if m.MaxLines > 0 && len(m.value)+len(lines)-1 > m.MaxLines {
+		allowedHeight := max(0, m.MaxLines-len(m.value)+1)
+		dropped = len(lines) - allowedHeight
 		lines = lines[:allowedHeight]
 	}
 
 	if len(lines) == 0 {
 		// Nothing left to insert.
-		return
+		return dropped
 	}
 
 	// Save the remainder of the original line at the current
@@ -827,6 +1031,7 @@ func (m *Model) InsertRunesFromUserInput(runes []rune) {
 	m.value[m.row] = append(m.value[m.row], tail...)
 
 	m.SetCursorColumn(m.col)
+	return dropped
 }
 
 // Value returns the value of the text input.
@@ -873,6 +1078,33 @@ func (m *Model) LineCount() int {
 	return m.ContentHeight()
 }
 
+// SetCursorPosition moves the cursor to the given row and column, clamped to
+// the content.
+func (m *Model) SetCursorPosition(row, col int) {
+	m.row = clamp(row, 0, len(m.value)-1)
+	m.SetCursorColumn(col)
+}
+
+// RowCount returns the number of rows, not counting soft wraps.
+func (m Model) RowCount() int {
+	return len(m.value)
+}
+
+// RowLength returns the number of items in a row, counting each attachment
+// as one.
+func (m Model) RowLength(row int) int {
+	if row < 0 || row >= len(m.value) {
+		return 0
+	}
+	return len(m.value[row])
+}
+
+// CursorDisplayLine returns the line the cursor is on counting soft wrapped
+// lines, which is its row in the output of View.
+func (m Model) CursorDisplayLine() int {
+	return m.cursorLineNumber()
+}
+
 // Line returns the line position.
 func (m Model) Line() int {
 	return m.row
@@ -902,7 +1134,7 @@ func (m Model) LastRuneIndex(r rune) int {
 }
 
 func (m *Model) Newline() {
-	if m.MaxHeight > 0 && len(m.value) >= m.MaxHeight {
+	if m.MaxLines > 0 && len(m.value) >= m.MaxLines {
 		return
 	}
 	m.col = clamp(m.col, 0, len(m.value[m.row]))
@@ -1157,9 +1389,10 @@ func (m *Model) Blur() {
 
 // Reset sets the input to its default state with no input.
 func (m *Model) Reset() {
-	m.value = make([][]any, minHeight, maxLines)
+	m.value = make([][]any, minHeight, defaultMaxLines)
 	m.col = 0
 	m.row = 0
+	m.SelectionStart = nil
 	m.SetCursorColumn(0)
 }
 
@@ -1188,6 +1421,127 @@ func (m *Model) deleteAfterCursor() {
 	m.SetCursorColumn(len(m.value[m.row]))
 }
 
+// selectionRange returns the bounds of the selection in order, with the end
+// exclusive. It reports false when nothing is selected.
+func (m Model) selectionRange() (Position, Position, bool) {
+	if m.SelectionStart == nil || len(m.value) == 0 {
+		return Position{}, Position{}, false
+	}
+	anchor := *m.SelectionStart
+	anchor.Row = clamp(anchor.Row, 0, len(m.value)-1)
+	anchor.Col = clamp(anchor.Col, 0, len(m.value[anchor.Row]))
+	cursor := Position{Row: m.row, Col: m.col}
+	switch {
+	case anchor == cursor:
+		return Position{}, Position{}, false
+	case cursor.before(anchor):
+		return cursor, anchor, true
+	default:
+		return anchor, cursor, true
+	}
+}
+
+// HasSelection reports whether any text is selected.
+func (m Model) HasSelection() bool {
+	_, _, ok := m.selectionRange()
+	return ok
+}
+
+// SelectedText returns the selected text, with attachments replaced by their
+// display text.
+func (m Model) SelectedText() string {
+	start, end, ok := m.selectionRange()
+	if !ok {
+		return ""
+	}
+	lines := make([]string, 0, end.Row-start.Row+1)
+	for row := start.Row; row <= end.Row; row++ {
+		from, to := 0, len(m.value[row])
+		if row == start.Row {
+			from = start.Col
+		}
+		if row == end.Row {
+			to = end.Col
+		}
+		lines = append(lines, interfacesToString(m.value[row][from:to]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DeleteSelection removes the selected text, leaving the cursor where the
+// selection began. It returns whether anything was removed.
+func (m *Model) DeleteSelection() bool {
+	start, end, ok := m.selectionRange()
+	m.SelectionStart = nil
+	if !ok {
+		return false
+	}
+	joined := copyInterfaceSlice(m.value[start.Row][:start.Col])
+	m.value[start.Row] = append(joined, m.value[end.Row][end.Col:]...)
+	m.value = slices.Delete(m.value, start.Row+1, end.Row+1)
+	m.row = start.Row
+	m.SetCursorColumn(start.Col)
+	return true
+}
+
+// ClearCache drops the memoized line wrapping, so lines are wrapped again on
+// the next render.
+func (m *Model) ClearCache() {
+	m.cache = NewMemoCache[line, [][]any](m.cache.Capacity())
+}
+
+// WrapSelection puts open and close on lines of their own around the
+// selection, keeping the selected text and any attachments in place. It
+// returns whether anything was selected.
+func (m *Model) WrapSelection(open, close string) bool {
+	start, end, ok := m.selectionRange()
+	m.SelectionStart = nil
+	if !ok {
+		return false
+	}
+
+	// close goes in first so the start of the selection doesn't move
+	after := "\n" + close
+	if end.Col == 0 {
+		after = close + "\n"
+	} else if end.Col < len(m.value[end.Row]) {
+		after += "\n"
+	}
+	m.row = end.Row
+	m.SetCursorColumn(end.Col)
+	m.insertRunes([]rune(after))
+
+	before := open + "\n"
+	if start.Col > 0 {
+		before = "\n" + before
+	}
+	m.row = start.Row
+	m.SetCursorColumn(start.Col)
+	m.insertRunes([]rune(before))
+	return true
+}
+
+// extendSelection anchors a selection at the cursor unless one is already
+// active, so that moving the cursor afterwards extends it.
+func (m *Model) extendSelection() {
+	if m.SelectionStart == nil {
+		m.SelectionStart = &Position{Row: m.row, Col: m.col}
+	}
+}
+
+// copySelection writes the selected text to the clipboard, also trying OSC52
+// for terminals that support it.
+func (m Model) copySelection() tea.Cmd {
+	text := m.SelectedText()
+	return tea.Sequence(
+		func() tea.Msg {
+			clipboard.Write(clipboard.FmtText, []byte(text))
+			return nil
+		},
+		tea.SetClipboard(text),
+	)
+}
+
 // transposeLeft exchanges the runes at the cursor and immediately
 // before. No-op if the cursor is at the beginning of the line.  If
 // the cursor is not at the end of the line yet, moves the cursor to
@@ -1555,13 +1909,49 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.value[m.row] = make([]any, 0)
 	}
 
-	if m.MaxHeight > 0 && m.MaxHeight != m.cache.Capacity() {
-		m.cache = NewMemoCache[line, [][]any](m.MaxHeight)
+	if m.MaxLines > 0 && m.MaxLines != m.cache.Capacity() {
+		m.cache = NewMemoCache[line, [][]any](m.MaxLines)
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		// Any key other than a selection key drops the selection
+		keepSelection := false
 		switch {
+		case key.Matches(msg, m.KeyMap.Copy) && m.HasSelection():
+			keepSelection = true
+			cmds = append(cmds, m.copySelection())
+		case key.Matches(msg, m.KeyMap.SelectCharacterForward):
+			keepSelection = true
+			m.extendSelection()
+			m.characterRight()
+		case key.Matches(msg, m.KeyMap.SelectCharacterBackward):
+			keepSelection = true
+			m.extendSelection()
+			m.characterLeft(false /* insideLine */)
+		case key.Matches(msg, m.KeyMap.SelectWordForward):
+			keepSelection = true
+			m.extendSelection()
+			m.wordRight()
+		case key.Matches(msg, m.KeyMap.SelectWordBackward):
+			keepSelection = true
+			m.extendSelection()
+			m.wordLeft()
+		case key.Matches(msg, m.KeyMap.SelectLineNext):
+			keepSelection = true
+			m.extendSelection()
+			m.CursorDown()
+		case key.Matches(msg, m.KeyMap.SelectLinePrevious):
+			keepSelection = true
+			m.extendSelection()
+			m.CursorUp()
+		case m.HasSelection() && key.Matches(msg,
+			m.KeyMap.DeleteCharacterBackward,
+			m.KeyMap.DeleteCharacterForward,
+			m.KeyMap.DeleteWordBackward,
+			m.KeyMap.DeleteWordForward,
+		):
+			m.DeleteSelection()
 		case key.Matches(msg, m.KeyMap.DeleteAfterCursor):
 			m.col = clamp(m.col, 0, len(m.value[m.row]))
 			if m.col >= len(m.value[m.row]) {
@@ -1582,6 +1972,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.mergeLineAbove(m.row)
 				break
 			}
+			if m.AutoPair && m.deletePair() {
+				break
+			}
 			if len(m.value[m.row]) > 0 && m.col > 0 {
 				m.value[m.row] = slices.Delete(m.value[m.row], m.col-1, m.col)
 				m.SetCursorColumn(m.col - 1)
@@ -1608,6 +2001,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			m.deleteWordRight()
 		case key.Matches(msg, m.KeyMap.InsertNewline):
+			m.DeleteSelection()
 			m.Newline()
 		case key.Matches(msg, m.KeyMap.LineEnd):
 			m.CursorEnd()
@@ -1639,11 +2033,22 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.transposeLeft()
 
 		default:
-			m.InsertRunesFromUserInput([]rune(msg.Text))
+			// Typing replaces the selection
+			if msg.Text != "" {
+				m.DeleteSelection()
+			}
+			runes := []rune(msg.Text)
+			if !m.AutoPair || len(runes) != 1 || !m.insertPair(runes[0]) {
+				m.InsertRunesFromUserInput(runes)
+			}
+		}
+		if !keepSelection {
+			m.SelectionStart = nil
 		}
 
 	case pasteMsg:
-		m.InsertRunesFromUserInput([]rune(msg))
+		m.DeleteSelection()
+		cmds = append(cmds, m.PasteRunes([]rune(msg)))
 
 	case pasteErrMsg:
 		m.Err = msg
@@ -1688,6 +2093,7 @@ func (m Model) View() string {
 			style = styles.computedText()
 		}
 
+		offset := 0
 		for wl, wrappedLine := range wrappedLines {
 			prompt := m.promptView(displayLine)
 			prompt = styles.computedPrompt().Render(prompt)
@@ -1732,12 +2138,12 @@ func (m Model) View() string {
 					m.renderLineWithAttachments(
 						wrappedLine[:lineInfo.ColumnOffset],
 						style,
+						l, offset,
 					),
 				)
 
 				if m.col >= len(line) && lineInfo.CharOffset >= m.width {
-					m.virtualCursor.SetChar(" ")
-					s.WriteString(m.virtualCursor.View())
+					s.WriteString(m.virtualCursorView(" "))
 				} else if lineInfo.ColumnOffset < len(wrappedLine) {
 					// Render the item under the cursor
 					item := wrappedLine[lineInfo.ColumnOffset]
@@ -1747,20 +2153,19 @@ func (m Model) View() string {
 						s.WriteString(m.Styles.SelectedAttachment.Render(att.Display))
 					} else {
 						// Item at cursor is a rune. Render it with the virtual cursor.
-						m.virtualCursor.SetChar(string(item.(rune)))
-						s.WriteString(style.Render(m.virtualCursor.View()))
+						s.WriteString(style.Render(m.virtualCursorView(string(item.(rune)))))
 					}
 
 					// Render the part of the line after the cursor
-					s.WriteString(m.renderLineWithAttachments(wrappedLine[lineInfo.ColumnOffset+1:], style))
+					s.WriteString(m.renderLineWithAttachments(wrappedLine[lineInfo.ColumnOffset+1:], style, l, offset+lineInfo.ColumnOffset+1))
 				} else {
 					// Cursor is at the end of the line
-					m.virtualCursor.SetChar(" ")
-					s.WriteString(style.Render(m.virtualCursor.View()))
+					s.WriteString(style.Render(m.virtualCursorView(" ")))
 				}
 			} else {
-				s.WriteString(m.renderLineWithAttachments(wrappedLine, style))
+				s.WriteString(m.renderLineWithAttachments(wrappedLine, style, l, offset))
 			}
+			offset += len(wrappedLine)
 
 			s.WriteString(style.Render(strings.Repeat(" ", max(0, padding))))
 			s.WriteRune('\n')
@@ -1804,6 +2209,10 @@ func (m Model) lineNumberView(n int, isCursorLine bool) (str string) {
 		return ""
 	}
 
+	if m.RelativeLineNumbers && n > 0 && !isCursorLine {
+		n = abs(n - 1 - m.row)
+	}
+
 	if n <= 0 {
 		str = " "
 	} else {
@@ -1876,8 +2285,7 @@ func (m Model) placeholderView() string {
 		case i == 0:
 			// first character of first line as cursor with character
 			m.virtualCursor.TextStyle = styles.computedPlaceholder()
-			m.virtualCursor.SetChar(string(plines[0][0]))
-			s.WriteString(lineStyle.Render(m.virtualCursor.View()))
+			s.WriteString(lineStyle.Render(m.virtualCursorView(string(plines[0][0]))))
 
 			// the rest of the first line
 			placeholderTail := plines[0][1:]
@@ -2104,6 +2512,18 @@ func wrapInterfaces(content []any, width int) [][]any {
 			inSpaces = false
 			word = append(word, item)
 			wordW += itemW
+			if wordW > width && len(word) > 1 {
+				// The word can never fit on a line, so hard-break it at the width
+				// and carry the overflowing item into the next chunk.
+				if lineW > 0 {
+					lines = append(lines, []any{})
+				}
+				lines[len(lines)-1] = append(lines[len(lines)-1], word[:len(word)-1]...)
+				lines = append(lines, []any{})
+				lineW = 0
+				word = []any{item}
+				wordW = itemW
+			}
 		}
 	}
 
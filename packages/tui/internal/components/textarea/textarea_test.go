@@ -0,0 +1,411 @@
+package textarea
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/attachment"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// newTestModel returns a focused textarea with a static cursor and distinct
+// attachment styles so that the selected attachment shows up in the output
+func newTestModel(width int) Model {
+	m := New()
+	m.Styles.Cursor.Blink = false
+	m.Styles.Attachment = lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+	m.Styles.SelectedAttachment = lipgloss.NewStyle().Reverse(true)
+	m.ShowLineNumbers = false
+	m.SetWidth(width)
+	m.Focus()
+	return m
+}
+
+func newTestAttachment(id, display string) *attachment.Attachment {
+	return &attachment.Attachment{
+		ID:      id,
+		Type:    "file",
+		Display: display,
+		URL:     "file://" + display,
+	}
+}
+
+func TestViewGolden(t *testing.T) {
+	cases := []struct {
+		name  string
+		setup func() Model
+	}{
+		{
+			name: "placeholder",
+			setup: func() Model {
+				m := newTestModel(20)
+				m.Placeholder = "Ask anything about the project"
+				return m
+			},
+		},
+		{
+			name: "placeholder_line_numbers",
+			setup: func() Model {
+				m := newTestModel(20)
+				m.ShowLineNumbers = true
+				m.SetWidth(20)
+				m.Placeholder = "Ask anything about the project"
+				return m
+			},
+		},
+		{
+			name: "cursor_on_attachment",
+			setup: func() Model {
+				m := newTestModel(40)
+				m.InsertString("see ")
+				m.InsertAttachment(newTestAttachment("a", "@main.go"))
+				m.InsertString(" and ")
+				m.InsertAttachment(newTestAttachment("b", "@go.mod"))
+				m.SetCursorColumn(4)
+				return m
+			},
+		},
+		{
+			name: "cursor_after_attachment",
+			setup: func() Model {
+				m := newTestModel(40)
+				m.InsertString("see ")
+				m.InsertAttachment(newTestAttachment("a", "@main.go"))
+				m.InsertString(" and ")
+				m.InsertAttachment(newTestAttachment("b", "@go.mod"))
+				return m
+			},
+		},
+		{
+			name: "wrapped_double_width",
+			setup: func() Model {
+				m := newTestModel(14)
+				m.InsertString("こんにちは 世界 折り返し テスト")
+				m.SetCursorColumn(10)
+				return m
+			},
+		},
+		{
+			name: "wrapped_attachment",
+			setup: func() Model {
+				m := newTestModel(16)
+				m.InsertString("please review ")
+				m.InsertAttachment(newTestAttachment("a", "@internal/app.go"))
+				m.InsertString(" now")
+				return m
+			},
+		},
+		{
+			name: "line_numbers",
+			setup: func() Model {
+				m := newTestModel(24)
+				m.ShowLineNumbers = true
+				m.SetWidth(24)
+				m.InsertString("first line\nsecond line that wraps around\nthird")
+				m.SetCursorPosition(1, 7)
+				return m
+			},
+		},
+		{
+			name: "relative_line_numbers",
+			setup: func() Model {
+				m := newTestModel(24)
+				m.ShowLineNumbers = true
+				m.RelativeLineNumbers = true
+				m.SetWidth(24)
+				m.InsertString("first\nsecond line that wraps around\nthird\nfourth")
+				m.SetCursorPosition(1, 3)
+				return m
+			},
+		},
+		{
+			name: "selection",
+			setup: func() Model {
+				m := newTestModel(24)
+				m.Styles.Selection = lipgloss.NewStyle().Underline(true)
+				m.InsertString("select ")
+				m.InsertAttachment(newTestAttachment("a", "@main.go"))
+				m.InsertString(" across\nwrapped lines of text")
+				m.SelectionStart = &Position{Row: 0, Col: 3}
+				m.SetCursorPosition(1, 10)
+				return m
+			},
+		},
+		{
+			name: "blurred",
+			setup: func() Model {
+				m := newTestModel(20)
+				m.InsertString("not focused ")
+				m.InsertAttachment(newTestAttachment("a", "@main.go"))
+				m.Blur()
+				return m
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.setup().View()
+
+			golden := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				if err := os.MkdirAll("testdata", 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(expected) {
+				t.Errorf("view differs from %s, run with -update to accept:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestWrapLongWord(t *testing.T) {
+	long := strings.Repeat("x", 500)
+	cases := []struct {
+		name    string
+		content string
+		width   int
+	}{
+		{"alone", long, 20},
+		{"after word", "see " + long, 20},
+		{"between words", "see " + long + " done", 17},
+		{"double width", strings.Repeat("界", 500), 15},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var content []any
+			for _, r := range tc.content {
+				content = append(content, r)
+			}
+			var joined strings.Builder
+			for i, line := range wrapInterfaces(content, tc.width) {
+				var lineW int
+				for _, item := range line {
+					lineW += itemWidth(item)
+					joined.WriteRune(item.(rune))
+				}
+				if lineW > tc.width {
+					t.Errorf("line %d is %d wide, want at most %d", i, lineW, tc.width)
+				}
+			}
+			if got := strings.ReplaceAll(joined.String(), " ", ""); got != strings.ReplaceAll(tc.content, " ", "") {
+				t.Errorf("wrapping lost content: %q", got)
+			}
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	m := newTestModel(40)
+	m.InsertString("one ")
+	m.InsertAttachment(newTestAttachment("a", "@main.go"))
+	m.InsertString(" two\nthree four")
+
+	// select backwards from the cursor to just inside the first row
+	m.SetCursorPosition(1, 5)
+	m.SelectionStart = &Position{Row: 1, Col: 5}
+	m.SetCursorPosition(0, 2)
+	if got, want := m.SelectedText(), "e @main.go two\nthree"; got != want {
+		t.Errorf("SelectedText() = %q, want %q", got, want)
+	}
+
+	if !m.DeleteSelection() {
+		t.Fatal("DeleteSelection() = false, want true")
+	}
+	if got, want := m.Value(), "on four"; got != want {
+		t.Errorf("Value() after delete = %q, want %q", got, want)
+	}
+	if m.Line() != 0 || m.CursorColumn() != 2 {
+		t.Errorf("cursor after delete = %d:%d, want 0:2", m.Line(), m.CursorColumn())
+	}
+	if m.HasSelection() || m.DeleteSelection() {
+		t.Error("selection still active after delete")
+	}
+}
+
+func TestSelectionKeys(t *testing.T) {
+	press := func(m Model, k string) Model {
+		var msg tea.KeyPressMsg
+		switch k {
+		case "shift+left":
+			msg = tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModShift}
+		case "ctrl+shift+left":
+			msg = tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModShift | tea.ModCtrl}
+		case "left":
+			msg = tea.KeyPressMsg{Code: tea.KeyLeft}
+		default:
+			msg = tea.KeyPressMsg{Code: rune(k[0]), Text: k}
+		}
+		m, _ = m.Update(msg)
+		return m
+	}
+
+	m := newTestModel(40)
+	m.InsertString("hello brave world")
+	m = press(m, "ctrl+shift+left")
+	m = press(m, "shift+left")
+	if got, want := m.SelectedText(), " world"; got != want {
+		t.Errorf("SelectedText() = %q, want %q", got, want)
+	}
+
+	// typing replaces the selection
+	m = press(m, "!")
+	if got, want := m.Value(), "hello brave!"; got != want {
+		t.Errorf("Value() after typing = %q, want %q", got, want)
+	}
+
+	// moving without shift drops the selection
+	m = press(m, "shift+left")
+	m = press(m, "left")
+	if m.HasSelection() {
+		t.Error("selection still active after moving the cursor")
+	}
+}
+
+func TestRemoveAttachment(t *testing.T) {
+	m := newTestModel(40)
+	m.InsertString("see ")
+	m.InsertAttachment(newTestAttachment("a", "@main.go"))
+	m.InsertString(" and ")
+	m.InsertAttachment(newTestAttachment("b", "@go.mod"))
+	m.InsertString(" now\nnext")
+	m.SetCursorPosition(0, 12)
+	m.SelectionStart = &Position{Row: 0, Col: 6}
+
+	if m.RemoveAttachment("missing") {
+		t.Error("RemoveAttachment(missing) = true, want false")
+	}
+	if !m.RemoveAttachment("a") {
+		t.Fatal("RemoveAttachment(a) = false, want true")
+	}
+	if got, want := m.Value(), "see  and @go.mod now\nnext"; got != want {
+		t.Errorf("Value() = %q, want %q", got, want)
+	}
+	// the cursor and the anchor stay on the items they were on
+	if m.Line() != 0 || m.CursorColumn() != 11 {
+		t.Errorf("cursor = %d:%d, want 0:11", m.Line(), m.CursorColumn())
+	}
+	if got, want := m.SelectedText(), "and @go.mod "; got != want {
+		t.Errorf("SelectedText() = %q, want %q", got, want)
+	}
+	if got := len(m.GetAttachments()); got != 1 {
+		t.Errorf("len(GetAttachments()) = %d, want 1", got)
+	}
+}
+
+func TestMaxLines(t *testing.T) {
+	m := newTestModel(40)
+	m.MaxLines = 3
+
+	cmd := m.PasteRunes([]rune("a\nb\nc\nd\ne"))
+	if got, want := m.Value(), "a\nb\nc"; got != want {
+		t.Errorf("Value() = %q, want %q", got, want)
+	}
+	if cmd == nil {
+		t.Fatal("PasteRunes() returned no command after dropping lines")
+	}
+	if got, want := cmd(), (LineLimitReachedMsg{Dropped: 2}); got != want {
+		t.Errorf("PasteRunes() message = %#v, want %#v", got, want)
+	}
+
+	m.Newline()
+	if got := m.LineCount(); got != 3 {
+		t.Errorf("LineCount() after newline at the limit = %d, want 3", got)
+	}
+
+	m.MaxLines = 0
+	if cmd := m.PasteRunes([]rune("\nd\ne")); cmd != nil {
+		t.Errorf("PasteRunes() without a limit returned %#v", cmd())
+	}
+	if got := m.LineCount(); got != 5 {
+		t.Errorf("LineCount() without a limit = %d, want 5", got)
+	}
+}
+
+func TestAutoPair(t *testing.T) {
+	backspace := tea.KeyPressMsg{Code: tea.KeyBackspace}
+	typeText := func(m Model, text string) Model {
+		for _, r := range text {
+			m, _ = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		}
+		return m
+	}
+
+	m := newTestModel(40)
+	m.AutoPair = true
+	m = typeText(m, "f(")
+	if got, want := m.Value(), "f()"; got != want {
+		t.Errorf("Value() after opener = %q, want %q", got, want)
+	}
+	if got := m.CursorColumn(); got != 2 {
+		t.Errorf("CursorColumn() after opener = %d, want 2", got)
+	}
+
+	// typing the closer steps over the one already there
+	m = typeText(m, `"x")`)
+	if got, want := m.Value(), `f("x")`; got != want {
+		t.Errorf("Value() after closers = %q, want %q", got, want)
+	}
+	if got := m.CursorColumn(); got != 6 {
+		t.Errorf("CursorColumn() after closers = %d, want 6", got)
+	}
+
+	// deleting the opener of an empty pair deletes both
+	m = typeText(m, "[")
+	m, _ = m.Update(backspace)
+	if got, want := m.Value(), `f("x")`; got != want {
+		t.Errorf("Value() after deleting an empty pair = %q, want %q", got, want)
+	}
+
+	// pastes are inserted as they are
+	m.PasteRunes([]rune("{"))
+	if got, want := m.Value(), `f("x"){`; got != want {
+		t.Errorf("Value() after paste = %q, want %q", got, want)
+	}
+
+	// text inserted by the program is not paired
+	m = newTestModel(40)
+	m.AutoPair = true
+	m.InsertString("a (b")
+	m.InsertRune('`')
+	if got, want := m.Value(), "a (b`"; got != want {
+		t.Errorf("Value() after inserting text = %q, want %q", got, want)
+	}
+
+	// a typed code fence gets three backticks
+	m = newTestModel(40)
+	m.AutoPair = true
+	m = typeText(m, "```")
+	if got, want := m.Value(), "```"; got != want {
+		t.Errorf("Value() after typing a fence = %q, want %q", got, want)
+	}
+
+	// the opener goes in alone when there is no room for the closer
+	m = newTestModel(40)
+	m.AutoPair = true
+	m.CharLimit = 2
+	m = typeText(m, "a{")
+	if got, want := m.Value(), "a{"; got != want {
+		t.Errorf("Value() at the char limit = %q, want %q", got, want)
+	}
+
+	m = newTestModel(40)
+	m = typeText(m, "(")
+	if got, want := m.Value(), "("; got != want {
+		t.Errorf("Value() with AutoPair off = %q, want %q", got, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/sst/opencode/internal/layout"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
@@ -34,20 +35,59 @@ type Toast struct {
 	Color     compat.AdaptiveColor
 	CreatedAt time.Time
 	Duration  time.Duration
+	// Expanded shows the whole message instead of truncating it
+	Expanded bool
 }
 
+// defaultMaxLines is how many message lines a toast shows before truncating
+const defaultMaxLines = 6
+
+// defaultMaxStack is how many toasts show at once
+const defaultMaxStack = 3
+
 // ToastManager manages multiple toast notifications
 type ToastManager struct {
-	toasts []Toast
+	toasts   []Toast
+	maxWidth int
+	maxLines int
+	maxStack int
 }
 
-// NewToastManager creates a new toast manager
-func NewToastManager() *ToastManager {
+// NewToastManager creates a new toast manager. Toasts wrap at maxWidth columns
+// and truncate after maxLines message lines, and only the newest maxStack
+// show at once, with zero picking a default for any of them
+func NewToastManager(maxWidth, maxLines, maxStack int) *ToastManager {
+	if maxLines <= 0 {
+		maxLines = defaultMaxLines
+	}
+	if maxStack <= 0 {
+		maxStack = defaultMaxStack
+	}
 	return &ToastManager{
-		toasts: []Toast{},
+		toasts:   []Toast{},
+		maxWidth: maxWidth,
+		maxLines: maxLines,
+		maxStack: maxStack,
 	}
 }
 
+// visible returns the toasts that show, oldest first, and how many older ones
+// are hidden until the newer ones are dismissed
+func (tm *ToastManager) visible() ([]Toast, int) {
+	hidden := max(len(tm.toasts)-tm.maxStack, 0)
+	return tm.toasts[hidden:], hidden
+}
+
+// renderHidden renders the indicator for toasts beyond the stack limit
+func (tm *ToastManager) renderHidden(hidden int) string {
+	t := theme.CurrentTheme()
+	return styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundElement()).
+		Padding(0, 2).
+		Render(fmt.Sprintf("+%d more", hidden))
+}
+
 // Init initializes the toast manager
 func (tm *ToastManager) Init() tea.Cmd {
 	return nil
@@ -86,6 +126,40 @@ func (tm *ToastManager) Update(msg tea.Msg) (*ToastManager, tea.Cmd) {
 	return tm, nil
 }
 
+// ExpandLatest shows the whole message of the newest truncated toast and
+// restarts its dismissal timer, reporting false when no toast is truncated
+func (tm *ToastManager) ExpandLatest() (tea.Cmd, bool) {
+	for i := len(tm.toasts) - 1; i >= 0; i-- {
+		toast := &tm.toasts[i]
+		if toast.Expanded || len(tm.wrapMessage(toast.Message)) <= tm.maxLines {
+			continue
+		}
+		toast.Expanded = true
+		// a new ID orphans the pending dismissal so the toast stays for a full duration
+		toast.ID = fmt.Sprintf("toast-%d", time.Now().UnixNano())
+		id := toast.ID
+		return tea.Tick(toast.Duration, func(t time.Time) tea.Msg {
+			return DismissToastMsg{ID: id}
+		}), true
+	}
+	return nil, false
+}
+
+// width is the outer width of a toast, from config or a third of the viewport
+func (tm *ToastManager) width() int {
+	if tm.maxWidth > 0 {
+		return max(tm.maxWidth, 26)
+	}
+	return max(40, layout.Current.Viewport.Width/3)
+}
+
+// wrapMessage wraps the message to the toast content width, breaking words
+// too long to fit
+func (tm *ToastManager) wrapMessage(message string) []string {
+	contentMaxWidth := max(tm.width()-6, 20)
+	return strings.Split(ansi.Wrap(message, contentMaxWidth, " "), "\n")
+}
+
 // renderSingleToast renders a single toast notification
 func (tm *ToastManager) renderSingleToast(toast Toast) string {
 	t := theme.CurrentTheme()
@@ -95,8 +169,7 @@ func (tm *ToastManager) renderSingleToast(toast Toast) string {
 		Background(t.BackgroundElement()).
 		Padding(1, 2)
 
-	maxWidth := max(40, layout.Current.Viewport.Width/3)
-	contentMaxWidth := max(maxWidth-6, 20)
+	maxWidth := tm.width()
 
 	// Build content with wrapping
 	var content strings.Builder
@@ -107,13 +180,18 @@ func (tm *ToastManager) renderSingleToast(toast Toast) string {
 		content.WriteString("\n")
 	}
 
-	// Wrap message text
-	messageStyle := styles.NewStyle()
-	contentWidth := lipgloss.Width(toast.Message)
-	if contentWidth > contentMaxWidth {
-		messageStyle = messageStyle.Width(contentMaxWidth)
+	// Wrap message text, truncating long messages until expanded
+	lines := tm.wrapMessage(toast.Message)
+	if !toast.Expanded && len(lines) > tm.maxLines {
+		hidden := len(lines) - tm.maxLines + 1
+		lines = append(
+			lines[:tm.maxLines-1],
+			styles.NewStyle().
+				Foreground(t.TextMuted()).
+				Render(fmt.Sprintf("… %d more lines, /expand to show", hidden)),
+		)
 	}
-	content.WriteString(messageStyle.Render(toast.Message))
+	content.WriteString(strings.Join(lines, "\n"))
 
 	// Render toast with max width
 	return baseStyle.MaxWidth(maxWidth).Render(content.String())
@@ -125,11 +203,15 @@ func (tm *ToastManager) View() string {
 		return ""
 	}
 
+	toasts, hidden := tm.visible()
 	var toastViews []string
-	for _, toast := range tm.toasts {
+	for _, toast := range toasts {
 		toastView := tm.renderSingleToast(toast)
 		toastViews = append(toastViews, toastView+"\n")
 	}
+	if hidden > 0 {
+		toastViews = append(toastViews, tm.renderHidden(hidden))
+	}
 
 	return strings.Join(toastViews, "\n")
 }
@@ -148,7 +230,8 @@ func (tm *ToastManager) RenderOverlay(background string) string {
 	currentY := 2
 
 	// Render each toast individually
-	for _, toast := range tm.toasts {
+	toasts, hidden := tm.visible()
+	for _, toast := range toasts {
 		// Render individual toast
 		toastView := tm.renderSingleToast(toast)
 		toastWidth := lipgloss.Width(toastView)
@@ -177,6 +260,16 @@ func (tm *ToastManager) RenderOverlay(background string) string {
 		currentY += toastHeight + 1
 	}
 
+	if hidden > 0 && currentY < bgHeight-2 {
+		indicator := tm.renderHidden(hidden)
+		result = layout.PlaceOverlay(
+			max(bgWidth-lipgloss.Width(indicator)-4, 0),
+			currentY,
+			indicator,
+			result,
+		)
+	}
+
 	return result
 }
 
@@ -0,0 +1,38 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/theme"
+)
+
+func TestMaxStack(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	tm := NewToastManager(0, 0, 2)
+	for _, message := range []string{"first", "second", "third", "fourth"} {
+		tm, _ = tm.Update(NewInfoToast(message)())
+	}
+
+	view := ansi.Strip(tm.View())
+	for _, message := range []string{"third", "fourth", "+2 more"} {
+		if !strings.Contains(view, message) {
+			t.Errorf("expected %q in the toasts:\n%s", message, view)
+		}
+	}
+	for _, message := range []string{"first", "second"} {
+		if strings.Contains(view, message) {
+			t.Errorf("expected %q to be hidden:\n%s", message, view)
+		}
+	}
+
+	tm, _ = tm.Update(DismissToastMsg{ID: tm.toasts[3].ID})
+	if view := ansi.Strip(tm.View()); !strings.Contains(view, "second") || !strings.Contains(view, "+1 more") {
+		t.Errorf("expected an older toast to show after a dismissal:\n%s", view)
+	}
+}
@@ -0,0 +1,31 @@
+package layout
+
+// EditorPlacement holds the overlay coordinates of the expanded editor
+type EditorPlacement struct {
+	X int
+	Y int
+}
+
+// CenteredEditorPlacement places an editor of the given width below content of
+// contentHeight rows, with both centered in the container, as on the home screen
+func CenteredEditorPlacement(container Dimensions, editorWidth, contentHeight int) EditorPlacement {
+	return EditorPlacement{
+		X: (container.Width - editorWidth) / 2,
+		Y: (container.Height / 2) + (contentHeight / 2) - 2,
+	}
+}
+
+// BottomEditorPlacement places an editor of the given size horizontally
+// centered at the bottom of the container, as in the chat view
+func BottomEditorPlacement(container Dimensions, editor Dimensions) EditorPlacement {
+	return EditorPlacement{
+		X: (container.Width - editor.Width) / 2,
+		Y: container.Height - editor.Height,
+	}
+}
+
+// Above returns the y coordinate for an overlay of the given height whose last
+// row sits on the editor's first row, such as the completions popup
+func (p EditorPlacement) Above(height int) int {
+	return p.Y - height + 1
+}
@@ -0,0 +1,56 @@
+package layout
+
+import "testing"
+
+func TestCenteredEditorPlacement(t *testing.T) {
+	cases := []struct {
+		name          string
+		container     Dimensions
+		editorWidth   int
+		contentHeight int
+		expected      EditorPlacement
+	}{
+		{"even", Dimensions{Width: 96, Height: 40}, 80, 10, EditorPlacement{X: 8, Y: 23}},
+		{"odd", Dimensions{Width: 97, Height: 41}, 80, 11, EditorPlacement{X: 8, Y: 23}},
+		{"editor wider than container", Dimensions{Width: 60, Height: 20}, 80, 4, EditorPlacement{X: -10, Y: 10}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CenteredEditorPlacement(tc.container, tc.editorWidth, tc.contentHeight)
+			if got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestBottomEditorPlacement(t *testing.T) {
+	cases := []struct {
+		name      string
+		container Dimensions
+		editor    Dimensions
+		expected  EditorPlacement
+	}{
+		{"single line", Dimensions{Width: 96, Height: 40}, Dimensions{Width: 80, Height: 5}, EditorPlacement{X: 8, Y: 35}},
+		{"expanded", Dimensions{Width: 96, Height: 40}, Dimensions{Width: 80, Height: 12}, EditorPlacement{X: 8, Y: 28}},
+		{"odd width", Dimensions{Width: 95, Height: 40}, Dimensions{Width: 80, Height: 5}, EditorPlacement{X: 7, Y: 35}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := BottomEditorPlacement(tc.container, tc.editor)
+			if got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEditorPlacementAbove(t *testing.T) {
+	p := EditorPlacement{X: 8, Y: 35}
+	if got := p.Above(1); got != 35 {
+		t.Errorf("expected a one row overlay on the editor row, got y=%d", got)
+	}
+	if got := p.Above(10); got != 26 {
+		t.Errorf("expected y=26, got %d", got)
+	}
+}
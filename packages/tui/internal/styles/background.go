@@ -1,6 +1,10 @@
 package styles
 
-import "image/color"
+import (
+	"image/color"
+
+	"github.com/charmbracelet/lipgloss/v2/compat"
+)
 
 type TerminalInfo struct {
 	Background       color.Color
@@ -15,3 +19,22 @@ func init() {
 		BackgroundIsDark: true,
 	}
 }
+
+// SetTerminalBackground records the terminal background and whether it is
+// dark, which picks the light or dark variant of adaptive colors
+func SetTerminalBackground(background color.Color, isDark bool) {
+	Terminal = &TerminalInfo{
+		Background:       background,
+		BackgroundIsDark: isDark,
+	}
+	compat.HasDarkBackground = isDark
+}
+
+// DefaultBackground returns the background assumed for the light or dark mode
+// when the terminal doesn't report one
+func DefaultBackground(isDark bool) color.Color {
+	if isDark {
+		return color.Black
+	}
+	return color.White
+}
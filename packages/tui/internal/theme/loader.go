@@ -3,6 +3,7 @@ package theme
 import (
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image/color"
 	"os"
@@ -80,13 +81,36 @@ func LoadThemesFromDirectories(userConfig, projectRoot, cwd string) error {
 		dirs = append(dirs, filepath.Join(cwd, ".autoprovisioner", "themes"))
 	}
 
+	var errs []error
 	for _, dir := range dirs {
 		if err := loadThemesFromDirectory(dir); err != nil {
-			fmt.Printf("Warning: Failed to load themes from %s: %v\n", dir, err)
+			errs = append(errs, fmt.Errorf("failed to load themes from %s: %w", dir, err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// ReloadThemesFromDirectories drops all loaded themes except the system theme
+// and loads them again, keeping the current theme if it still exists and
+// falling back to opencode otherwise
+func ReloadThemesFromDirectories(userConfig, projectRoot, cwd string) error {
+	current := CurrentThemeName()
+
+	globalManager.mu.Lock()
+	for name := range globalManager.themes {
+		if name != "system" {
+			delete(globalManager.themes, name)
+		}
+	}
+	globalManager.mu.Unlock()
+
+	err := LoadThemesFromDirectories(userConfig, projectRoot, cwd)
+	if setErr := SetTheme(current); setErr != nil {
+		SetTheme("opencode")
+		err = errors.Join(err, setErr)
+	}
+	return err
 }
 
 func loadThemesFromDirectory(dir string) error {
@@ -99,6 +123,7 @@ func loadThemesFromDirectory(dir string) error {
 		return fmt.Errorf("failed to read directory: %w", err)
 	}
 
+	var errs []error
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
 			continue
@@ -109,20 +134,20 @@ func loadThemesFromDirectory(dir string) error {
 
 		data, err := os.ReadFile(filePath)
 		if err != nil {
-			fmt.Printf("Warning: Failed to read theme file %s: %v\n", filePath, err)
+			errs = append(errs, fmt.Errorf("failed to read theme file %s: %w", filePath, err))
 			continue
 		}
 
 		theme, err := parseJSONTheme(themeName, data)
 		if err != nil {
-			fmt.Printf("Warning: Failed to parse theme %s: %v\n", filePath, err)
+			errs = append(errs, fmt.Errorf("failed to parse theme %s: %w", filePath, err))
 			continue
 		}
 
 		RegisterTheme(themeName, theme)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func parseJSONTheme(name string, data []byte) (Theme, error) {
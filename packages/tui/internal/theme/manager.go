@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/lipgloss/v2"
@@ -17,16 +18,37 @@ import (
 // Manager handles theme registration, selection, and retrieval.
 // It maintains a registry of available themes and tracks the currently active theme.
 type Manager struct {
-	themes               map[string]Theme
-	currentName          string
-	currentUsesAnsiCache bool // Cache whether current theme uses ANSI colors
-	mu                   sync.RWMutex
+	themes map[string]Theme
+	// current is replaced rather than modified, so renderers on other
+	// goroutines can read it without locking while the theme changes
+	current atomic.Pointer[currentTheme]
+	mu      sync.RWMutex
+}
+
+// currentTheme is a snapshot of the active theme
+type currentTheme struct {
+	name     string
+	theme    Theme
+	usesAnsi bool // Cache whether the theme uses ANSI colors
 }
 
 // Global instance of the theme manager
 var globalManager = &Manager{
-	themes:      make(map[string]Theme),
-	currentName: "",
+	themes: make(map[string]Theme),
+}
+
+func init() {
+	// keep glamour from picking up chroma's charm style for code blocks
+	delete(styles.Registry, "charm")
+}
+
+// setCurrent publishes the theme as the active one. Callers must hold mu.
+func (m *Manager) setCurrent(name string, theme Theme) {
+	m.current.Store(&currentTheme{
+		name:     name,
+		theme:    theme,
+		usesAnsi: themeUsesAnsiColors(theme),
+	})
 }
 
 // RegisterTheme adds a new theme to the registry.
@@ -38,9 +60,8 @@ func RegisterTheme(name string, theme Theme) {
 	globalManager.themes[name] = theme
 
 	// If this is the first theme, make it the default
-	if globalManager.currentName == "" {
-		globalManager.currentName = name
-		globalManager.currentUsesAnsiCache = themeUsesAnsiColors(theme)
+	if globalManager.current.Load() == nil {
+		globalManager.setCurrent(name, theme)
 	}
 }
 
@@ -49,38 +70,34 @@ func RegisterTheme(name string, theme Theme) {
 func SetTheme(name string) error {
 	globalManager.mu.Lock()
 	defer globalManager.mu.Unlock()
-	delete(styles.Registry, "charm")
 
 	theme, exists := globalManager.themes[name]
 	if !exists {
 		return fmt.Errorf("theme '%s' not found", name)
 	}
 
-	globalManager.currentName = name
-	globalManager.currentUsesAnsiCache = themeUsesAnsiColors(theme)
+	globalManager.setCurrent(name, theme)
 
 	return nil
 }
 
 // CurrentTheme returns the currently active theme.
-// If no theme is set, it returns nil.
+// If no theme is set, it returns nil. It is safe to call from any goroutine.
 func CurrentTheme() Theme {
-	globalManager.mu.RLock()
-	defer globalManager.mu.RUnlock()
-
-	if globalManager.currentName == "" {
+	current := globalManager.current.Load()
+	if current == nil {
 		return nil
 	}
-
-	return globalManager.themes[globalManager.currentName]
+	return current.theme
 }
 
 // CurrentThemeName returns the name of the currently active theme.
 func CurrentThemeName() string {
-	globalManager.mu.RLock()
-	defer globalManager.mu.RUnlock()
-
-	return globalManager.currentName
+	current := globalManager.current.Load()
+	if current == nil {
+		return ""
+	}
+	return current.name
 }
 
 // AvailableThemes returns a list of all registered theme names.
@@ -124,17 +141,15 @@ func UpdateSystemTheme(terminalBg color.Color, isDark bool) {
 
 	dynamicTheme := NewSystemTheme(terminalBg, isDark)
 	globalManager.themes["system"] = dynamicTheme
-	if globalManager.currentName == "system" {
-		globalManager.currentUsesAnsiCache = themeUsesAnsiColors(dynamicTheme)
+	if CurrentThemeName() == "system" {
+		globalManager.setCurrent("system", dynamicTheme)
 	}
 }
 
 // CurrentThemeUsesAnsiColors returns true if the current theme uses ANSI 0-16 colors
 func CurrentThemeUsesAnsiColors() bool {
-	// globalManager.mu.RLock()
-	// defer globalManager.mu.RUnlock()
-
-	return globalManager.currentUsesAnsiCache
+	current := globalManager.current.Load()
+	return current != nil && current.usesAnsi
 }
 
 // isAnsiColor checks if a color represents an ANSI 0-16 color
@@ -0,0 +1,53 @@
+package theme
+
+import (
+	"image/color"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentThemeAccess reads the current theme from several goroutines,
+// as parallel rendering does, while the theme changes. Run with -race.
+func TestConcurrentThemeAccess(t *testing.T) {
+	if err := LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	UpdateSystemTheme(color.Black, true)
+	defer SetTheme("opencode")
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	for range 8 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				current := CurrentTheme()
+				if current == nil {
+					t.Error("CurrentTheme returned nil while switching themes")
+					return
+				}
+				_ = current.Background()
+				_ = current.DiffAddedBg()
+				_ = CurrentThemeName()
+				_ = CurrentThemeUsesAnsiColors()
+			}
+		}()
+	}
+
+	for i := range 200 {
+		if i%2 == 0 {
+			SetTheme("system")
+		} else {
+			SetTheme("opencode")
+		}
+		UpdateSystemTheme(color.Gray{Y: uint8(i)}, i%3 != 0)
+	}
+	close(done)
+	readers.Wait()
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+)
+
+// conversationMessage is a message recovered from an exported conversation
+type conversationMessage struct {
+	Role string
+	Text string
+}
+
+// conversationHeaderPattern matches the role and timestamp line written by
+// formatConversationToMarkdown after each --- separator
+var conversationHeaderPattern = regexp.MustCompile(`^\*\*(User|Assistant)\*\* \(\*[^*]*\*\)$`)
+
+// conversationPartPattern matches the placeholders written for file and tool
+// parts, which can't be replayed
+var conversationPartPattern = regexp.MustCompile(`^\[(File|Tool): .*\]$`)
+
+func formatConversationToMarkdown(messages []app.Message) string {
+	var builder strings.Builder
+
+	builder.WriteString("# Conversation History\n\n")
+
+	for _, msg := range messages {
+		builder.WriteString("---\n\n")
+
+		var role string
+		var timestamp time.Time
+
+		switch info := msg.Info.(type) {
+		case opencode.UserMessage:
+			role = "User"
+			timestamp = time.UnixMilli(int64(info.Time.Created))
+		case opencode.AssistantMessage:
+			role = "Assistant"
+			timestamp = time.UnixMilli(int64(info.Time.Created))
+		default:
+			continue
+		}
+
+		builder.WriteString(
+			fmt.Sprintf("**%s** (*%s*)\n\n", role, timestamp.Format("2006-01-02 15:04:05")),
+		)
+
+		for _, part := range msg.Parts {
+			switch p := part.(type) {
+			case opencode.TextPart:
+				builder.WriteString(p.Text + "\n\n")
+			case opencode.FilePart:
+				builder.WriteString(fmt.Sprintf("[File: %s]\n\n", p.Filename))
+			case opencode.ToolPart:
+				builder.WriteString(fmt.Sprintf("[Tool: %s]\n\n", p.Tool))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// parseConversationMarkdown reads a conversation written by
+// formatConversationToMarkdown back into its messages. A message starts at a
+// --- line followed by a role header, and runs until the next one; file and
+// tool placeholders are dropped since only the text can be replayed.
+func parseConversationMarkdown(content string) []conversationMessage {
+	var messages []conversationMessage
+	var current *conversationMessage
+	var body []string
+
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(strings.Join(body, "\n"))
+			messages = append(messages, *current)
+		}
+		body = nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == "---" {
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next < len(lines) {
+				if match := conversationHeaderPattern.FindStringSubmatch(lines[next]); match != nil {
+					flush()
+					current = &conversationMessage{Role: match[1]}
+					i = next
+					continue
+				}
+			}
+		}
+		if current == nil || conversationPartPattern.MatchString(line) {
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	return messages
+}
+
+// conversationUserPrompts returns the non-empty user messages of a parsed
+// conversation in order
+func conversationUserPrompts(messages []conversationMessage) []string {
+	var prompts []string
+	for _, message := range messages {
+		if message.Role == "User" && message.Text != "" {
+			prompts = append(prompts, message.Text)
+		}
+	}
+	return prompts
+}
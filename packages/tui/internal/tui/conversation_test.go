@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+)
+
+func TestParseConversationMarkdown(t *testing.T) {
+	messages := []app.Message{
+		{
+			Info: opencode.UserMessage{},
+			Parts: []opencode.PartUnion{
+				opencode.TextPart{Text: "fix the bug\n\n---\n\nin main.go"},
+				opencode.FilePart{Filename: "main.go"},
+			},
+		},
+		{
+			Info:  opencode.AssistantMessage{},
+			Parts: []opencode.PartUnion{opencode.TextPart{Text: "done"}, opencode.ToolPart{Tool: "edit"}},
+		},
+		{
+			Info:  opencode.UserMessage{},
+			Parts: []opencode.PartUnion{opencode.TextPart{Text: "thanks"}},
+		},
+	}
+
+	parsed := parseConversationMarkdown(formatConversationToMarkdown(messages))
+	expected := []conversationMessage{
+		{Role: "User", Text: "fix the bug\n\n---\n\nin main.go"},
+		{Role: "Assistant", Text: "done"},
+		{Role: "User", Text: "thanks"},
+	}
+	if len(parsed) != len(expected) {
+		t.Fatalf("expected %d messages, got %d: %+v", len(expected), len(parsed), parsed)
+	}
+	for i, message := range parsed {
+		if message != expected[i] {
+			t.Errorf("message %d: expected %+v, got %+v", i, expected[i], message)
+		}
+	}
+
+	prompts := conversationUserPrompts(parsed)
+	if len(prompts) != 2 || prompts[1] != "thanks" {
+		t.Errorf("unexpected user prompts: %q", prompts)
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// defaultLogo is shown on the home screen unless tui.logo is configured
+const defaultLogo = "AutoProvisioner"
+
+// renderLogo renders the home screen logo with the version beneath it and the
+// optional tagline, fitted to width. A custom logo too wide for the terminal
+// falls back to the default one, and the logo and version are truncated as a
+// last resort, while the tagline wraps.
+func renderLogo(logoText, version, tagline string, width int) string {
+	t := theme.CurrentTheme()
+	width = max(width, 1)
+	if lipgloss.Width(logoText) > width {
+		logoText = defaultLogo
+	}
+	if lipgloss.Width(logoText) > width {
+		logoText = ansi.Truncate(logoText, width, "…")
+	}
+	logo := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.NewStyle().Background(t.Background()).Bold(true).Render(logoText),
+	)
+
+	// the version sits under the right edge of the logo, widening the block
+	// when it is longer than the logo
+	blockWidth := min(max(lipgloss.Width(logo), lipgloss.Width(version)), width)
+	logo = lipgloss.PlaceHorizontal(
+		blockWidth,
+		lipgloss.Center,
+		logo,
+		styles.WhitespaceStyle(t.Background()),
+	)
+	versionView := styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.Background()).
+		Width(blockWidth).
+		Align(lipgloss.Right).
+		Render(ansi.Truncate(version, blockWidth, "…"))
+
+	logoAndVersion := strings.Join([]string{logo, versionView}, "\n")
+	if tagline == "" {
+		return logoAndVersion
+	}
+	taglineView := styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.Background()).
+		Width(min(lipgloss.Width(tagline), width)).
+		Render(tagline)
+	return lipgloss.JoinVertical(lipgloss.Center, logoAndVersion, "", taglineView)
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/theme"
+)
+
+func TestRenderLogoNarrow(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	wideLogo := "#####################\n#   custom   logo   #\n#####################"
+	cases := []struct {
+		name     string
+		logo     string
+		width    int
+		contains string
+	}{
+		{"default fits", defaultLogo, 80, defaultLogo},
+		{"custom fits", wideLogo, 80, "custom   logo"},
+		{"custom falls back", wideLogo, 18, defaultLogo},
+		{"default truncated", defaultLogo, 8, "AutoPro…"},
+		{"tiny", wideLogo, 1, "…"},
+	}
+	for _, tc := range cases {
+		view := renderLogo(tc.logo, "v0.0.0-development", "provision anything, anywhere", tc.width)
+		plain := ansi.Strip(view)
+		if !strings.Contains(plain, tc.contains) {
+			t.Errorf("%s: expected %q in\n%s", tc.name, tc.contains, plain)
+		}
+		for _, line := range strings.Split(view, "\n") {
+			if w := lipgloss.Width(line); w > tc.width {
+				t.Errorf("%s: line %q is %d wide, over %d", tc.name, ansi.Strip(line), w, tc.width)
+			}
+		}
+	}
+}
@@ -2,17 +2,22 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"image/color"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/sst/opencode-sdk-go"
 	"github.com/sst/opencode/internal/app"
@@ -21,7 +26,9 @@ import (
 	"github.com/sst/opencode/internal/components/chat"
 	cmdcomp "github.com/sst/opencode/internal/components/commands"
 	"github.com/sst/opencode/internal/components/dialog"
+	"github.com/sst/opencode/internal/components/diff"
 	"github.com/sst/opencode/internal/components/fileviewer"
+	"github.com/sst/opencode/internal/components/inspector"
 	"github.com/sst/opencode/internal/components/modal"
 	"github.com/sst/opencode/internal/components/status"
 	"github.com/sst/opencode/internal/components/toast"
@@ -37,6 +44,32 @@ type InterruptDebounceTimeoutMsg struct{}
 // ExitDebounceTimeoutMsg is sent when the exit key debounce timeout expires
 type ExitDebounceTimeoutMsg struct{}
 
+// BackgroundColorTimeoutMsg is sent when the terminal hasn't reported its
+// background color in time
+type BackgroundColorTimeoutMsg struct{}
+
+// readFile is a file read for the file viewer
+type readFile struct {
+	path    string
+	content string
+	patch   bool
+}
+
+// filesReadMsg carries the files read to be opened as tabs, and how many of
+// the total failed to read
+type filesReadMsg struct {
+	files  []readFile
+	failed int
+	total  int
+}
+
+// sessionsAbortedMsg reports which busy sessions were aborted and how many
+// aborts failed
+type sessionsAbortedMsg struct {
+	aborted []string
+	failed  int
+}
+
 // InterruptKeyState tracks the state of interrupt key presses for debouncing
 type InterruptKeyState int
 
@@ -56,6 +89,34 @@ const (
 const interruptDebounceTimeout = 1 * time.Second
 const exitDebounceTimeout = 1 * time.Second
 
+// backgroundColorTimeout is how long to wait for the terminal to report its
+// background color before falling back to a dark background
+const backgroundColorTimeout = 2 * time.Second
+
+// maxInlineFileSize caps how much file content can be inserted into the editor
+const maxInlineFileSize = 32 * 1024
+
+// maxEditedTabs caps how many edited files are opened at once as tabs
+const maxEditedTabs = 10
+
+// editedTabsConfirmThreshold is the number of edited files above which
+// opening them all asks for confirmation first
+const editedTabsConfirmThreshold = 5
+
+// toolOutputScrollStep is the number of columns truncated tool output scrolls
+const toolOutputScrollStep = 8
+
+// messagesScrollStep is the number of lines the messages scroll while typing
+const messagesScrollStep = 3
+
+// defaultSavePath is where conversations are saved within the project when
+// tui.save_path is not set
+const defaultSavePath = ".autoprovisioner/sessions/{id}.md"
+
+// errSavePathOutsideProject is returned when tui.save_path points outside the
+// project root
+var errSavePathOutsideProject = errors.New("save path is outside the project")
+
 type appModel struct {
 	width, height        int
 	app                  *app.App
@@ -67,22 +128,53 @@ type appModel struct {
 	commandProvider      completions.CompletionProvider
 	fileProvider         completions.CompletionProvider
 	symbolsProvider      completions.CompletionProvider
+	sessionsProvider     completions.CompletionProvider
+	snippetsProvider     completions.CompletionProvider
 	showCompletionDialog bool
-	leaderBinding        *key.Binding
+	// fileCompletionTyped is set when the file completion command typed the
+	// @ trigger, so closing it again removes the trigger
+	fileCompletionTyped bool
+	leaderBinding       *key.Binding
 	// isLeaderSequence     bool
 	toastManager      *toast.ToastManager
+	inspector         *inspector.Model
 	interruptKeyState InterruptKeyState
 	exitKeyState      ExitKeyState
 	messagesRight     bool
 	fileViewer        fileviewer.Model
+	focus             focusArea
+	// backgroundDetected is set once the terminal reports its background color
+	backgroundDetected bool
+	// importQueue holds imported user messages still waiting to be replayed
+	importQueue []string
+	// importAnswered is the assistant message the import replay last advanced
+	// on, since the server reports a completed message more than once
+	importAnswered string
+	// permissions holds pending permission requests, the first of which is
+	// shown in the permission dialog
+	permissions []opencode.EventListResponseEventPermissionUpdatedProperties
+	// modals holds the dialogs hidden beneath modal, most recent last
+	modals []layout.Modal
 }
 
+// focusArea is the pane that receives navigation keys
+type focusArea int
+
+const (
+	focusEditor focusArea = iota
+	focusMessages
+	focusFileViewer
+)
+
 func (a appModel) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	// https://github.com/charmbracelet/bubbletea/issues/1440
 	// https://github.com/sst/opencode/issues/127
-	if !util.IsWsl() {
+	if !util.IsWsl() && a.app.State.Background == "" && !a.app.ThemePinned {
 		cmds = append(cmds, tea.RequestBackgroundColor)
+		cmds = append(cmds, tea.Tick(backgroundColorTimeout, func(t time.Time) tea.Msg {
+			return BackgroundColorTimeoutMsg{}
+		}))
 	}
 	cmds = append(cmds, a.app.InitializeProvider())
 	cmds = append(cmds, a.editor.Init())
@@ -92,6 +184,10 @@ func (a appModel) Init() tea.Cmd {
 	cmds = append(cmds, a.toastManager.Init())
 	cmds = append(cmds, a.fileViewer.Init())
 
+	if a.app.VersionMismatch() && a.app.Config.Tui.VersionMismatch == opencode.ConfigTuiVersionMismatchToast {
+		cmds = append(cmds, a.versionMismatchToast())
+	}
+
 	// Check if we should show the init dialog
 	cmds = append(cmds, func() tea.Msg {
 		shouldShow := a.app.Info.Git && a.app.Info.Time.Initialized > 0
@@ -108,6 +204,15 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		// the keybind hints row takes a line from everything above the status bar
+		if !a.app.State.HideHints {
+			size.Height--
+		}
+		size.Height -= 2 * a.app.State.ContentPadding().Vertical
+		msg = size
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
 		keyString := msg.String()
@@ -115,11 +220,9 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// 1. Handle active modal
 		if a.modal != nil {
 			switch keyString {
-			// Escape always closes current modal
+			// Escape always closes the topmost modal
 			case "esc":
-				cmd := a.modal.Close()
-				a.modal = nil
-				return a, cmd
+				return a, a.closeModal()
 			case "ctrl+c":
 				// give the modal a chance to handle the ctrl+c
 				updatedModal, cmd := a.modal.Update(msg)
@@ -127,9 +230,16 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if cmd != nil {
 					return a, cmd
 				}
-				cmd = a.modal.Close()
-				a.modal = nil
-				return a, cmd
+				return a, a.closeModal()
+			}
+			for _, command := range a.app.Commands.Matches(msg, false) {
+				switch command.Name {
+				case commands.ModalCycleCommand:
+					a.cycleModals()
+					return a, nil
+				case commands.ModalCloseAllCommand:
+					return a, a.closeAllModals()
+				}
 			}
 
 			// Pass all other key presses to the modal
@@ -147,10 +257,21 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Route navigation keys to the messages or file viewer while focused,
+		// keeping typed text out of the editor
+		if a.focus != focusEditor {
+			if cmd, handled := a.handleFocusedKey(keyString); handled {
+				return a, cmd
+			}
+			if msg.Text != "" {
+				return a, nil
+			}
+		}
+
 		// 3. Handle completions trigger
 		if keyString == "/" &&
 			!a.showCompletionDialog &&
-			a.editor.Value() == "" {
+			strings.TrimSpace(a.editor.Value()) == "" {
 			a.showCompletionDialog = true
 
 			updated, cmd := a.editor.Update(msg)
@@ -169,14 +290,24 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle file completions trigger
 		if keyString == "@" &&
 			!a.showCompletionDialog {
+			a.fileCompletionTyped = false
+			return a, a.openFileCompletion(msg)
+		}
+
+		// Handle snippet completions trigger, at the start of a word only so
+		// colons in regular text are left alone
+		value := a.editor.Value()
+		if keyString == ":" &&
+			!a.showCompletionDialog &&
+			len(a.app.Config.Tui.Snippets) > 0 &&
+			(value == "" || strings.HasSuffix(value, " ") || strings.HasSuffix(value, "\n")) {
 			a.showCompletionDialog = true
 
 			updated, cmd := a.editor.Update(msg)
 			a.editor = updated.(chat.EditorComponent)
 			cmds = append(cmds, cmd)
 
-			// Set both file and symbols providers for @ completion
-			a.completions = dialog.NewCompletionDialogComponent("@", a.fileProvider, a.symbolsProvider)
+			a.completions = dialog.NewCompletionDialogComponent(":", a.snippetsProvider)
 			updated, cmd = a.completions.Update(msg)
 			a.completions = updated.(dialog.CompletionDialog)
 			cmds = append(cmds, cmd)
@@ -185,6 +316,11 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if a.showCompletionDialog {
+			fileCompletionCommand := a.app.Commands[commands.InputFileCompletionCommand]
+			if fileCompletionCommand.Matches(msg, a.app.IsLeaderSequence) {
+				a.closeFileCompletion()
+				return a, nil
+			}
 			switch keyString {
 			case "tab", "enter", "esc", "ctrl+c", "up", "down", "ctrl+p", "ctrl+n":
 				updated, cmd := a.completions.Update(msg)
@@ -204,6 +340,12 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Batch(cmds...)
 		}
 
+		// Tab moves between the fields of an expanded snippet
+		if keyString == "tab" && a.editor.InSnippet() {
+			a.editor.NextSnippetField()
+			return a, nil
+		}
+
 		// 4. Maximize editor responsiveness for printable characters
 		if msg.Text != "" {
 			updated, cmd := a.editor.Update(msg)
@@ -220,6 +362,13 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// Let the editor copy its selection before ctrl+c clears the input
+		if keyString == "ctrl+c" && a.focus == focusEditor && a.editor.HasSelection() {
+			updated, cmd := a.editor.Update(msg)
+			a.editor = updated.(chat.EditorComponent)
+			return a, cmd
+		}
+
 		// 6 Handle input clear command
 		inputClearCommand := a.app.Commands[commands.InputClearCommand]
 		if inputClearCommand.Matches(msg, a.app.IsLeaderSequence) && a.editor.Length() > 0 {
@@ -295,29 +444,49 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.messages = updated.(chat.MessagesComponent)
 		cmds = append(cmds, cmd)
 		return a, tea.Batch(cmds...)
+	case inspector.EventMsg:
+		a.inspector.Record(msg)
+		return a, nil
 	case tea.BackgroundColorMsg:
-		styles.Terminal = &styles.TerminalInfo{
-			Background:       msg.Color,
-			BackgroundIsDark: msg.IsDark(),
+		if a.app.State.Background != "" {
+			return a, nil
 		}
+		a.backgroundDetected = true
 		slog.Debug("Background color", "color", msg.String(), "isDark", msg.IsDark())
-		return a, func() tea.Msg {
-			theme.UpdateSystemTheme(
-				styles.Terminal.Background,
-				styles.Terminal.BackgroundIsDark,
-			)
-			return dialog.ThemeSelectedMsg{
-				ThemeName: theme.CurrentThemeName(),
-			}
+		return a, setTerminalBackground(msg.Color, msg.IsDark())
+	case filesReadMsg:
+		for _, file := range msg.files {
+			var cmd tea.Cmd
+			a.fileViewer, cmd = a.fileViewer.SetFile(file.path, file.content, file.patch)
+			cmds = append(cmds, cmd)
 		}
-	case modal.CloseModalMsg:
-		a.editor.Focus()
-		var cmd tea.Cmd
-		if a.modal != nil {
-			cmd = a.modal.Close()
+		cmds = append(cmds, a.layoutPanes())
+		if msg.failed > 0 {
+			cmds = append(cmds, toast.NewErrorToast(
+				fmt.Sprintf("Failed to read %d of %d files", msg.failed, msg.total),
+			))
 		}
-		a.modal = nil
-		return a, cmd
+		return a, tea.Batch(cmds...)
+	case sessionsAbortedMsg:
+		for _, sessionID := range msg.aborted {
+			a.app.SetSessionBusy(sessionID, false)
+		}
+		if msg.failed > 0 {
+			return a, toast.NewErrorToast(fmt.Sprintf(
+				"Failed to abort %d of %s",
+				msg.failed,
+				util.Pluralize(msg.failed+len(msg.aborted), "session"),
+			))
+		}
+		return a, toast.NewSuccessToast("Aborted " + util.Pluralize(len(msg.aborted), "session"))
+	case BackgroundColorTimeoutMsg:
+		if a.backgroundDetected {
+			return a, nil
+		}
+		slog.Warn("Terminal didn't report its background color, assuming dark")
+		return a, setTerminalBackground(styles.DefaultBackground(true), true)
+	case modal.CloseModalMsg:
+		return a, a.closeModal()
 	case commands.ExecuteCommandMsg:
 		updated, cmd := a.executeCommand(commands.Command(msg))
 		return updated, cmd
@@ -332,17 +501,49 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, toast.NewErrorToast(msg.Error())
 	case app.SendPrompt:
 		a.showCompletionDialog = false
+		if a.app.Config.Tui.AutoAttachFile && a.fileViewer.HasFile() {
+			msg = a.app.WithViewedFile(msg, a.fileViewer.Filename())
+		}
+		threshold := a.app.Config.Tui.ConfirmPromptTokens
+		if threshold > 0 && int64(msg.EstimateTokens()) > threshold {
+			a.openModal(dialog.NewSendConfirmDialog(a.app, msg))
+			return a, nil
+		}
 		a.app, cmd = a.app.SendPrompt(context.Background(), msg)
 		cmds = append(cmds, cmd)
+	case dialog.SendConfirmedMsg:
+		if !msg.Confirmed {
+			a.editor.SetPrompt(msg.Prompt.Typed())
+			return a, a.setFocus(focusEditor)
+		}
+		a.app, cmd = a.app.SendPrompt(context.Background(), msg.Prompt)
+		cmds = append(cmds, cmd)
+	case chat.LongPasteMsg:
+		a.openModal(dialog.NewPasteDialog(msg.Text))
+		return a, nil
+	case dialog.RestartConfirmedMsg:
+		if msg.Confirmed {
+			a.app.RestartRequested = true
+			return a, tea.Quit
+		}
+	case dialog.ShareConfirmedMsg:
+		if msg.Confirmed {
+			return a, a.shareSession()
+		}
 	case app.SetEditorContentMsg:
 		// Set the editor content without sending
 		a.editor.SetValueWithAttachments(msg.Text)
-		updated, cmd := a.editor.Focus()
-		a.editor = updated.(chat.EditorComponent)
-		cmds = append(cmds, cmd)
+		cmds = append(cmds, a.setFocus(focusEditor))
+	case dialog.FenceLanguageSelectedMsg:
+		a.editor.WrapInFence(msg.Language)
+		cmds = append(cmds, a.setFocus(focusEditor))
 	case dialog.CompletionDialogCloseMsg:
 		a.showCompletionDialog = false
 	case opencode.EventListResponseEventInstallationUpdated:
+		if a.app.Config.Tui.UpdateRestart == opencode.ConfigTuiUpdateRestartPrompt && a.modal == nil {
+			a.openModal(dialog.NewRestartDialog(msg.Properties.Version))
+			return a, nil
+		}
 		return a, toast.NewSuccessToast(
 			"autoprovisioner updated to "+msg.Properties.Version+", restart to apply.",
 			toast.WithTitle("New version installed"),
@@ -354,11 +555,9 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, toast.NewSuccessToast("Session deleted successfully")
 	case opencode.EventListResponseEventSessionUpdated:
-		if msg.Properties.Info.ID == a.app.Session.ID {
-			a.app.Session = &msg.Properties.Info
-		}
+		a.app.UpdateSession(msg.Properties.Info)
 	case opencode.EventListResponseEventMessagePartUpdated:
-		slog.Info("message part updated", "message", msg.Properties.Part.MessageID, "part", msg.Properties.Part.ID)
+		slog.Info("message part updated", util.LogEventKey, string(msg.Type), "message", msg.Properties.Part.MessageID, "part", msg.Properties.Part.ID)
 
 		if msg.Properties.Part.SessionID == a.app.Session.ID {
 			messageIndex := slices.IndexFunc(a.app.Messages, func(m app.Message) bool {
@@ -467,6 +666,9 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 	case opencode.EventListResponseEventMessageUpdated:
+		if assistant, ok := msg.Properties.Info.AsUnion().(opencode.AssistantMessage); ok {
+			a.app.SetSessionBusy(assistant.SessionID, assistant.Time.Completed == 0)
+		}
 		if msg.Properties.Info.SessionID == a.app.Session.ID {
 			matchIndex := slices.IndexFunc(a.app.Messages, func(m app.Message) bool {
 				switch casted := m.Info.(type) {
@@ -478,6 +680,10 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return false
 			})
 
+			if user, ok := msg.Properties.Info.AsUnion().(opencode.UserMessage); ok {
+				a.app.AcknowledgeSend(user.ID)
+			}
+
 			if matchIndex > -1 {
 				match := a.app.Messages[matchIndex]
 				a.app.Messages[matchIndex] = app.Message{
@@ -492,22 +698,110 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					Parts: []opencode.PartUnion{},
 				})
 			}
+
+			// replay the next imported message once the previous one is answered
+			if assistant, ok := msg.Properties.Info.AsUnion().(opencode.AssistantMessage); ok &&
+				assistant.Time.Completed > 0 && len(a.importQueue) > 0 && !a.app.IsBusy() &&
+				assistant.ID != a.importAnswered {
+				a.importAnswered = assistant.ID
+				next := a.importQueue[0]
+				a.importQueue = a.importQueue[1:]
+				cmds = append(cmds, util.CmdHandler(app.SendPrompt{Text: next}))
+			}
+		}
+	case opencode.EventListResponseEventPermissionUpdated:
+		if a.app.PermissionGranted(msg.Properties.SessionID, msg.Properties.Title) {
+			return a, a.respondPermission(
+				msg.Properties,
+				opencode.SessionRespondPermissionParamsResponseOnce,
+			)
 		}
+		a.permissions = append(a.permissions, msg.Properties)
+		if len(a.permissions) == 1 {
+			cmd := a.showPermission()
+			return a, cmd
+		}
+		// refresh the open dialog so it counts the queued request
+		for _, m := range append([]layout.Modal{a.modal}, a.modals...) {
+			if permissionDialog, ok := m.(dialog.PermissionDialog); ok {
+				permissionDialog.SetPending(len(a.permissions) - 1)
+			}
+		}
+	case dialog.PermissionRespondedMsg:
+		if len(a.permissions) > 0 {
+			a.permissions = a.permissions[1:]
+		}
+		response := msg.Response
+		if response == opencode.SessionRespondPermissionParamsResponseAlways {
+			// grants are kept by the TUI, keyed by title, so the server only
+			// approves this request and revoking a grant takes effect
+			response = opencode.SessionRespondPermissionParamsResponseOnce
+			a.app.GrantPermission(msg.Permission.SessionID, msg.Permission.Title)
+			// answer queued requests the grant now covers
+			a.permissions = slices.DeleteFunc(
+				a.permissions,
+				func(p opencode.EventListResponseEventPermissionUpdatedProperties) bool {
+					if !a.app.PermissionGranted(p.SessionID, p.Title) {
+						return false
+					}
+					cmds = append(cmds, a.respondPermission(
+						p,
+						opencode.SessionRespondPermissionParamsResponseOnce,
+					))
+					return true
+				},
+			)
+		}
+		cmds = append(cmds, a.respondPermission(msg.Permission, response))
+		if len(a.permissions) > 0 {
+			cmds = append(cmds, a.showPermission())
+		}
+		return a, tea.Batch(cmds...)
+	case opencode.EventListResponseEventSessionIdle:
+		a.app.SetSessionBusy(msg.Properties.SessionID, false)
 	case opencode.EventListResponseEventSessionError:
+		if msg.Properties.SessionID != "" {
+			a.app.SetSessionBusy(msg.Properties.SessionID, false)
+		}
 		switch err := msg.Properties.Error.AsUnion().(type) {
 		case nil:
 		case opencode.ProviderAuthError:
-			slog.Error("Failed to authenticate with provider", "error", err.Data.Message)
-			return a, toast.NewErrorToast("Provider error: " + err.Data.Message)
+			slog.Error("Failed to authenticate with provider", "provider", err.Data.ProviderID, "error", err.Data.Message)
+			return a, toast.NewErrorToast(
+				err.Data.Message+"\n"+a.app.AuthHint(err.Data.ProviderID),
+				toast.WithTitle("Provider error"),
+				toast.WithDuration(10*time.Second),
+			)
 		case opencode.UnknownError:
 			slog.Error("Server error", "name", err.Name, "message", err.Data.Message)
 			return a, toast.NewErrorToast(err.Data.Message, toast.WithTitle(string(err.Name)))
 		}
+	case opencode.EventListResponseEventFileEdited:
+		// edits don't carry a session, attribute them to the active one
+		if a.app.Session.ID != "" {
+			a.app.RecordEdit(a.app.Session.ID, util.Relative(msg.Properties.File))
+		}
 	case opencode.EventListResponseEventFileWatcherUpdated:
-		if a.fileViewer.HasFile() {
-			if a.fileViewer.Filename() == msg.Properties.File {
-				return a.openFile(msg.Properties.File)
+		if a.fileViewer.Filename() == msg.Properties.File {
+			return a.openFile(msg.Properties.File)
+		}
+		// refresh background tabs without switching to them
+		if slices.Contains(a.fileViewer.Filenames(), msg.Properties.File) {
+			response, err := a.app.Client.File.Read(
+				context.Background(),
+				opencode.FileReadParams{
+					Path: opencode.F(msg.Properties.File),
+				},
+			)
+			if err != nil {
+				slog.Error("Failed to read file", "error", err)
+				return a, nil
 			}
+			a.fileViewer = a.fileViewer.UpdateFile(
+				msg.Properties.File,
+				response.Content,
+				response.Type == opencode.FileReadResponseTypePatch,
+			)
 		}
 	case tea.WindowSizeMsg:
 		msg.Height -= 2 // Make space for the status bar
@@ -530,10 +824,20 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		a.app.Session = msg
 		a.app.Messages = messages
+		a.importQueue = nil
 		return a, util.CmdHandler(app.SessionLoadedMsg{})
 	case app.SessionCreatedMsg:
 		a.app.Session = msg.Session
-		return a, util.CmdHandler(app.SessionLoadedMsg{})
+		if a.app.ObserveServerVersion(msg.Session.Version) &&
+			a.app.Config.Tui.VersionMismatch == opencode.ConfigTuiVersionMismatchToast {
+			cmds = append(cmds, a.versionMismatchToast())
+		}
+		cmds = append(cmds, util.CmdHandler(app.SessionLoadedMsg{}))
+		return a, tea.Batch(cmds...)
+	case app.SessionLoadedMsg:
+		if !a.app.State.NoAutoFocus {
+			cmds = append(cmds, a.setFocus(focusEditor))
+		}
 	case app.ModelSelectedMsg:
 		a.app.Provider = &msg.Provider
 		a.app.Model = &msg.Model
@@ -543,6 +847,13 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		a.app.State.UpdateModelUsage(msg.Provider.ID, msg.Model.ID)
 		cmds = append(cmds, a.app.SaveState())
+	case dialog.CompareMessagesMsg:
+		return a.compareMessages(msg.Before, msg.After)
+	case dialog.ProviderSelectedMsg:
+		a.app.State.ModeProvider[a.app.Mode.Name] = msg.Provider.ID
+		modelDialog := dialog.NewProviderModelDialog(a.app, msg.Provider)
+		a.openModal(modelDialog)
+		cmds = append(cmds, a.app.SaveState())
 	case dialog.ThemeSelectedMsg:
 		a.app.State.Theme = msg.ThemeName
 		cmds = append(cmds, a.app.SaveState())
@@ -562,7 +873,18 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reset exit key state after timeout
 		a.exitKeyState = ExitKeyIdle
 		a.editor.SetExitKeyInDebounce(false)
+	case dialog.OpenEditedConfirmedMsg:
+		if !msg.Confirmed {
+			return a, nil
+		}
+		return a.openFiles(msg.Files)
 	case dialog.FindSelectedMsg:
+		switch msg.Action {
+		case dialog.FindInsert:
+			return a.insertFile(msg.FilePath)
+		case dialog.FindImport:
+			return a.importConversation(msg.FilePath)
+		}
 		return a.openFile(msg.FilePath)
 	}
 
@@ -594,6 +916,10 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	a.fileViewer = fv
 	cmds = append(cmds, cmd)
 
+	if _, ok := msg.(tea.WindowSizeMsg); ok {
+		cmds = append(cmds, a.layoutPanes())
+	}
+
 	return a, tea.Batch(cmds...)
 }
 
@@ -609,9 +935,10 @@ func (a appModel) View() string {
 	} else {
 		mainLayout = a.chat()
 	}
+	padding := a.app.State.ContentPadding()
 	mainLayout = styles.NewStyle().
 		Background(t.Background()).
-		Padding(0, 2).
+		Padding(padding.Vertical, padding.Horizontal).
 		Render(mainLayout)
 	mainLayout = lipgloss.PlaceHorizontal(
 		a.width,
@@ -626,6 +953,7 @@ func (a appModel) View() string {
 	if a.modal != nil {
 		mainLayout = a.modal.Render(mainLayout)
 	}
+	mainLayout = a.inspector.RenderOverlay(mainLayout)
 	mainLayout = a.toastManager.RenderOverlay(mainLayout)
 
 	if theme.CurrentThemeUsesAnsiColors() {
@@ -634,6 +962,18 @@ func (a appModel) View() string {
 	return mainLayout + "\n" + a.status.View()
 }
 
+// setTerminalBackground applies the terminal background and regenerates the
+// system theme from it
+func setTerminalBackground(background color.Color, isDark bool) tea.Cmd {
+	styles.SetTerminalBackground(background, isDark)
+	return func() tea.Msg {
+		theme.UpdateSystemTheme(background, isDark)
+		return dialog.ThemeSelectedMsg{
+			ThemeName: theme.CurrentThemeName(),
+		}
+	}
+}
+
 func (a appModel) openFile(filepath string) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	response, err := a.app.Client.File.Read(
@@ -649,34 +989,399 @@ func (a appModel) openFile(filepath string) (tea.Model, tea.Cmd) {
 	a.fileViewer, cmd = a.fileViewer.SetFile(
 		filepath,
 		response.Content,
-		response.Type == "patch",
+		response.Type == opencode.FileReadResponseTypePatch,
+	)
+	return a, tea.Batch(cmd, a.layoutPanes())
+}
+
+// confirmShare reports whether sharing asks for consent first, defaulting to true
+func confirmShare(cfg opencode.ConfigTui) bool {
+	return cfg.JSON.ConfirmShare.IsNull() || cfg.ConfirmShare
+}
+
+// shareSession shares the current session and copies its URL to the clipboard
+func (a appModel) shareSession() tea.Cmd {
+	response, err := a.app.Client.Session.Share(context.Background(), a.app.Session.ID)
+	if err != nil {
+		slog.Error("Failed to share session", "error", err)
+		return toast.NewErrorToast("Failed to share session")
+	}
+	return tea.Batch(
+		app.SetClipboard(response.Share.URL),
+		toast.NewSuccessToast("Share URL copied to clipboard!"),
 	)
-	return a, cmd
+}
+
+// openToolOutput shows the full output of the tool call in view in the file
+// viewer, without the truncation applied in the messages view
+func (a appModel) openToolOutput() (tea.Model, tea.Cmd) {
+	part, ok := a.messages.ToolInView()
+	if !ok {
+		return a, toast.NewInfoToast("No tool output to show")
+	}
+	var cmd tea.Cmd
+	a.fileViewer, cmd = a.fileViewer.SetFile(
+		part.Tool+" output",
+		ansi.Strip(chat.ToolOutput(part)),
+		false,
+	)
+	return a, tea.Batch(cmd, a.layoutPanes())
+}
+
+// compareMessages opens a diff of the text of two messages in the file viewer
+func (a appModel) compareMessages(before, after int) (tea.Model, tea.Cmd) {
+	if before < 0 || after >= len(a.app.Messages) {
+		return a, nil
+	}
+	responses := 0
+	var beforeNumber, afterNumber int
+	for i := 0; i <= after; i++ {
+		message := a.app.Messages[i]
+		if _, ok := message.Info.(opencode.AssistantMessage); !ok || strings.TrimSpace(message.Text()) == "" {
+			continue
+		}
+		responses++
+		switch i {
+		case before:
+			beforeNumber = responses
+		case after:
+			afterNumber = responses
+		}
+	}
+
+	name := fmt.Sprintf("response %d vs %d.md", beforeNumber, afterNumber)
+	patch := diff.UnifiedText(name, a.app.Messages[before].Text(), a.app.Messages[after].Text())
+	if patch == "" {
+		return a, toast.NewInfoToast("The responses are identical")
+	}
+	var cmd tea.Cmd
+	a.fileViewer, cmd = a.fileViewer.SetFile(name, patch, true)
+	return a, tea.Batch(cmd, a.layoutPanes())
+}
+
+// openFiles opens each file in its own tab, leaving the last one active
+// openFiles reads the files in the background, opening them as tabs once the
+// filesReadMsg arrives
+func (a appModel) openFiles(filepaths []string) (tea.Model, tea.Cmd) {
+	return a, func() tea.Msg {
+		msg := filesReadMsg{total: len(filepaths)}
+		for _, filepath := range filepaths {
+			response, err := a.app.Client.File.Read(
+				context.Background(),
+				opencode.FileReadParams{
+					Path: opencode.F(filepath),
+				},
+			)
+			if err != nil {
+				slog.Error("Failed to read file", "file", filepath, "error", err)
+				msg.failed++
+				continue
+			}
+			msg.files = append(msg.files, readFile{
+				path:    filepath,
+				content: response.Content,
+				patch:   response.Type == opencode.FileReadResponseTypePatch,
+			})
+		}
+		return msg
+	}
+}
+
+func (a appModel) insertFile(filepath string) (tea.Model, tea.Cmd) {
+	response, err := a.app.Client.File.Read(
+		context.Background(),
+		opencode.FileReadParams{
+			Path: opencode.F(filepath),
+		},
+	)
+	if err != nil {
+		slog.Error("Failed to read file", "error", err)
+		return a, toast.NewErrorToast("Failed to read file")
+	}
+
+	content := response.Content
+	// modified files come back as a patch against HEAD, insert the current version
+	if response.Type == opencode.FileReadResponseTypePatch {
+		content, err = diff.NewContent(content)
+		if err != nil {
+			slog.Error("Failed to parse file patch", "error", err)
+			return a, toast.NewErrorToast("Failed to read file")
+		}
+	}
+	if util.IsBinary(content) {
+		return a, toast.NewErrorToast("Binary files can't be inserted inline")
+	}
+	if len(content) > maxInlineFileSize {
+		return a, toast.NewErrorToast(
+			fmt.Sprintf("File is too large to insert inline (max %dKB)", maxInlineFileSize/1024),
+		)
+	}
+
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	a.editor.InsertText(fmt.Sprintf(
+		"%s\n%s%s\n%s\n%s\n",
+		filepath,
+		fence,
+		util.Extension(filepath),
+		content,
+		fence,
+	))
+	return a, a.setFocus(focusEditor)
+}
+
+// importConversation reads an exported conversation and replays its user
+// messages into a new session, sending each once the previous is answered
+func (a appModel) importConversation(filepath string) (tea.Model, tea.Cmd) {
+	response, err := a.app.Client.File.Read(
+		context.Background(),
+		opencode.FileReadParams{
+			Path: opencode.F(filepath),
+		},
+	)
+	if err != nil {
+		slog.Error("Failed to read file", "error", err)
+		return a, toast.NewErrorToast("Failed to read file")
+	}
+
+	content := response.Content
+	if response.Type == opencode.FileReadResponseTypePatch {
+		content, err = diff.NewContent(content)
+		if err != nil {
+			slog.Error("Failed to parse file patch", "error", err)
+			return a, toast.NewErrorToast("Failed to read file")
+		}
+	}
+
+	prompts := conversationUserPrompts(parseConversationMarkdown(content))
+	if len(prompts) == 0 {
+		return a, toast.NewInfoToast("No user messages found to import.")
+	}
+
+	a.app.Session = &opencode.Session{}
+	a.app.Messages = []app.Message{}
+	a.importQueue = prompts[1:]
+	return a, tea.Sequence(
+		util.CmdHandler(app.SessionClearedMsg{}),
+		util.CmdHandler(app.SendPrompt{Text: prompts[0]}),
+		toast.NewInfoToast(fmt.Sprintf("Replaying %d imported messages", len(prompts))),
+	)
+}
+
+// openModal shows m on top of the dialog stack, keeping any open dialog
+// beneath it so closing m returns to it
+func (a *appModel) openModal(m layout.Modal) {
+	if a.modal != nil {
+		a.modals = append(a.modals, a.modal)
+	}
+	a.modal = m
+}
+
+// closeModal closes the topmost dialog and reveals the one beneath it,
+// refocusing the editor once the stack is empty
+func (a *appModel) closeModal() tea.Cmd {
+	if a.modal == nil {
+		return nil
+	}
+	cmd := a.modal.Close()
+	a.modal = nil
+	if n := len(a.modals); n > 0 {
+		a.modal = a.modals[n-1]
+		a.modals = a.modals[:n-1]
+	} else if a.focus == focusEditor {
+		a.editor.Focus()
+	}
+	return cmd
+}
+
+// closeAllModals closes every open dialog, topmost first
+func (a *appModel) closeAllModals() tea.Cmd {
+	var cmds []tea.Cmd
+	for a.modal != nil {
+		cmds = append(cmds, a.closeModal())
+	}
+	return tea.Batch(cmds...)
+}
+
+// cycleModals moves the topmost dialog to the bottom of the stack and shows
+// the one that was beneath it
+func (a *appModel) cycleModals() {
+	if a.modal == nil || len(a.modals) == 0 {
+		return
+	}
+	n := len(a.modals)
+	top := a.modals[n-1]
+	a.modals = append([]layout.Modal{a.modal}, a.modals[:n-1]...)
+	a.modal = top
+}
+
+// abortSessions aborts the sessions in parallel in the background, reporting
+// the outcome in a sessionsAbortedMsg
+func (a *appModel) abortSessions(sessionIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		errs := make([]error, len(sessionIDs))
+		var wg sync.WaitGroup
+		for i, sessionID := range sessionIDs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = a.app.Abort(context.Background(), sessionID)
+			}()
+		}
+		wg.Wait()
+
+		var msg sessionsAbortedMsg
+		for i, err := range errs {
+			if err != nil {
+				slog.Error("Failed to abort session", "session", sessionIDs[i], "error", err)
+				msg.failed++
+				continue
+			}
+			msg.aborted = append(msg.aborted, sessionIDs[i])
+		}
+		return msg
+	}
+}
+
+// showPermission opens the dialog for the first queued permission request,
+// closing any other dialogs since the session is blocked until it's answered
+func (a *appModel) showPermission() tea.Cmd {
+	cmd := a.closeAllModals()
+	a.editor.Blur()
+	a.modal = dialog.NewPermissionDialog(a.permissions[0], len(a.permissions)-1)
+	return cmd
+}
+
+// respondPermission posts the response to a permission request
+func (a appModel) respondPermission(
+	permission opencode.EventListResponseEventPermissionUpdatedProperties,
+	response opencode.SessionRespondPermissionParamsResponse,
+) tea.Cmd {
+	return func() tea.Msg {
+		_, err := a.app.Client.Session.RespondPermission(
+			context.Background(),
+			permission.SessionID,
+			permission.ID,
+			opencode.SessionRespondPermissionParams{
+				Response: opencode.F(response),
+			},
+		)
+		if err != nil {
+			slog.Error("Failed to respond to permission request", "error", err)
+			return toast.NewErrorToast("Failed to respond to permission request")()
+		}
+		return nil
+	}
+}
+
+// openFileCompletion types the @ trigger into the editor and opens the
+// completion dialog for files, symbols and sessions
+func (a *appModel) openFileCompletion(msg tea.KeyPressMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	a.showCompletionDialog = true
+
+	updated, cmd := a.editor.Update(msg)
+	a.editor = updated.(chat.EditorComponent)
+	cmds = append(cmds, cmd)
+
+	// Set file, symbol and session providers for @ completion
+	a.completions = dialog.NewCompletionDialogComponent("@", a.fileProvider, a.symbolsProvider, a.sessionsProvider)
+	updated, cmd = a.completions.Update(msg)
+	a.completions = updated.(dialog.CompletionDialog)
+	cmds = append(cmds, cmd)
+
+	return tea.Sequence(cmds...)
+}
+
+// closeFileCompletion closes the completion dialog from the file completion
+// command, removing the @ trigger if the command typed it
+func (a *appModel) closeFileCompletion() {
+	a.showCompletionDialog = false
+	if a.fileCompletionTyped {
+		a.editor.RemoveFileTrigger()
+		a.fileCompletionTyped = false
+	}
+}
+
+// setFocus moves keyboard focus to the given pane and updates the focus
+// indicators of the others
+func (a *appModel) setFocus(area focusArea) tea.Cmd {
+	regained := area == focusMessages && a.focus != focusMessages
+	a.focus = area
+	a.messages.SetFocused(area == focusMessages)
+	a.fileViewer.SetFocused(area == focusFileViewer)
+	if area == focusEditor {
+		updated, cmd := a.editor.Focus()
+		a.editor = updated.(chat.EditorComponent)
+		return cmd
+	}
+	a.editor.Blur()
+	if regained && a.app.Config.Tui.ScrollOnFocus {
+		updated, cmd := a.messages.GotoBottom()
+		a.messages = updated.(chat.MessagesComponent)
+		return cmd
+	}
+	return nil
+}
+
+// handleFocusedKey scrolls the focused messages or file viewer, reporting
+// whether the key was used
+func (a *appModel) handleFocusedKey(keyString string) (tea.Cmd, bool) {
+	if keyString == "esc" {
+		return a.setFocus(focusEditor), true
+	}
+	if a.focus == focusFileViewer {
+		switch keyString {
+		case "up", "k":
+			a.fileViewer.ScrollUp(1)
+		case "down", "j":
+			a.fileViewer.ScrollDown(1)
+		case "pgup", "b":
+			a.fileViewer, _ = a.fileViewer.PageUp()
+		case "pgdown", "space":
+			a.fileViewer, _ = a.fileViewer.PageDown()
+		case "home", "g":
+			a.fileViewer.ScrollToTop()
+		case "end", "G":
+			a.fileViewer.ScrollToBottom()
+		default:
+			return nil, false
+		}
+		return nil, true
+	}
+	switch keyString {
+	case "up", "k":
+		a.messages.ScrollUp(1)
+	case "down", "j":
+		a.messages.ScrollDown(1)
+	case "pgup", "b":
+		a.messages.PageUp()
+	case "pgdown", "space":
+		a.messages.PageDown()
+	case "home", "g":
+		a.messages.GotoTop()
+	case "end", "G":
+		a.messages.GotoBottom()
+	default:
+		return nil, false
+	}
+	return nil, true
 }
 
 func (a appModel) home() string {
 	measure := util.Measure("home.View")
 	defer measure()
 	t := theme.CurrentTheme()
-	effectiveWidth := a.width - 4
+	effectiveWidth := a.width - 2*a.app.State.ContentPadding().Horizontal
 	baseStyle := styles.NewStyle().Background(t.Background()).Bold(true)
-	base := baseStyle.Render
 
-	logo := lipgloss.JoinVertical(
-		lipgloss.Center,
-		base("AutoProvisioner"),
-	)
-	// cwd := app.Info.Path.Cwd
-	// config := app.Info.Path.Config
-
-	versionStyle := styles.NewStyle().
-		Foreground(t.TextMuted()).
-		Background(t.Background()).
-		Width(lipgloss.Width(logo)).
-		Align(lipgloss.Right)
-	version := versionStyle.Render(a.app.Version)
-
-	logoAndVersion := strings.Join([]string{logo, version}, "\n")
+	logoText := defaultLogo
+	if a.app.Config.Tui.Logo != "" {
+		logoText = strings.TrimRight(a.app.Config.Tui.Logo, "\n")
+	}
+	logoAndVersion := renderLogo(logoText, a.app.Version, a.app.Config.Tui.Tagline, effectiveWidth)
 	logoAndVersion = lipgloss.PlaceHorizontal(
 		effectiveWidth,
 		lipgloss.Center,
@@ -728,13 +1433,16 @@ func (a appModel) home() string {
 		styles.WhitespaceStyle(t.Background()),
 	)
 
-	editorX := (effectiveWidth - editorWidth) / 2
-	editorY := (a.height / 2) + (mainHeight / 2) - 2
+	placement := layout.CenteredEditorPlacement(
+		layout.Dimensions{Width: effectiveWidth, Height: a.height},
+		editorWidth,
+		mainHeight,
+	)
 
 	if editorLines > 1 {
 		mainLayout = layout.PlaceOverlay(
-			editorX,
-			editorY,
+			placement.X,
+			placement.Y,
 			a.editor.Content(),
 			mainLayout,
 		)
@@ -743,11 +1451,10 @@ func (a appModel) home() string {
 	if a.showCompletionDialog {
 		a.completions.SetWidth(editorWidth)
 		overlay := a.completions.View()
-		overlayHeight := lipgloss.Height(overlay)
 
 		mainLayout = layout.PlaceOverlay(
-			editorX,
-			editorY-overlayHeight+1,
+			placement.X,
+			placement.Above(lipgloss.Height(overlay)),
 			overlay,
 			mainLayout,
 		)
@@ -756,10 +1463,77 @@ func (a appModel) home() string {
 	return mainLayout
 }
 
+// versionMismatchToast warns that the server runs a different version than
+// the TUI, whose events and responses may no longer match
+func (a appModel) versionMismatchToast() tea.Cmd {
+	return toast.NewWarningToast(
+		fmt.Sprintf("The server runs %s but the TUI is %s, restart to avoid errors", a.app.Info.Version, a.app.Version),
+		toast.WithTitle("Version mismatch"),
+		toast.WithDuration(10*time.Second),
+	)
+}
+
+// fileViewerWidth is the width of the file viewer beside the messages, zero
+// when no file is open
+func (a appModel) fileViewerWidth() int {
+	if !a.fileViewer.HasFile() {
+		return 0
+	}
+	effectiveWidth := a.width - 2*a.app.State.ContentPadding().Horizontal
+	return int(float64(effectiveWidth) * a.app.State.FileViewerRatio())
+}
+
+// layoutPanes sizes the messages and the file viewer to share the content
+// width, and must follow anything that opens or closes a file or resizes
+func (a *appModel) layoutPanes() tea.Cmd {
+	var cmds []tea.Cmd
+	fileViewerWidth := a.fileViewerWidth()
+	if fileViewerWidth > 0 {
+		var cmd tea.Cmd
+		a.fileViewer, cmd = a.fileViewer.SetSize(fileViewerWidth, a.height)
+		cmds = append(cmds, cmd)
+	}
+	effectiveWidth := a.width - 2*a.app.State.ContentPadding().Horizontal
+	updated, cmd := a.messages.SetWidth(effectiveWidth - fileViewerWidth)
+	a.messages = updated.(chat.MessagesComponent)
+	cmds = append(cmds, cmd)
+	a.editor.SetWidth(effectiveWidth - fileViewerWidth)
+	return tea.Batch(cmds...)
+}
+
+// latestChildSession returns the sub-agent session of the most recent task
+// tool call in the conversation
+func (a appModel) latestChildSession() string {
+	for i := len(a.app.Messages) - 1; i >= 0; i-- {
+		parts := a.app.Messages[i].Parts
+		for j := len(parts) - 1; j >= 0; j-- {
+			if toolCall, ok := parts[j].(opencode.ToolPart); ok {
+				if id := chat.ChildSessionID(toolCall); id != "" {
+					return id
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// openSession switches to the session with the given ID
+func (a appModel) openSession(sessionID string) tea.Cmd {
+	return func() tea.Msg {
+		session, err := a.app.FindSession(context.Background(), sessionID)
+		if err != nil {
+			slog.Error("Failed to find session", "error", err)
+			return toast.NewErrorToast("Failed to open session")()
+		}
+		return app.SessionSelectedMsg(session)
+	}
+}
+
 func (a appModel) chat() string {
 	measure := util.Measure("chat.View")
 	defer measure()
-	effectiveWidth := a.width - 4
+	fileViewerWidth := a.fileViewerWidth()
+	effectiveWidth := a.width - 2*a.app.State.ContentPadding().Horizontal - fileViewerWidth
 	t := theme.CurrentTheme()
 	editorView := a.editor.View()
 	lines := a.editor.Lines()
@@ -775,13 +1549,15 @@ func (a appModel) chat() string {
 	)
 
 	mainLayout := messagesView + "\n" + editorView
-	editorX := (effectiveWidth - editorWidth) / 2
+	placement := layout.BottomEditorPlacement(
+		layout.Dimensions{Width: effectiveWidth, Height: a.height},
+		layout.Dimensions{Width: editorWidth, Height: editorHeight},
+	)
 
 	if lines > 1 {
-		editorY := a.height - editorHeight
 		mainLayout = layout.PlaceOverlay(
-			editorX,
-			editorY,
+			placement.X,
+			placement.Y,
 			a.editor.Content(),
 			mainLayout,
 		)
@@ -790,17 +1566,22 @@ func (a appModel) chat() string {
 	if a.showCompletionDialog {
 		a.completions.SetWidth(editorWidth)
 		overlay := a.completions.View()
-		overlayHeight := lipgloss.Height(overlay)
-		editorY := a.height - editorHeight + 1
 
 		mainLayout = layout.PlaceOverlay(
-			editorX,
-			editorY-overlayHeight,
+			placement.X,
+			placement.Above(lipgloss.Height(overlay)),
 			overlay,
 			mainLayout,
 		)
 	}
 
+	if fileViewerWidth > 0 {
+		fileViewerView := a.fileViewer.View()
+		if a.messagesRight {
+			return lipgloss.JoinHorizontal(lipgloss.Top, fileViewerView, mainLayout)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, mainLayout, fileViewerView)
+	}
 	return mainLayout
 }
 
@@ -812,7 +1593,7 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 	switch command.Name {
 	case commands.AppHelpCommand:
 		helpDialog := dialog.NewHelpDialog(a.app)
-		a.modal = helpDialog
+		a.openModal(helpDialog)
 	case commands.SwitchModeCommand:
 		updated, cmd := a.app.SwitchMode()
 		a.app = updated
@@ -874,22 +1655,73 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		}
 		a.app.Session = &opencode.Session{}
 		a.app.Messages = []app.Message{}
+		a.importQueue = nil
 		cmds = append(cmds, util.CmdHandler(app.SessionClearedMsg{}))
+	case commands.PromptNewSessionCommand:
+		if strings.TrimSpace(a.editor.Value()) == "" {
+			return a, toast.NewInfoToast("Nothing in the editor to move")
+		}
+		// the editor keeps its text and attachments across the switch
+		session, err := a.app.CreateSession(context.Background())
+		if err != nil {
+			slog.Error("Failed to create session", "error", err)
+			return a, toast.NewErrorToast("Failed to create session")
+		}
+		a.app.Messages = []app.Message{}
+		a.importQueue = nil
+		cmds = append(cmds, util.CmdHandler(app.SessionCreatedMsg{Session: session}))
+	case commands.SessionImportCommand:
+		a.editor.Blur()
+		importDialog := dialog.NewImportConversationDialog(a.fileProvider)
+		cmds = append(cmds, importDialog.Init())
+		a.openModal(importDialog)
+	case commands.PermissionListCommand:
+		if a.app.Session.ID == "" {
+			return a, toast.NewInfoToast("No active session")
+		}
+		a.openModal(dialog.NewPermissionGrantsDialog(a.app))
 	case commands.SessionListCommand:
 		sessionDialog := dialog.NewSessionDialog(a.app)
-		a.modal = sessionDialog
+		a.openModal(sessionDialog)
 	case commands.SessionShareCommand:
 		if a.app.Session.ID == "" {
 			return a, nil
 		}
-		response, err := a.app.Client.Session.Share(context.Background(), a.app.Session.ID)
-		if err != nil {
-			slog.Error("Failed to share session", "error", err)
-			return a, toast.NewErrorToast("Failed to share session")
+		if confirmShare(a.app.Config.Tui) && a.app.Session.Share.URL == "" {
+			a.openModal(dialog.NewShareConfirmDialog())
+			return a, nil
+		}
+		cmds = append(cmds, a.shareSession())
+	case commands.SessionCopyIDCommand:
+		if a.app.Session.ID == "" {
+			return a, toast.NewInfoToast("No session to copy the ID of")
 		}
-		shareUrl := response.Share.URL
-		cmds = append(cmds, app.SetClipboard(shareUrl))
-		cmds = append(cmds, toast.NewSuccessToast("Share URL copied to clipboard!"))
+		cmds = append(cmds, app.SetClipboard(a.app.Session.ID))
+		cmds = append(cmds, toast.NewSuccessToast(
+			fmt.Sprintf("Session ID %s copied to clipboard", a.app.Session.ID),
+		))
+	case commands.SessionChildCommand:
+		childID := a.latestChildSession()
+		if childID == "" {
+			return a, toast.NewInfoToast("No sub-agent session in this conversation")
+		}
+		cmds = append(cmds, a.openSession(childID))
+	case commands.SessionParentCommand:
+		if a.app.Session.ParentID == "" {
+			return a, toast.NewInfoToast("This session has no parent")
+		}
+		cmds = append(cmds, a.openSession(a.app.Session.ParentID))
+	case commands.SessionNoteCommand:
+		if a.app.Session.ID == "" {
+			return a, toast.NewInfoToast("No active session to add a note to")
+		}
+		if a.app.IsBusy() {
+			return a, toast.NewInfoToast("Wait for the response to finish before adding a note")
+		}
+		cmds = append(cmds, a.app.AddNote(context.Background(), strings.TrimSpace(a.editor.Value())))
+		updated, cmd := a.editor.Clear()
+		a.editor = updated.(chat.EditorComponent)
+		cmds = append(cmds, cmd)
 	case commands.SessionUnshareCommand:
 		if a.app.Session.ID == "" {
 			return a, nil
@@ -905,8 +1737,47 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		if a.app.Session.ID == "" {
 			return a, nil
 		}
+		a.importQueue = nil
 		a.app.Cancel(context.Background(), a.app.Session.ID)
 		return a, nil
+	case commands.SessionAbortAllCommand:
+		busy := a.app.BusySessions()
+		if len(busy) == 0 {
+			return a, toast.NewInfoToast("No sessions are busy")
+		}
+		a.importQueue = nil
+		return a, a.abortSessions(busy)
+	case commands.PromptCancelCommand:
+		prompt := a.app.CancelPendingSend()
+		if prompt == nil {
+			return a, toast.NewInfoToast("No pending message to cancel")
+		}
+		a.editor.SetPrompt(*prompt)
+		cmds = append(cmds, a.setFocus(focusEditor))
+		cmds = append(cmds, util.CmdHandler(app.PromptCanceledMsg{}))
+	case commands.SessionWebCommand:
+		// prefer the shared session page, falling back to the server dashboard
+		url := a.app.Session.Share.URL
+		if url == "" {
+			url = a.app.ServerURL
+		}
+		if url == "" {
+			return a, toast.NewErrorToast("No server URL available")
+		}
+		if err := util.OpenURL(url); err != nil {
+			slog.Error("Failed to open browser", "error", err)
+			return a, toast.NewErrorToast("Failed to open browser")
+		}
+		return a, toast.NewInfoToast("Opened " + url)
+	case commands.SessionCurlCommand:
+		curl, ok := a.app.LastRequestAsCurl()
+		if !ok {
+			return a, toast.NewInfoToast("No request has been sent yet")
+		}
+		return a, tea.Sequence(
+			app.SetClipboard(curl),
+			toast.NewSuccessToast("Copied last request as curl"),
+		)
 	case commands.SessionCompactCommand:
 		if a.app.Session.ID == "" {
 			return a, nil
@@ -925,7 +1796,7 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		}
 
 		// Format to Markdown
-		markdownContent := formatConversationToMarkdown(messages)
+		markdownContent := util.Redact(formatConversationToMarkdown(messages))
 
 		// Check if EDITOR is set
 		editor := os.Getenv("EDITOR")
@@ -964,6 +1835,22 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 			return nil
 		})
 		cmds = append(cmds, cmd)
+	case commands.SessionSaveCommand:
+		if a.app.Session.ID == "" {
+			return a, toast.NewErrorToast("No active session to save.")
+		}
+		if len(a.app.Messages) == 0 {
+			return a, toast.NewInfoToast("No messages to save.")
+		}
+		path, err := a.saveConversation()
+		if errors.Is(err, errSavePathOutsideProject) {
+			return a, toast.NewErrorToast("The save path must be within the project")
+		}
+		if err != nil {
+			slog.Error("Failed to save conversation", "error", err)
+			return a, toast.NewErrorToast("Failed to save conversation.")
+		}
+		return a, toast.NewSuccessToast("Saved conversation to " + path)
 	case commands.ToolDetailsCommand:
 		message := "Tool details are now visible"
 		if a.messages.ToolDetailsVisible() {
@@ -971,29 +1858,193 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		}
 		cmds = append(cmds, util.CmdHandler(chat.ToggleToolDetailsMsg{}))
 		cmds = append(cmds, toast.NewInfoToast(message))
+	case commands.ToolDetailsExpandCommand:
+		cmds = append(cmds, util.CmdHandler(chat.SetToolDetailsMsg{Visible: true}))
+		cmds = append(cmds, toast.NewInfoToast("Tool details expanded"))
+	case commands.ToolDetailsCollapseCommand:
+		cmds = append(cmds, util.CmdHandler(chat.SetToolDetailsMsg{Visible: false}))
+		cmds = append(cmds, toast.NewInfoToast("Tool details collapsed"))
+	case commands.ToolOutputWrapCommand:
+		message := "Tool output is now truncated"
+		if !a.messages.ToolOutputWrapped() {
+			message = "Tool output is now wrapped"
+		}
+		cmds = append(cmds, util.CmdHandler(chat.ToggleToolOutputWrapMsg{}))
+		cmds = append(cmds, toast.NewInfoToast(message))
+	case commands.ToolOutputLeftCommand, commands.ToolOutputRightCommand:
+		if a.messages.ToolOutputWrapped() {
+			return a, toast.NewInfoToast("Tool output is wrapped, toggle wrap to scroll")
+		}
+		delta := toolOutputScrollStep
+		if command.Name == commands.ToolOutputLeftCommand {
+			delta = -delta
+		}
+		cmds = append(cmds, util.CmdHandler(chat.ScrollToolOutputMsg{Delta: delta}))
 	case commands.ModelListCommand:
 		modelDialog := dialog.NewModelDialog(a.app)
-		a.modal = modelDialog
+		a.openModal(modelDialog)
+	case commands.ProviderListCommand:
+		providerDialog := dialog.NewProviderDialog(a.app)
+		a.openModal(providerDialog)
 	case commands.ThemeListCommand:
-		themeDialog := dialog.NewThemeDialog()
-		a.modal = themeDialog
+		themeDialog := dialog.NewThemeDialog(a.app)
+		a.openModal(themeDialog)
 	// case commands.FileListCommand:
 	// 	a.editor.Blur()
 	// 	findDialog := dialog.NewFindDialog(a.fileProvider)
 	// 	cmds = append(cmds, findDialog.Init())
 	// 	a.modal = findDialog
+	case commands.ThemeReloadCommand:
+		err := a.app.ReloadThemes()
+		cmds = append(cmds, util.CmdHandler(dialog.ThemeSelectedMsg{ThemeName: theme.CurrentThemeName()}))
+		if err != nil {
+			slog.Warn("Failed to reload themes", "error", err)
+			cmds = append(cmds, toast.NewErrorToast("Some themes failed to load, see the log for details"))
+			break
+		}
+		cmds = append(cmds, toast.NewSuccessToast("Reloaded themes"))
+	case commands.FileEditedCommand:
+		a.editor.Blur()
+		a.openModal(dialog.NewEditedFilesDialog(a.app.EditedFiles(a.app.Session.ID)))
+	case commands.FileOpenEditedCommand:
+		edited := a.app.EditedFiles(a.app.Session.ID)
+		if len(edited) == 0 {
+			return a, toast.NewInfoToast("No files edited in this session")
+		}
+		files := make([]string, 0, min(len(edited), maxEditedTabs))
+		for _, file := range edited[:min(len(edited), maxEditedTabs)] {
+			files = append(files, file.Path)
+		}
+		if len(edited) > editedTabsConfirmThreshold {
+			a.editor.Blur()
+			a.openModal(dialog.NewOpenEditedConfirmDialog(files, len(edited)))
+			break
+		}
+		return a.openFiles(files)
+	case commands.LogFilterCommand:
+		a.editor.Blur()
+		a.openModal(dialog.NewLogFilterDialog())
+	case commands.EventInspectorCommand:
+		a.inspector.Toggle()
+	case commands.DebugClearCachesCommand:
+		for _, provider := range []completions.CompletionProvider{
+			a.commandProvider,
+			a.fileProvider,
+			a.symbolsProvider,
+			a.sessionsProvider,
+			a.snippetsProvider,
+		} {
+			if refreshable, ok := provider.(completions.RefreshableProvider); ok {
+				refreshable.Refresh()
+			}
+		}
+		cmds = append(cmds, util.CmdHandler(chat.ClearCacheMsg{}))
+		cmds = append(cmds, toast.NewInfoToast("Caches cleared"))
+	case commands.ThemeBackgroundCommand:
+		isDark := !styles.Terminal.BackgroundIsDark
+		a.app.State.Background = "light"
+		if isDark {
+			a.app.State.Background = "dark"
+		}
+		cmds = append(cmds, setTerminalBackground(styles.DefaultBackground(isDark), isDark))
+		cmds = append(cmds, toast.NewInfoToast("Using a "+a.app.State.Background+" background"))
 	case commands.FileCloseCommand:
 		a.fileViewer, cmd = a.fileViewer.Clear()
-		cmds = append(cmds, cmd)
+		cmds = append(cmds, cmd, a.layoutPanes())
+		if a.focus == focusFileViewer && !a.fileViewer.HasFile() {
+			cmds = append(cmds, a.setFocus(focusEditor))
+		}
+	case commands.FileViewerGrowCommand, commands.FileViewerShrinkCommand:
+		delta := app.SplitRatioStep
+		if command.Name == commands.FileViewerShrinkCommand {
+			delta = -delta
+		}
+		if a.app.State.ResizeSplit(delta) {
+			cmds = append(cmds, a.layoutPanes(), a.app.SaveState())
+		}
 	case commands.FileDiffToggleCommand:
 		a.fileViewer, cmd = a.fileViewer.ToggleDiff()
 		cmds = append(cmds, cmd)
 		a.app.State.SplitDiff = a.fileViewer.DiffStyle() == fileviewer.DiffStyleSplit
+		a.app.State.InlineDiff = a.fileViewer.DiffStyle() == fileviewer.DiffStyleInline
+		a.app.State.GutterDiff = a.fileViewer.DiffStyle() == fileviewer.DiffStyleGutter
 		cmds = append(cmds, a.app.SaveState())
+	case commands.FileNextCommand:
+		a.fileViewer, cmd = a.fileViewer.NextTab()
+		cmds = append(cmds, cmd)
+	case commands.FilePreviousCommand:
+		a.fileViewer, cmd = a.fileViewer.PreviousTab()
+		cmds = append(cmds, cmd)
+	case commands.FileChangeNextCommand:
+		a.fileViewer, cmd = a.fileViewer.NextChange()
+		cmds = append(cmds, cmd)
+	case commands.FileChangePreviousCommand:
+		a.fileViewer, cmd = a.fileViewer.PreviousChange()
+		cmds = append(cmds, cmd)
+	case commands.FileCopyHunkCommand:
+		patch, ok := a.fileViewer.CurrentHunk()
+		if !ok {
+			return a, toast.NewInfoToast("No diff hunk to copy")
+		}
+		return a, tea.Sequence(
+			app.SetClipboard(patch),
+			toast.NewSuccessToast("Copied diff hunk"),
+		)
+	case commands.FileAttachCommand:
+		if !a.fileViewer.HasFile() {
+			return a, toast.NewInfoToast("No file open in the viewer")
+		}
+		if !a.editor.AttachFile(a.fileViewer.Filename()) {
+			return a, toast.NewInfoToast("File is already attached")
+		}
+	case commands.FileGotoEditCommand:
+		line, ok := a.fileViewer.CurrentLine()
+		if !ok {
+			return a, toast.NewInfoToast("Open an edited file as a diff to find its edit")
+		}
+		updated, cmd := a.messages.GotoEdit(a.fileViewer.Filename(), line)
+		a.messages = updated.(chat.MessagesComponent)
+		cmds = append(cmds, cmd)
 	case commands.FileSearchCommand:
 		return a, nil
 	case commands.ProjectInitCommand:
 		cmds = append(cmds, a.app.InitializeProject(context.Background()))
+	case commands.InputClearAttachmentsCommand:
+		removed := a.editor.ClearAttachments()
+		if removed == 0 {
+			return a, toast.NewInfoToast("No attachments to clear")
+		}
+		return a, toast.NewSuccessToast("Removed " + util.Pluralize(removed, "attachment"))
+	case commands.InputGitContextCommand:
+		cmds = append(cmds, a.editor.AttachGitContext())
+	case commands.InputFenceCommand:
+		if strings.TrimSpace(a.editor.Value()) == "" {
+			return a, toast.NewInfoToast("Nothing to wrap in a code fence")
+		}
+		a.openModal(dialog.NewFenceDialog())
+	case commands.InputDateCommand:
+		a.editor.InsertText(time.Now().Format(time.DateOnly))
+	case commands.InputTimeCommand:
+		a.editor.InsertText(time.Now().Format("15:04"))
+	case commands.InputCwdCommand:
+		a.editor.InsertText(a.app.Cwd())
+	case commands.InputLineNumbersCommand:
+		a.app.State.EditorLineNumbers = a.app.State.EditorLineNumbers.Next()
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, toast.NewInfoToast(
+			fmt.Sprintf("Editor line numbers: %s", a.app.State.EditorLineNumbers),
+		))
+	case commands.InputFileCompletionCommand:
+		if a.showCompletionDialog {
+			a.closeFileCompletion()
+			return a, nil
+		}
+		if a.focus != focusEditor {
+			cmds = append(cmds, a.setFocus(focusEditor))
+		}
+		// Type the trigger for the user, so it works after any character
+		a.fileCompletionTyped = true
+		cmds = append(cmds, a.openFileCompletion(tea.KeyPressMsg{Code: '@', Text: "@"}))
 	case commands.InputClearCommand:
 		if a.editor.Value() == "" {
 			return a, nil
@@ -1013,6 +2064,11 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		updated, cmd := a.editor.Newline()
 		a.editor = updated.(chat.EditorComponent)
 		cmds = append(cmds, cmd)
+	case commands.InputFileInsertCommand:
+		a.editor.Blur()
+		insertDialog := dialog.NewInsertFileDialog(a.fileProvider)
+		cmds = append(cmds, insertDialog.Init())
+		a.openModal(insertDialog)
 	case commands.MessagesFirstCommand:
 		updated, cmd := a.messages.GotoTop()
 		a.messages = updated.(chat.MessagesComponent)
@@ -1021,6 +2077,11 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		updated, cmd := a.messages.GotoBottom()
 		a.messages = updated.(chat.MessagesComponent)
 		cmds = append(cmds, cmd)
+	case commands.MessagesLineUpCommand:
+		// scrolls the conversation without taking focus from the editor
+		a.messages.ScrollUp(messagesScrollStep)
+	case commands.MessagesLineDownCommand:
+		a.messages.ScrollDown(messagesScrollStep)
 	case commands.MessagesPageUpCommand:
 		if a.fileViewer.HasFile() {
 			a.fileViewer, cmd = a.fileViewer.PageUp()
@@ -1061,10 +2122,104 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		a.messagesRight = !a.messagesRight
 		a.app.State.MessagesRight = a.messagesRight
 		cmds = append(cmds, a.app.SaveState())
+	case commands.MessagesModelBadgesCommand:
+		a.app.State.HideModelBadges = !a.app.State.HideModelBadges
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, util.CmdHandler(chat.ToggleModelBadgesMsg{}))
+	case commands.ToastExpandCommand:
+		cmd, ok := a.toastManager.ExpandLatest()
+		if !ok {
+			return a, toast.NewInfoToast("No truncated notification to expand")
+		}
+		cmds = append(cmds, cmd)
+	case commands.MessagesDensityCommand:
+		a.app.State.MessageDensity = a.app.State.MessageDensity.Next()
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, util.CmdHandler(chat.MessageDensityChangedMsg{}))
+		cmds = append(cmds, toast.NewInfoToast(
+			fmt.Sprintf("Message density: %s", a.app.State.MessageDensity),
+		))
+	case commands.MessagesShortPathsCommand:
+		a.app.State.ShortPaths = !a.app.State.ShortPaths
+		util.ShortPaths = a.app.State.ShortPaths
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, util.CmdHandler(chat.PathsChangedMsg{}))
+		message := "Showing full file paths"
+		if a.app.State.ShortPaths {
+			message = "Showing short file paths"
+		}
+		cmds = append(cmds, toast.NewInfoToast(message))
+	case commands.MessagesLastErrorCommand:
+		updated, cmd := a.messages.GotoLastError()
+		a.messages = updated.(chat.MessagesComponent)
+		cmds = append(cmds, cmd)
+	case commands.MessagesCopyToolInputCommand:
+		updated, cmd := a.messages.CopyToolInput()
+		a.messages = updated.(chat.MessagesComponent)
+		cmds = append(cmds, cmd)
+	case commands.MessagesCopyCodeCommand:
+		updated, cmd := a.messages.CopyCodeBlocks()
+		a.messages = updated.(chat.MessagesComponent)
+		cmds = append(cmds, cmd)
+	case commands.MessagesToolOutputCommand:
+		return a.openToolOutput()
+	case commands.HintsToggleCommand:
+		a.app.State.HideHints = !a.app.State.HideHints
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, tea.RequestWindowSize)
+	case commands.LayoutPaddingCommand:
+		padding := a.app.State.ContentPadding().Next()
+		a.app.State.Padding = &padding
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, tea.RequestWindowSize)
+		cmds = append(cmds, toast.NewInfoToast(
+			fmt.Sprintf("Padding: %d columns, %d rows", padding.Horizontal, padding.Vertical),
+		))
+	case commands.MessagesRawCommand:
+		message := "Showing raw markdown"
+		if a.messages.RawMarkdown() {
+			message = "Showing rendered markdown"
+		}
+		cmds = append(cmds, util.CmdHandler(chat.ToggleRawMarkdownMsg{}))
+		cmds = append(cmds, toast.NewInfoToast(message))
+	case commands.MessagesSyntheticCommand:
+		message := "Showing injected context"
+		if a.messages.SyntheticVisible() {
+			message = "Hiding injected context"
+		}
+		cmds = append(cmds, util.CmdHandler(chat.ToggleSyntheticMsg{}))
+		cmds = append(cmds, toast.NewInfoToast(message))
+	case commands.FocusToggleCommand:
+		next := focusEditor
+		switch a.focus {
+		case focusEditor:
+			next = focusMessages
+		case focusMessages:
+			if a.fileViewer.HasFile() {
+				next = focusFileViewer
+			}
+		}
+		cmds = append(cmds, a.setFocus(next))
+	case commands.FocusAutoCommand:
+		a.app.State.NoAutoFocus = !a.app.State.NoAutoFocus
+		message := "Editor focused when a session loads"
+		if a.app.State.NoAutoFocus {
+			message = "Focus kept when a session loads"
+		}
+		cmds = append(cmds, a.app.SaveState())
+		cmds = append(cmds, toast.NewInfoToast(message))
 	case commands.MessagesCopyCommand:
 		updated, cmd := a.messages.CopyLastMessage()
 		a.messages = updated.(chat.MessagesComponent)
 		cmds = append(cmds, cmd)
+	case commands.MessagesCompareCommand:
+		a.openModal(dialog.NewCompareDialog(a.app))
+	case commands.MessagesTocCommand:
+		headings := a.messages.Headings()
+		if len(headings) == 0 {
+			return a, toast.NewInfoToast("No headings in the current message")
+		}
+		a.openModal(dialog.NewTocDialog(headings))
 	case commands.MessagesRevertCommand:
 	case commands.AppExitCommand:
 		return a, tea.Quit
@@ -1076,6 +2231,8 @@ func NewModel(app *app.App) tea.Model {
 	commandProvider := completions.NewCommandCompletionProvider(app)
 	fileProvider := completions.NewFileContextGroup(app)
 	symbolsProvider := completions.NewSymbolsContextGroup(app)
+	sessionsProvider := completions.NewSessionsContextGroup(app)
+	snippetsProvider := completions.NewSnippetsContextGroup(app)
 
 	messages := chat.NewMessagesComponent(app)
 	editor := chat.NewEditorComponent(app)
@@ -1087,6 +2244,12 @@ func NewModel(app *app.App) tea.Model {
 		leaderBinding = &binding
 	}
 
+	toastManager := toast.NewToastManager(
+		int(app.Config.Tui.ToastMaxWidth),
+		int(app.Config.Tui.ToastMaxLines),
+		int(app.Config.Tui.ToastMaxStack),
+	)
+
 	model := &appModel{
 		status:               status.NewStatusCmp(app),
 		app:                  app,
@@ -1096,9 +2259,12 @@ func NewModel(app *app.App) tea.Model {
 		commandProvider:      commandProvider,
 		fileProvider:         fileProvider,
 		symbolsProvider:      symbolsProvider,
+		sessionsProvider:     sessionsProvider,
+		snippetsProvider:     snippetsProvider,
 		leaderBinding:        leaderBinding,
 		showCompletionDialog: false,
-		toastManager:         toast.NewToastManager(),
+		toastManager:         toastManager,
+		inspector:            inspector.New(),
 		interruptKeyState:    InterruptKeyIdle,
 		exitKeyState:         ExitKeyIdle,
 		fileViewer:           fileviewer.New(app),
@@ -1108,43 +2274,28 @@ func NewModel(app *app.App) tea.Model {
 	return model
 }
 
-func formatConversationToMarkdown(messages []app.Message) string {
-	var builder strings.Builder
-
-	builder.WriteString("# Conversation History\n\n")
-
-	for _, msg := range messages {
-		builder.WriteString("---\n\n")
-
-		var role string
-		var timestamp time.Time
-
-		switch info := msg.Info.(type) {
-		case opencode.UserMessage:
-			role = "User"
-			timestamp = time.UnixMilli(int64(info.Time.Created))
-		case opencode.AssistantMessage:
-			role = "Assistant"
-			timestamp = time.UnixMilli(int64(info.Time.Created))
-		default:
-			continue
-		}
-
-		builder.WriteString(
-			fmt.Sprintf("**%s** (*%s*)\n\n", role, timestamp.Format("2006-01-02 15:04:05")),
-		)
-
-		for _, part := range msg.Parts {
-			switch p := part.(type) {
-			case opencode.TextPart:
-				builder.WriteString(p.Text + "\n\n")
-			case opencode.FilePart:
-				builder.WriteString(fmt.Sprintf("[File: %s]\n\n", p.Filename))
-			case opencode.ToolPart:
-				builder.WriteString(fmt.Sprintf("[Tool: %s]\n\n", p.Tool))
-			}
-		}
+// saveConversation writes the current conversation as markdown to the
+// configured save path, resolved against the project root, and returns the
+// path as configured
+func (a appModel) saveConversation() (string, error) {
+	pattern := a.app.Config.Tui.SavePath
+	if pattern == "" {
+		pattern = defaultSavePath
 	}
-
-	return builder.String()
+	path := filepath.Clean(strings.ReplaceAll(pattern, "{id}", a.app.Session.ID))
+	root := a.app.Info.Path.Root
+	target := filepath.Join(root, path)
+	// the conversation is only ever written within the project
+	if rel, err := filepath.Rel(root, target); filepath.IsAbs(path) || err != nil ||
+		rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", errSavePathOutsideProject, pattern)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", err
+	}
+	content := util.Redact(formatConversationToMarkdown(a.app.Messages))
+	if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
 }
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/chat"
+	"github.com/sst/opencode/internal/components/dialog"
+	"github.com/sst/opencode/internal/theme"
+)
+
+type testModal struct {
+	name   string
+	closed *[]string
+}
+
+func (m testModal) Init() tea.Cmd                       { return nil }
+func (m testModal) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m testModal) View() string                        { return m.name }
+func (m testModal) Render(string) string                { return m.name }
+func (m testModal) Close() tea.Cmd {
+	*m.closed = append(*m.closed, m.name)
+	return nil
+}
+
+func TestModalStack(t *testing.T) {
+	var closed []string
+	a := &appModel{focus: focusMessages}
+	for _, name := range []string{"help", "sessions", "themes"} {
+		a.openModal(testModal{name: name, closed: &closed})
+	}
+	top := func() string {
+		if a.modal == nil {
+			return ""
+		}
+		return a.modal.(testModal).name
+	}
+
+	if got := top(); got != "themes" {
+		t.Fatalf("top = %q, want themes", got)
+	}
+	a.cycleModals()
+	if got := top(); got != "sessions" {
+		t.Fatalf("after cycle top = %q, want sessions", got)
+	}
+	a.closeModal()
+	if got := top(); got != "help" {
+		t.Fatalf("after close top = %q, want help", got)
+	}
+	a.closeModal()
+	if got := top(); got != "themes" {
+		t.Fatalf("after close top = %q, want themes", got)
+	}
+	a.openModal(testModal{name: "models", closed: &closed})
+	a.closeAllModals()
+	if a.modal != nil || len(a.modals) != 0 {
+		t.Fatalf("modals left open: %v %v", a.modal, a.modals)
+	}
+	want := []string{"sessions", "help", "models", "themes"}
+	if len(closed) != len(want) {
+		t.Fatalf("closed = %v, want %v", closed, want)
+	}
+	for i := range want {
+		if closed[i] != want[i] {
+			t.Fatalf("closed = %v, want %v", closed, want)
+		}
+	}
+}
+
+type testEditor struct {
+	chat.EditorComponent
+}
+
+func (e testEditor) Blur() {}
+
+func TestPermissionReplacesFindDialog(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	a := appModel{focus: focusMessages, editor: testEditor{}}
+	a.openModal(dialog.NewFindDialog(nil))
+	a.permissions = append(a.permissions, opencode.EventListResponseEventPermissionUpdatedProperties{
+		ID:    "per_1",
+		Title: "Run ls",
+	})
+
+	// deliver whatever closing the find dialog sends back to the app
+	var msgs []tea.Msg
+	if cmd := a.showPermission(); cmd != nil {
+		msgs = append(msgs, cmd())
+	}
+	for i := 0; i < len(msgs); i++ {
+		msg := msgs[i]
+		if msg == nil {
+			continue
+		}
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, cmd := range batch {
+				if cmd != nil {
+					msgs = append(msgs, cmd())
+				}
+			}
+			continue
+		}
+		if _, ok := msg.(dialog.PermissionRespondedMsg); ok {
+			t.Fatal("permission answered before it was shown")
+		}
+		updated, _ := a.Update(msg)
+		a = updated.(appModel)
+	}
+
+	if _, ok := a.modal.(dialog.PermissionDialog); !ok {
+		t.Fatalf("modal = %T, want the permission dialog", a.modal)
+	}
+}
+
+func TestCloseFindDialogKeepsDialogBeneath(t *testing.T) {
+	if err := theme.LoadThemesFromJSON(); err != nil {
+		t.Fatal(err)
+	}
+	theme.SetTheme("opencode")
+
+	var closed []string
+	a := &appModel{focus: focusMessages}
+	a.openModal(testModal{name: "help", closed: &closed})
+	a.openModal(dialog.NewInsertFileDialog(nil))
+
+	if cmd := a.closeModal(); cmd != nil {
+		if msg := cmd(); msg != nil {
+			t.Fatalf("closing the find dialog sent %T", msg)
+		}
+	}
+	if m, ok := a.modal.(testModal); !ok || m.name != "help" {
+		t.Fatalf("modal = %v, want help", a.modal)
+	}
+}
+
+func TestSaveConversationStaysInProject(t *testing.T) {
+	root := t.TempDir()
+	a := appModel{app: &app.App{
+		Info:    opencode.App{Path: opencode.AppPath{Root: root}},
+		Config:  &opencode.Config{},
+		Session: &opencode.Session{ID: "ses_1"},
+	}}
+
+	for _, savePath := range []string{"notes/{id}.md", "notes/../{id}.md"} {
+		a.app.Config.Tui.SavePath = savePath
+		path, err := a.saveConversation()
+		if err != nil {
+			t.Fatalf("%s: %v", savePath, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			t.Errorf("%s: %v", savePath, err)
+		}
+	}
+
+	for _, savePath := range []string{"../{id}.md", "notes/../../{id}.md", "/tmp/{id}.md"} {
+		a.app.Config.Tui.SavePath = savePath
+		if _, err := a.saveConversation(); !errors.Is(err, errSavePathOutsideProject) {
+			t.Errorf("%s: error = %v, want errSavePathOutsideProject", savePath, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/styles"
+)
+
+var (
+	// CSI sequences, of which only SGR (ending in m) are kept
+	csiRegex = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]`)
+	// OSC sequences such as hyperlinks and window titles, and lone escapes
+	oscRegex = regexp.MustCompile(`\x1b\][^\x07\x1b]*(\x07|\x1b\\)|\x1b[^\[]`)
+	// SGR sequences that reset all attributes, clearing the background
+	resetRegex = regexp.MustCompile(`\x1b\[0*m`)
+)
+
+// HasANSI reports whether text contains escape sequences
+func HasANSI(text string) bool {
+	return strings.ContainsRune(text, '\x1b')
+}
+
+// sanitizeANSI keeps the colors and attributes of text, dropping sequences that
+// move the cursor or change the terminal, and overwritten progress output
+func sanitizeANSI(text string) string {
+	text = oscRegex.ReplaceAllString(text, "")
+	text = csiRegex.ReplaceAllStringFunc(text, func(seq string) string {
+		if strings.HasSuffix(seq, "m") {
+			return seq
+		}
+		return ""
+	})
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		// a carriage return redraws the line, only the last redraw is visible
+		if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+			line = line[idx+1:]
+		}
+		lines[i] = strings.ReplaceAll(line, "\t", "  ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenderANSI renders text that carries its own colors, such as test runner
+// output, on the given background. Lines are wrapped, or cut starting offset
+// columns in, to width and padded so colored output never breaks the layout
+func RenderANSI(
+	text string,
+	width int,
+	wrap bool,
+	offset int,
+	backgroundColor compat.AdaptiveColor,
+) string {
+	background := styles.NewStyle().Background(backgroundColor)
+	// the sequence that sets the background, restored after every reset
+	prefix, _, _ := strings.Cut(background.Render(" "), " ")
+
+	text = sanitizeANSI(text)
+	if wrap {
+		text = ansi.Wrap(text, width, "")
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !wrap {
+			line = ansi.Cut(line, offset, offset+width)
+		}
+		padding := max(0, width-ansi.StringWidth(line))
+		line = resetRegex.ReplaceAllStringFunc(line, func(reset string) string {
+			return reset + prefix
+		})
+		lines[i] = prefix + line + strings.Repeat(" ", padding) + "\x1b[m"
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSanitizeANSI(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"keeps colors", "\x1b[32mok\x1b[0m", "\x1b[32mok\x1b[0m"},
+		{"drops cursor movement", "\x1b[2K\x1b[1Gdone", "done"},
+		{"drops hyperlinks", "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", "link"},
+		{"keeps last redraw", "10%\r50%\r100%", "100%"},
+		{"keeps crlf lines", "a\r\nb", "a\nb"},
+		{"expands tabs", "a\tb", "a  b"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sanitizeANSI(tc.input); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	background := compat.AdaptiveColor{Light: lipgloss.Color("#eeeeee"), Dark: lipgloss.Color("#111111")}
+	input := "\x1b[32mPASS\x1b[0m TestSomething with a long name\n\x1b[31mFAIL\x1b[0m"
+
+	for _, wrap := range []bool{true, false} {
+		rendered := RenderANSI(input, 20, wrap, 0, background)
+		for _, line := range strings.Split(rendered, "\n") {
+			if width := ansi.StringWidth(line); width != 20 {
+				t.Errorf("wrap=%v: expected lines padded to 20 columns, got %d in %q", wrap, width, line)
+			}
+		}
+		if !strings.Contains(rendered, "\x1b[32mPASS") {
+			t.Errorf("wrap=%v: expected colors to be kept, got %q", wrap, rendered)
+		}
+	}
+
+	if lines := strings.Count(RenderANSI(input, 20, true, 0, background), "\n"); lines < 2 {
+		t.Errorf("expected the long line to wrap, got %d line breaks", lines)
+	}
+	cut := ansi.Strip(RenderANSI("0123456789", 4, false, 3, background))
+	if cut != "3456" {
+		t.Errorf("expected the line cut from the offset, got %q", cut)
+	}
+}
@@ -48,6 +48,18 @@ func (h *APILogHandler) Enabled(_ context.Context, level slog.Level) bool {
 }
 
 func (h *APILogHandler) Handle(ctx context.Context, r slog.Record) error {
+	category := r.Message
+	r.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == LogEventKey {
+			category = attr.Value.String()
+			return false
+		}
+		return true
+	})
+	if logFiltered(category) {
+		return nil
+	}
+
 	var apiLevel opencode.AppLogParamsLevel
 	switch r.Level {
 	case slog.LevelDebug:
@@ -75,10 +87,16 @@ func (h *APILogHandler) Handle(ctx context.Context, r slog.Record) error {
 		return true
 	})
 
+	for key, value := range extra {
+		if text, ok := value.(string); ok {
+			extra[key] = Redact(text)
+		}
+	}
+
 	params := opencode.AppLogParams{
 		Service: opencode.F(h.service),
 		Level:   opencode.F(apiLevel),
-		Message: opencode.F(r.Message),
+		Message: opencode.F(Redact(r.Message)),
 	}
 
 	if len(extra) > 0 {
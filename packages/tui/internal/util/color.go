@@ -50,6 +50,8 @@ var targetBGMap = map[string]string{
 }
 
 func ConvertRGBToAnsi16Colors(s string) string {
+	measure := Profile("util.ConvertRGBToAnsi16Colors")
+	defer measure()
 	return csiRE.ReplaceAllStringFunc(s, func(seq string) string {
 		params := strings.Split(csiRE.FindStringSubmatch(seq)[1], ";")
 		out := make([]string, 0, len(params))
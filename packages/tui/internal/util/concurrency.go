@@ -1,40 +1,73 @@
 package util
 
 import (
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-func mapParallel[in, out any](items []in, fn func(in) out) chan out {
-	mapChans := make([]chan out, 0, len(items))
+// maxConcurrency bounds how many items are computed at once across every
+// parallel map, zero for GOMAXPROCS
+var maxConcurrency atomic.Int64
 
-	for _, v := range items {
-		ch := make(chan out)
-		mapChans = append(mapChans, ch)
-		go func() {
-			defer close(ch)
-			ch <- fn(v)
-		}()
-	}
+// workers counts the goroutines computing items on behalf of a parallel map,
+// shared so nested maps stay within the same limit
+var workers atomic.Int64
+
+// SetMaxConcurrency bounds how many items WriteStringsPar computes at once.
+// Zero or less uses GOMAXPROCS.
+func SetMaxConcurrency(n int) {
+	maxConcurrency.Store(int64(max(n, 0)))
+}
 
-	resultChan := make(chan out)
+// MaxConcurrency returns how many items WriteStringsPar computes at once
+func MaxConcurrency() int {
+	if n := maxConcurrency.Load(); n > 0 {
+		return int(n)
+	}
+	return runtime.GOMAXPROCS(0)
+}
 
-	go func() {
-		defer close(resultChan)
-		for _, ch := range mapChans {
-			v := <-ch
-			resultChan <- v
+// acquireWorker reserves a worker if the limit allows one. The calling
+// goroutine counts towards the limit, so one fewer worker is handed out.
+func acquireWorker() bool {
+	for {
+		n := workers.Load()
+		if n >= int64(MaxConcurrency()-1) {
+			return false
+		}
+		if workers.CompareAndSwap(n, n+1) {
+			return true
 		}
-	}()
+	}
+}
 
-	return resultChan
+func mapParallel[in, out any](items []in, fn func(in) out) []out {
+	results := make([]out, len(items))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		// with every worker busy the caller computes the item itself, so
+		// nested maps never block waiting on each other for a worker
+		if !acquireWorker() {
+			results[i] = fn(item)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer workers.Add(-1)
+			results[i] = fn(item)
+		}()
+	}
+	wg.Wait()
+	return results
 }
 
 // WriteStringsPar allows to iterate over a list and compute strings in parallel,
 // yet write them in order.
 func WriteStringsPar[a any](sb *strings.Builder, items []a, fn func(a) string) {
-	ch := mapParallel(items, fn)
-
-	for v := range ch {
+	for _, v := range mapParallel(items, fn) {
 		sb.WriteString(v)
 	}
 }
@@ -3,6 +3,7 @@ package util_test
 import (
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,3 +22,68 @@ func TestWriteStringsPar(t *testing.T) {
 		t.Fatalf("expected 0123456789, got %s", sb.String())
 	}
 }
+
+func TestWriteStringsParBoundsConcurrency(t *testing.T) {
+	util.SetMaxConcurrency(2)
+	defer util.SetMaxConcurrency(0)
+
+	var active, peak atomic.Int32
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+	sb := strings.Builder{}
+	util.WriteStringsPar(&sb, items, func(i int) string {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+		return strconv.Itoa(i) + ","
+	})
+
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 items computed at once, got %d", peak.Load())
+	}
+	expected := "0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,"
+	if sb.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, sb.String())
+	}
+}
+
+func TestWriteStringsParNestedBoundsConcurrency(t *testing.T) {
+	util.SetMaxConcurrency(3)
+	defer util.SetMaxConcurrency(0)
+
+	var active, peak atomic.Int32
+	items := []int{0, 1, 2, 3, 4, 5}
+	sb := strings.Builder{}
+	util.WriteStringsPar(&sb, items, func(i int) string {
+		inner := strings.Builder{}
+		util.WriteStringsPar(&inner, items, func(j int) string {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			return strconv.Itoa(j)
+		})
+		return inner.String() + ","
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 items computed at once, got %d", peak.Load())
+	}
+	expected := strings.Repeat("012345,", len(items))
+	if sb.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, sb.String())
+	}
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// cursorShapes are the cursor shapes selectable with tui.cursor_shape
+var cursorShapes = map[string]tea.CursorShape{
+	"block":     tea.CursorBlock,
+	"bar":       tea.CursorBar,
+	"underline": tea.CursorUnderline,
+}
+
+// CursorShape returns the cursor shape with the given name, defaulting to a
+// block when the name is empty or unknown
+func CursorShape(name string) tea.CursorShape {
+	if s, ok := cursorShapes[name]; ok {
+		return s
+	}
+	return tea.CursorBlock
+}
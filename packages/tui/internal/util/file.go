@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss/v2/compat"
 	"github.com/charmbracelet/x/ansi"
@@ -15,6 +16,12 @@ import (
 var RootPath string
 var CwdPath string
 
+// ShortPaths shortens the file paths shown in messages to their last segments
+var ShortPaths bool
+
+// shortPathSegments is how many trailing segments a shortened path keeps
+const shortPathSegments = 3
+
 type fileRenderer struct {
 	filename string
 	content  string
@@ -59,6 +66,33 @@ func RenderFile(
 	return content
 }
 
+// IsBinary reports whether content looks like binary data rather than text,
+// either because it contains NUL bytes, is not valid UTF-8, or is dominated
+// by control or replacement characters
+func IsBinary(content string) bool {
+	sample := content
+	if len(sample) > 8000 {
+		sample = sample[:8000]
+		// don't let a multi-byte rune split at the cut count as invalid
+		for len(sample) > 0 && !utf8.RuneStart(sample[len(sample)-1]) {
+			sample = sample[:len(sample)-1]
+		}
+		if len(sample) > 0 {
+			sample = sample[:len(sample)-1]
+		}
+	}
+	if strings.ContainsRune(sample, 0) || !utf8.ValidString(sample) {
+		return true
+	}
+	control := 0
+	for _, r := range sample {
+		if r == utf8.RuneError || (unicode.IsControl(r) && !strings.ContainsRune("\n\r\t\f", r)) {
+			control++
+		}
+	}
+	return control > len(sample)/10
+}
+
 func TruncateHeight(content string, height int) string {
 	lines := strings.Split(content, "\n")
 	if len(lines) > height {
@@ -72,6 +106,26 @@ func Relative(path string) string {
 	return strings.TrimPrefix(path, RootPath+"/")
 }
 
+// DisplayPath returns the path relative to the project, shortened to its last
+// segments when ShortPaths is set
+func DisplayPath(path string) string {
+	path = Relative(path)
+	if !ShortPaths {
+		return path
+	}
+	return ShortenPath(path, shortPathSegments)
+}
+
+// ShortenPath keeps the last segments of a path, replacing the leading ones
+// with an ellipsis
+func ShortenPath(path string, segments int) string {
+	parts := strings.Split(path, "/")
+	if len(parts) <= segments {
+		return path
+	}
+	return "…/" + strings.Join(parts[len(parts)-segments:], "/")
+}
+
 func Extension(path string) string {
 	ext := filepath.Ext(path)
 	if ext == "" {
@@ -85,6 +139,7 @@ func Extension(path string) string {
 func ToMarkdown(content string, width int, backgroundColor compat.AdaptiveColor) string {
 	r := styles.GetMarkdownRenderer(width-6, backgroundColor)
 	content = strings.ReplaceAll(content, RootPath+"/", "")
+	content = normalizeFences(content)
 	rendered, _ := r.Render(content)
 	lines := strings.Split(rendered, "\n")
 
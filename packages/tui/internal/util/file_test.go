@@ -0,0 +1,44 @@
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sst/opencode/internal/util"
+)
+
+func TestIsBinary(t *testing.T) {
+	cases := map[string]bool{
+		"package main\n\nfunc main() {}\n":    false,
+		"héllo wörld\t✓\n":                    false,
+		"\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR": true,
+		"\xff\xfe\xfd":                        true,
+		strings.Repeat("\x01\x02text", 10):    true,
+	}
+	for content, expected := range cases {
+		if got := util.IsBinary(content); got != expected {
+			t.Errorf("IsBinary(%q) = %v, expected %v", content, got, expected)
+		}
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	util.CwdPath = "/project"
+	defer func() { util.CwdPath, util.ShortPaths = "", false }()
+
+	path := "/project/internal/components/dialog/x.go"
+	if got := util.DisplayPath(path); got != "internal/components/dialog/x.go" {
+		t.Errorf("DisplayPath() = %q, expected the full relative path", got)
+	}
+	util.ShortPaths = true
+	cases := map[string]string{
+		path:                   "…/components/dialog/x.go",
+		"/project/dialog/x.go": "dialog/x.go",
+		"main.go":              "main.go",
+	}
+	for path, expected := range cases {
+		if got := util.DisplayPath(path); got != expected {
+			t.Errorf("DisplayPath(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	// languages maps lowercase file extensions and code fence languages to
+	// the language used for syntax highlighting, as configured under
+	// tui.languages
+	languages     map[string]string
+	languagesOnce sync.Once
+)
+
+// SetLanguages sets the highlighting overrides configured under
+// tui.languages. Only the first call has any effect, so the overrides are
+// never written while render workers read them.
+func SetLanguages(configured map[string]string) {
+	languagesOnce.Do(func() {
+		languages = make(map[string]string, len(configured))
+		for hint, language := range configured {
+			languages[languageKey(hint)] = language
+		}
+	})
+}
+
+// languageKey normalizes a file extension or fence language for lookups
+func languageKey(hint string) string {
+	return strings.ToLower(strings.TrimPrefix(hint, "."))
+}
+
+// LanguageOverride returns the configured language for a file extension or
+// fence language, if there is one
+func LanguageOverride(hint string) (string, bool) {
+	language, ok := languages[languageKey(hint)]
+	return language, ok
+}
+
+// Language resolves a file extension or fence language to the language that
+// should be used for highlighting, applying any configured override
+func Language(hint string) string {
+	if language, ok := LanguageOverride(hint); ok {
+		return language
+	}
+	return languageKey(hint)
+}
+
+// fenceLanguage extracts the language from a fence info string, accepting
+// forms like "ts", "ts title=x", "ts:src/x.ts" and "{.ts}"
+func fenceLanguage(info string) string {
+	info = strings.TrimSpace(info)
+	info = strings.TrimPrefix(info, "{")
+	info = strings.TrimPrefix(info, ".")
+	language, _, _ := strings.Cut(info, " ")
+	language, _, _ = strings.Cut(language, ":")
+	language, _, _ = strings.Cut(language, ",")
+	language = strings.TrimSuffix(language, "}")
+	return Language(language)
+}
+
+// normalizeFences rewrites the info string of every opening code fence to the
+// bare language so the markdown renderer picks the intended lexer
+func normalizeFences(content string) string {
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "```") && !strings.HasPrefix(trimmed, "~~~") {
+			continue
+		}
+		marker := trimmed[:1]
+		length := len(trimmed) - len(strings.TrimLeft(trimmed, marker))
+		fence = trimmed[:length]
+		info := trimmed[length:]
+		if info == "" {
+			continue
+		}
+		indent := line[:len(line)-len(trimmed)]
+		lines[i] = indent + fence + fenceLanguage(info)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,23 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/sst/opencode/internal/util"
+)
+
+func TestLanguage(t *testing.T) {
+	util.SetLanguages(map[string]string{".H": "cpp"})
+
+	cases := map[string]string{
+		"h":   "cpp",
+		".H":  "cpp",
+		"go":  "go",
+		"TSX": "tsx",
+	}
+	for hint, expected := range cases {
+		if got := util.Language(hint); got != expected {
+			t.Errorf("Language(%q) = %q, expected %q", hint, got, expected)
+		}
+	}
+}
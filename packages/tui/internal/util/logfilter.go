@@ -0,0 +1,80 @@
+package util
+
+import (
+	"slices"
+	"sync"
+)
+
+// LogEventKey is the attribute naming the event type a log record is about.
+// Records are filtered by it, falling back to the log message.
+const LogEventKey = "event"
+
+// LogCategory is an event type or log message seen by the API log handler
+type LogCategory struct {
+	Name     string
+	Count    int
+	Excluded bool
+}
+
+var (
+	logFilterMu  sync.Mutex
+	logExcluded  = map[string]bool{}
+	logSeenCount = map[string]int{}
+)
+
+// SetLogExcluded replaces the categories that aren't forwarded to the server log
+func SetLogExcluded(categories []string) {
+	logFilterMu.Lock()
+	defer logFilterMu.Unlock()
+	logExcluded = make(map[string]bool, len(categories))
+	for _, category := range categories {
+		logExcluded[category] = true
+	}
+}
+
+// ToggleLogCategory flips whether the category is forwarded to the server log,
+// returning true if it is now excluded
+func ToggleLogCategory(category string) bool {
+	logFilterMu.Lock()
+	defer logFilterMu.Unlock()
+	if logExcluded[category] {
+		delete(logExcluded, category)
+		return false
+	}
+	logExcluded[category] = true
+	return true
+}
+
+// LogCategories returns the categories seen so far or excluded, sorted by name
+func LogCategories() []LogCategory {
+	logFilterMu.Lock()
+	defer logFilterMu.Unlock()
+	categories := make([]LogCategory, 0, len(logSeenCount))
+	for name, count := range logSeenCount {
+		categories = append(categories, LogCategory{Name: name, Count: count, Excluded: logExcluded[name]})
+	}
+	for name := range logExcluded {
+		if _, ok := logSeenCount[name]; !ok {
+			categories = append(categories, LogCategory{Name: name, Excluded: true})
+		}
+	}
+	slices.SortFunc(categories, func(a, b LogCategory) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+	return categories
+}
+
+// logFiltered counts a record in the category and reports whether it should be
+// dropped
+func logFiltered(category string) bool {
+	logFilterMu.Lock()
+	defer logFilterMu.Unlock()
+	logSeenCount[category]++
+	return logExcluded[category]
+}
@@ -0,0 +1,30 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/sst/opencode/internal/util"
+)
+
+func TestLogCategories(t *testing.T) {
+	util.SetLogExcluded([]string{"message.part.updated"})
+	defer util.SetLogExcluded(nil)
+
+	categories := util.LogCategories()
+	if len(categories) != 1 || categories[0].Name != "message.part.updated" || !categories[0].Excluded {
+		t.Fatalf("LogCategories() = %v, expected message.part.updated excluded", categories)
+	}
+
+	if util.ToggleLogCategory("message.part.updated") {
+		t.Errorf("ToggleLogCategory of an excluded category returned true")
+	}
+	if !util.ToggleLogCategory("session.updated") {
+		t.Errorf("ToggleLogCategory of a forwarded category returned false")
+	}
+	for _, category := range util.LogCategories() {
+		expected := category.Name == "session.updated"
+		if category.Excluded != expected {
+			t.Errorf("%s excluded = %v, expected %v", category.Name, category.Excluded, expected)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	profiling        atomic.Bool
+	profileThreshold atomic.Int64
+)
+
+// EnableProfiling logs render paths that take longer than the threshold
+func EnableProfiling(threshold time.Duration) {
+	profileThreshold.Store(int64(threshold))
+	profiling.Store(true)
+}
+
+// Profile times a render path when profiling is enabled, returning a function
+// that logs it if it was slow. It does nothing otherwise, so it is cheap
+// enough for hot paths.
+func Profile(tag string) func(...any) {
+	if !profiling.Load() {
+		return func(...any) {}
+	}
+	return profile(tag, time.Now())
+}
+
+func profile(tag string, startTime time.Time) func(...any) {
+	return func(args ...any) {
+		elapsed := time.Since(startTime)
+		if elapsed < time.Duration(profileThreshold.Load()) {
+			return
+		}
+		args = append(args, "tag", tag, "timeTakenMs", elapsed.Milliseconds())
+		slog.Warn("slow render", args...)
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// defaultRedactPatterns match common credential formats. When a pattern has a
+// capture group, the group is kept and only the rest of the match is redacted.
+var defaultRedactPatterns = []string{
+	`sk-[A-Za-z0-9_-]{20,}`,
+	`AKIA[0-9A-Z]{16}`,
+	`gh[pousr]_[A-Za-z0-9]{36,}`,
+	`github_pat_[A-Za-z0-9_]{22,}`,
+	`xox[abprs]-[A-Za-z0-9-]{10,}`,
+	`AIza[0-9A-Za-z_-]{35}`,
+	`(?i)(bearer\s+)[A-Za-z0-9._~+/-]+=*`,
+	`(?i)((?:api[_-]?key|secret|token|password)["']?\s*[:=]\s*["']?)[^\s"',]{8,}`,
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	redactMu       sync.RWMutex
+	redactPatterns = compileRedactPatterns(defaultRedactPatterns)
+)
+
+func compileRedactPatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		compiled = append(compiled, regexp.MustCompile(pattern))
+	}
+	return compiled
+}
+
+// AddRedactPatterns adds user configured patterns to the defaults used by
+// Redact, skipping and reporting any that fail to compile
+func AddRedactPatterns(patterns []string) error {
+	var invalid error
+	compiled := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			invalid = fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+
+	redactMu.Lock()
+	redactPatterns = append(redactPatterns, compiled...)
+	redactMu.Unlock()
+	return invalid
+}
+
+// Redact replaces anything that looks like a secret with a placeholder
+func Redact(text string) string {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+	for _, re := range redactPatterns {
+		replacement := redacted
+		if re.NumSubexp() > 0 {
+			replacement = "${1}" + redacted
+		}
+		text = re.ReplaceAllString(text, replacement)
+	}
+	return text
+}
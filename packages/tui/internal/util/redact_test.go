@@ -0,0 +1,21 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/sst/opencode/internal/util"
+)
+
+func TestRedact(t *testing.T) {
+	cases := map[string]string{
+		"key is sk-ant-REDACTED": "key is [REDACTED]",
+		"Authorization: Bearer abc.def-ghi":              "Authorization: Bearer [REDACTED]",
+		`{"api_key": "supersecretvalue"}`:                `{"api_key": "[REDACTED]"}`,
+		"nothing to see here":                            "nothing to see here",
+	}
+	for input, expected := range cases {
+		if got := util.Redact(input); got != expected {
+			t.Errorf("Redact(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
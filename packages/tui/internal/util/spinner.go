@@ -0,0 +1,26 @@
+package util
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/spinner"
+)
+
+// spinners are the spinner styles selectable with tui.spinner
+var spinners = map[string]spinner.Spinner{
+	"dots": spinner.MiniDot,
+	"line": spinner.Line,
+	"bounce": {
+		Frames: []string{"⠁", "⠂", "⠄", "⡀", "⠄", "⠂"},
+		FPS:    time.Second / 10,
+	},
+}
+
+// Spinner returns the spinner style with the given name, or fallback when the
+// name is empty or unknown so each spinner keeps its own default
+func Spinner(name string, fallback spinner.Spinner) spinner.Spinner {
+	if s, ok := spinners[name]; ok {
+		return s
+	}
+	return fallback
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -32,6 +33,15 @@ func ProcessTextWithHyphens(text string, processFunc func(string) string) string
 	return RestoreHyphens(processed)
 }
 
+// Pluralize formats a count with the noun, adding an s unless there is
+// exactly one
+func Pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 // GetMessageContainerFrame calculates the actual horizontal frame size
 // (padding + borders) for message containers based on current theme.
 func GetMessageContainerFrame() int {
@@ -38,8 +38,13 @@ func IsWsl() bool {
 	return false
 }
 
+// Measure times a block, logging how long it took at debug level, or only
+// when slow while profiling
 func Measure(tag string) func(...any) {
 	startTime := time.Now()
+	if profiling.Load() {
+		return profile(tag, startTime)
+	}
 	return func(args ...any) {
 		args = append(args, []any{"timeTakenMs", time.Since(startTime).Milliseconds()}...)
 		slog.Debug(tag, args...)
@@ -76,6 +76,7 @@ type App struct {
 	Hostname string  `json:"hostname,required"`
 	Path     AppPath `json:"path,required"`
 	Time     AppTime `json:"time,required"`
+	Version  string  `json:"version,required"`
 	JSON     appJSON `json:"-"`
 }
 
@@ -85,6 +86,7 @@ type appJSON struct {
 	Hostname    apijson.Field
 	Path        apijson.Field
 	Time        apijson.Field
+	Version     apijson.Field
 	raw         string
 	ExtraFields map[string]apijson.Field
 }
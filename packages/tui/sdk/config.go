@@ -67,6 +67,8 @@ type Config struct {
 	Model string `json:"model"`
 	// Default model from server config in the format of provider/model
 	DefaultModel string `json:"defaultModel"`
+	// Ask before tools edit or write files, answered from the TUI
+	PermissionPrompt bool `json:"permission_prompt"`
 	// Custom provider configurations and model overrides
 	Provider map[string]ConfigProvider `json:"provider"`
 	// Control sharing behavior:'manual' allows manual sharing via commands, 'auto'
@@ -75,8 +77,11 @@ type Config struct {
 	// Small model to use for tasks like summarization and title generation in the
 	// format of provider/model
 	SmallModel string `json:"small_model"`
-	// Theme name to use for the interface
+	// Theme name to use for the interface, or auto to follow the terminal
+	// background
 	Theme string `json:"theme"`
+	// TUI specific settings
+	Tui ConfigTui `json:"tui"`
 	// Custom username to display in conversations instead of system username
 	Username string     `json:"username"`
 	JSON     configJSON `json:"-"`
@@ -96,10 +101,12 @@ type configJSON struct {
 	Mcp               apijson.Field
 	Mode              apijson.Field
 	Model             apijson.Field
+	PermissionPrompt  apijson.Field
 	Provider          apijson.Field
 	Share             apijson.Field
 	SmallModel        apijson.Field
 	Theme             apijson.Field
+	Tui               apijson.Field
 	Username          apijson.Field
 	raw               string
 	ExtraFields       map[string]apijson.Field
@@ -470,33 +477,414 @@ func (r ConfigShare) IsKnown() bool {
 	return false
 }
 
+// TUI specific settings
+type ConfigTui struct {
+	// Attach the file open in the viewer to each message that does not already
+	// mention it
+	AutoAttachFile bool `json:"auto_attach_file"`
+	// Show activity in the status bar as an animated spinner or static text
+	BusyIndicator ConfigTuiBusyIndicator `json:"busy_indicator"`
+	// Mark added, modified and removed lines in a gutter when showing a modified
+	// file's contents instead of its diff, defaults to true
+	ChangeGutter bool `json:"change_gutter"`
+	// Ask for confirmation before sending prompts estimated above this many tokens
+	ConfirmPromptTokens int64 `json:"confirm_prompt_tokens"`
+	// Explain what sharing exposes and ask before sharing a session, defaults to
+	// true
+	ConfirmShare bool `json:"confirm_share"`
+	// Blink the editor cursor, defaults to true
+	CursorBlink bool `json:"cursor_blink"`
+	// Editor cursor shape
+	CursorShape ConfigTuiCursorShape `json:"cursor_shape"`
+	// Line numbers in diffs: sized to the largest line number, a fixed width, or
+	// hidden
+	DiffLineNumbers ConfigTuiDiffLineNumbers `json:"diff_line_numbers"`
+	// Diff color scheme, independent of the UI theme
+	DiffPreset ConfigTuiDiffPreset `json:"diff_preset"`
+	// Close brackets and quotes typed in the editor, defaults to false
+	EditorAutoPair bool `json:"editor_auto_pair"`
+	// Maximum number of characters the editor accepts, further input is blocked
+	EditorCharLimit int64 `json:"editor_char_limit"`
+	// Show the character count in a warning color once the editor holds this many
+	// characters
+	EditorCharWarning int64 `json:"editor_char_warning"`
+	// Maximum number of lines the editor grows to before scrolling internally
+	EditorMaxHeight int64 `json:"editor_max_height"`
+	// What submitting an empty editor does: nothing, or start a new line. Defaults
+	// to ignore
+	EmptyEnter ConfigTuiEmptyEnter `json:"empty_enter"`
+	// Mark changed text in diffs with underline and strikethrough so they read
+	// without relying on color
+	DiffSymbols bool `json:"diff_symbols"`
+	// Pin diffs to this many columns, centered, instead of filling the terminal.
+	// Side-by-side diffs use this width for each side
+	DiffWidth int64 `json:"diff_width"`
+	// Attach the git status and diff of the working tree to the first message of
+	// each session
+	GitContext bool `json:"git_context"`
+	// Map file extensions or code fence languages to syntax highlighting languages,
+	// eg { "h": "cpp" }
+	Languages map[string]string `json:"languages"`
+	// Event types or log messages not forwarded to the debug log, eg
+	// message.part.updated
+	LogExclude []string `json:"log_exclude"`
+	// Custom text or ASCII art shown in place of the logo on the home screen
+	Logo string `json:"logo"`
+	// What pasting long text does: store it as a single attachment, ask which to
+	// do, or insert it into the editor. Defaults to attach
+	PasteLong ConfigTuiPasteLong `json:"paste_long"`
+	// Pasted text with more lines than this, or over 150 characters, counts as
+	// long. Defaults to 3
+	PasteLongLines int64 `json:"paste_long_lines"`
+	// Disable cursor blink and spinners, showing static indicators instead
+	ReducedMotion bool `json:"reduced_motion"`
+	// Additional regex patterns to redact from logs and exported conversations
+	Redact []string `json:"redact"`
+	// Maximum number of diff lines rendered in parallel, defaults to the number of
+	// CPUs
+	RenderConcurrency int64 `json:"render_concurrency"`
+	// Minimum milliseconds between re-renders of a streaming message, for slow
+	// terminals. 0 disables
+	RenderThrottle int64 `json:"render_throttle"`
+	// Path conversations are saved to, relative to the project root. {id} is
+	// replaced with the session ID
+	SavePath string `json:"save_path"`
+	// Scroll to the latest message when the messages regain focus
+	ScrollOnFocus bool `json:"scroll_on_focus"`
+	// Named prompt snippets, inserted by typing : followed by the name. Tab moves
+	// between ${1:default} placeholders, ending at $0
+	Snippets map[string]string `json:"snippets"`
+	// Spinner style used for activity indicators
+	Spinner ConfigTuiSpinner `json:"spinner"`
+	// Text shown below the logo on the home screen
+	Tagline string `json:"tagline"`
+	// Message lines a notification shows before truncating, defaults to 6
+	ToastMaxLines int64 `json:"toast_max_lines"`
+	// Notifications shown at once, older ones are counted below the newest,
+	// defaults to 3
+	ToastMaxStack int64 `json:"toast_max_stack"`
+	// Maximum notification width in columns, defaults to a third of the screen
+	ToastMaxWidth int64 `json:"toast_max_width"`
+	// Render colors in tool output, such as from test runners, instead of stripping
+	// them
+	ToolOutputAnsi bool `json:"tool_output_ansi"`
+	// Lines of tool output shown before truncating, defaults to 10
+	ToolOutputMaxLines int64 `json:"tool_output_max_lines"`
+	// When an update is installed, offer to restart into it or only show a
+	// notification, defaults to silent
+	UpdateRestart ConfigTuiUpdateRestart `json:"update_restart"`
+	// How to warn when the server runs a different version than the TUI, defaults
+	// to banner
+	VersionMismatch ConfigTuiVersionMismatch `json:"version_mismatch"`
+	JSON            configTuiJSON            `json:"-"`
+}
+
+// configTuiJSON contains the JSON metadata for the struct [ConfigTui]
+type configTuiJSON struct {
+	AutoAttachFile      apijson.Field
+	BusyIndicator       apijson.Field
+	ChangeGutter        apijson.Field
+	ConfirmPromptTokens apijson.Field
+	ConfirmShare        apijson.Field
+	CursorBlink         apijson.Field
+	CursorShape         apijson.Field
+	DiffLineNumbers     apijson.Field
+	DiffPreset          apijson.Field
+	DiffSymbols         apijson.Field
+	DiffWidth           apijson.Field
+	EditorAutoPair      apijson.Field
+	EditorCharLimit     apijson.Field
+	EditorCharWarning   apijson.Field
+	EditorMaxHeight     apijson.Field
+	EmptyEnter          apijson.Field
+	GitContext          apijson.Field
+	Languages           apijson.Field
+	LogExclude          apijson.Field
+	Logo                apijson.Field
+	PasteLong           apijson.Field
+	PasteLongLines      apijson.Field
+	ReducedMotion       apijson.Field
+	Redact              apijson.Field
+	RenderConcurrency   apijson.Field
+	RenderThrottle      apijson.Field
+	SavePath            apijson.Field
+	ScrollOnFocus       apijson.Field
+	Snippets            apijson.Field
+	Spinner             apijson.Field
+	Tagline             apijson.Field
+	ToastMaxLines       apijson.Field
+	ToastMaxStack       apijson.Field
+	ToastMaxWidth       apijson.Field
+	ToolOutputAnsi      apijson.Field
+	ToolOutputMaxLines  apijson.Field
+	UpdateRestart       apijson.Field
+	VersionMismatch     apijson.Field
+	raw                 string
+	ExtraFields         map[string]apijson.Field
+}
+
+func (r *ConfigTui) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r configTuiJSON) RawJSON() string {
+	return r.raw
+}
+
+// Show activity in the status bar as an animated spinner or static text
+type ConfigTuiBusyIndicator string
+
+const (
+	ConfigTuiBusyIndicatorSpinner ConfigTuiBusyIndicator = "spinner"
+	ConfigTuiBusyIndicatorText    ConfigTuiBusyIndicator = "text"
+)
+
+func (r ConfigTuiBusyIndicator) IsKnown() bool {
+	switch r {
+	case ConfigTuiBusyIndicatorSpinner, ConfigTuiBusyIndicatorText:
+		return true
+	}
+	return false
+}
+
+// Editor cursor shape
+type ConfigTuiCursorShape string
+
+const (
+	ConfigTuiCursorShapeBlock     ConfigTuiCursorShape = "block"
+	ConfigTuiCursorShapeBar       ConfigTuiCursorShape = "bar"
+	ConfigTuiCursorShapeUnderline ConfigTuiCursorShape = "underline"
+)
+
+func (r ConfigTuiCursorShape) IsKnown() bool {
+	switch r {
+	case ConfigTuiCursorShapeBlock, ConfigTuiCursorShapeBar, ConfigTuiCursorShapeUnderline:
+		return true
+	}
+	return false
+}
+
+// Spinner style used for activity indicators
+type ConfigTuiSpinner string
+
+const (
+	ConfigTuiSpinnerDots   ConfigTuiSpinner = "dots"
+	ConfigTuiSpinnerLine   ConfigTuiSpinner = "line"
+	ConfigTuiSpinnerBounce ConfigTuiSpinner = "bounce"
+)
+
+func (r ConfigTuiSpinner) IsKnown() bool {
+	switch r {
+	case ConfigTuiSpinnerDots, ConfigTuiSpinnerLine, ConfigTuiSpinnerBounce:
+		return true
+	}
+	return false
+}
+
+// When an update is installed, offer to restart into it or only show a
+// notification, defaults to silent
+type ConfigTuiUpdateRestart string
+
+const (
+	ConfigTuiUpdateRestartPrompt ConfigTuiUpdateRestart = "prompt"
+	ConfigTuiUpdateRestartSilent ConfigTuiUpdateRestart = "silent"
+)
+
+func (r ConfigTuiUpdateRestart) IsKnown() bool {
+	switch r {
+	case ConfigTuiUpdateRestartPrompt, ConfigTuiUpdateRestartSilent:
+		return true
+	}
+	return false
+}
+
+// How to warn when the server runs a different version than the TUI, defaults
+// to banner
+type ConfigTuiVersionMismatch string
+
+const (
+	ConfigTuiVersionMismatchBanner ConfigTuiVersionMismatch = "banner"
+	ConfigTuiVersionMismatchToast  ConfigTuiVersionMismatch = "toast"
+	ConfigTuiVersionMismatchIgnore ConfigTuiVersionMismatch = "ignore"
+)
+
+func (r ConfigTuiVersionMismatch) IsKnown() bool {
+	switch r {
+	case ConfigTuiVersionMismatchBanner, ConfigTuiVersionMismatchToast, ConfigTuiVersionMismatchIgnore:
+		return true
+	}
+	return false
+}
+
+// What pasting long text does: store it as a single attachment, ask which to
+// do, or insert it into the editor. Defaults to attach
+type ConfigTuiPasteLong string
+
+const (
+	ConfigTuiPasteLongAttach ConfigTuiPasteLong = "attach"
+	ConfigTuiPasteLongAsk    ConfigTuiPasteLong = "ask"
+	ConfigTuiPasteLongInline ConfigTuiPasteLong = "inline"
+)
+
+func (r ConfigTuiPasteLong) IsKnown() bool {
+	switch r {
+	case ConfigTuiPasteLongAttach, ConfigTuiPasteLongAsk, ConfigTuiPasteLongInline:
+		return true
+	}
+	return false
+}
+
+// What submitting an empty editor does: nothing, or start a new line. Defaults
+// to ignore
+type ConfigTuiEmptyEnter string
+
+const (
+	ConfigTuiEmptyEnterIgnore  ConfigTuiEmptyEnter = "ignore"
+	ConfigTuiEmptyEnterNewline ConfigTuiEmptyEnter = "newline"
+)
+
+func (r ConfigTuiEmptyEnter) IsKnown() bool {
+	switch r {
+	case ConfigTuiEmptyEnterIgnore, ConfigTuiEmptyEnterNewline:
+		return true
+	}
+	return false
+}
+
+type ConfigTuiDiffLineNumbers string
+
+const (
+	ConfigTuiDiffLineNumbersAdaptive ConfigTuiDiffLineNumbers = "adaptive"
+	ConfigTuiDiffLineNumbersFixed    ConfigTuiDiffLineNumbers = "fixed"
+	ConfigTuiDiffLineNumbersNone     ConfigTuiDiffLineNumbers = "none"
+)
+
+func (r ConfigTuiDiffLineNumbers) IsKnown() bool {
+	switch r {
+	case ConfigTuiDiffLineNumbersAdaptive, ConfigTuiDiffLineNumbersFixed, ConfigTuiDiffLineNumbersNone:
+		return true
+	}
+	return false
+}
+
+type ConfigTuiDiffPreset string
+
+const (
+	ConfigTuiDiffPresetTheme        ConfigTuiDiffPreset = "theme"
+	ConfigTuiDiffPresetGitHub       ConfigTuiDiffPreset = "github"
+	ConfigTuiDiffPresetHighContrast ConfigTuiDiffPreset = "high-contrast"
+	ConfigTuiDiffPresetColorblind   ConfigTuiDiffPreset = "colorblind"
+)
+
+func (r ConfigTuiDiffPreset) IsKnown() bool {
+	switch r {
+	case ConfigTuiDiffPresetTheme, ConfigTuiDiffPresetGitHub, ConfigTuiDiffPresetHighContrast, ConfigTuiDiffPresetColorblind:
+		return true
+	}
+	return false
+}
+
 type KeybindsConfig struct {
 	// Exit the application
 	AppExit string `json:"app_exit,required"`
 	// Show help dialog
 	AppHelp string `json:"app_help,required"`
+	// Clear cached message renders and completions
+	DebugClearCaches string `json:"debug_clear_caches,required"`
 	// Open external editor
 	EditorOpen string `json:"editor_open,required"`
+	// Attach the file open in the viewer to the input
+	FileAttach string `json:"file_attach,required"`
+	// Jump to the next change in the file diff
+	FileChangeNext string `json:"file_change_next,required"`
+	// Jump to the previous change in the file diff
+	FileChangePrevious string `json:"file_change_previous,required"`
 	// Close file
 	FileClose string `json:"file_close,required"`
+	// Copy the diff hunk in view as a patch
+	FileCopyHunk string `json:"file_copy_hunk,required"`
 	// Split/unified diff
 	FileDiffToggle string `json:"file_diff_toggle,required"`
+	// List files edited in the session
+	FileEdited string `json:"file_edited,required"`
+	// Jump to the message whose edit changed the diff line in view
+	FileGotoEdit string `json:"file_goto_edit,required"`
+	// Open all files edited in the session as tabs
+	FileOpenEdited string `json:"file_open_edited,required"`
+	// Widen the file viewer beside the messages
+	FileViewerGrow string `json:"file_viewer_grow,required"`
+	// Narrow the file viewer beside the messages
+	FileViewerShrink string `json:"file_viewer_shrink,required"`
+	// Choose which event types are forwarded to the debug log
+	LogFilter string `json:"log_filter,required"`
+	// Toggle the live event inspector, requires --debug
+	EventInspector string `json:"event_inspector,required"`
+	// Switch between a light and dark terminal background
+	ThemeBackground string `json:"theme_background,required"`
+	// Reload themes from disk
+	ThemeReload string `json:"theme_reload,required"`
+	// Cycle the padding around the main content
+	LayoutPadding string `json:"layout_padding,required"`
+	// Open the full output of the tool call in view
+	MessagesToolOutput string `json:"messages_tool_output,required"`
+	// Copy the input arguments of the latest tool call as JSON
+	MessagesCopyToolInput string `json:"messages_copy_tool_input,required"`
+	// Copy the code blocks of the message in view
+	MessagesCopyCode string `json:"messages_copy_code,required"`
+	// Mark two responses and diff their text
+	MessagesCompare string `json:"messages_compare,required"`
+	// Show or hide context injected into messages, for debugging
+	MessagesSynthetic string `json:"messages_synthetic,required"`
+	// Shorten file paths in messages to their last segments
+	MessagesShortPaths string `json:"messages_short_paths,required"`
 	// List files
 	FileList string `json:"file_list,required"`
+	// Next file tab
+	FileNext string `json:"file_next,required"`
+	// Previous file tab
+	FilePrevious string `json:"file_previous,required"`
 	// Search file
 	FileSearch string `json:"file_search,required"`
+	// Cycle focus between the editor, messages and file viewer
+	FocusToggle string `json:"focus_toggle,required"`
+	// Toggle focusing the editor when a session loads
+	FocusAuto string `json:"focus_auto,required"`
+	// Scroll to the most recent error in the session
+	MessagesLastError string `json:"messages_last_error,required"`
+	// Show or hide the keybind hints row below the status bar
+	HintsToggle string `json:"hints_toggle,required"`
 	// Clear input field
 	InputClear string `json:"input_clear,required"`
+	// Insert the session working directory at the cursor
+	InputCwd string `json:"input_cwd,required"`
+	// Insert the current date at the cursor
+	InputDate string `json:"input_date,required"`
+	// Wrap the selection or input in a code fence
+	InputFence string `json:"input_fence,required"`
+	// Remove attachments from the input, keeping the text
+	InputClearAttachments string `json:"input_clear_attachments,required"`
+	// Attach the git status and diff of the working tree
+	InputGitContext string `json:"input_git_context,required"`
+	// Open or close file completion at the cursor
+	InputFileCompletion string `json:"input_file_completion,required"`
+	// Insert file contents inline
+	InputFileInsert string `json:"input_file_insert,required"`
+	// Cycle editor line numbers between off, absolute and relative
+	InputLineNumbers string `json:"input_line_numbers,required"`
 	// Insert newline in input
 	InputNewline string `json:"input_newline,required"`
 	// Paste from clipboard
 	InputPaste string `json:"input_paste,required"`
+	// Insert the current time at the cursor
+	InputTime string `json:"input_time,required"`
 	// Submit input
 	InputSubmit string `json:"input_submit,required"`
 	// Leader key for keybind combinations
 	Leader string `json:"leader,required"`
 	// Copy message
 	MessagesCopy string `json:"messages_copy,required"`
+	// Cycle message density between comfortable and compact
+	MessagesDensity string `json:"messages_density,required"`
 	// Navigate to first message
 	MessagesFirst string `json:"messages_first,required"`
 	// Scroll messages down by half page
@@ -507,6 +895,12 @@ type KeybindsConfig struct {
 	MessagesLast string `json:"messages_last,required"`
 	// Toggle layout
 	MessagesLayoutToggle string `json:"messages_layout_toggle,required"`
+	// Scroll messages down a few lines
+	MessagesLineDown string `json:"messages_line_down,required"`
+	// Scroll messages up a few lines
+	MessagesLineUp string `json:"messages_line_up,required"`
+	// Toggle model badges on assistant messages
+	MessagesModelBadges string `json:"messages_model_badges,required"`
 	// Navigate to next message
 	MessagesNext string `json:"messages_next,required"`
 	// Scroll messages down by one page
@@ -515,77 +909,186 @@ type KeybindsConfig struct {
 	MessagesPageUp string `json:"messages_page_up,required"`
 	// Navigate to previous message
 	MessagesPrevious string `json:"messages_previous,required"`
+	// Toggle between rendered and raw markdown in messages
+	MessagesRaw string `json:"messages_raw,required"`
 	// Revert message
 	MessagesRevert string `json:"messages_revert,required"`
+	// Show table of contents for the current message
+	MessagesToc string `json:"messages_toc,required"`
+	// Close every open dialog
+	ModalCloseAll string `json:"modal_close_all,required"`
+	// Bring the next open dialog to the front
+	ModalCycle string `json:"modal_cycle,required"`
 	// List available models
 	ModelList string `json:"model_list,required"`
+	// Review and revoke permissions allowed for the session
+	PermissionList string `json:"permission_list,required"`
 	// Create/update AGENTS.md
 	ProjectInit string `json:"project_init,required"`
+	// Cancel a sent message before the server accepts it
+	PromptCancel string `json:"prompt_cancel,required"`
+	// Move the editor content into a new session
+	PromptNewSession string `json:"prompt_new_session,required"`
+	// Pick a provider to list its models
+	ProviderList string `json:"provider_list,required"`
+	// Abort every session with a response in progress
+	SessionAbortAll string `json:"session_abort_all,required"`
+	// Open the latest sub-agent session
+	SessionChild string `json:"session_child,required"`
 	// Compact the session
 	SessionCompact string `json:"session_compact,required"`
+	// Copy the current session ID
+	SessionCopyID string `json:"session_copy_id,required"`
+	// Copy the last chat request as a curl command
+	SessionCurl string `json:"session_curl,required"`
 	// Export session to editor
 	SessionExport string `json:"session_export,required"`
+	// Save the conversation as markdown within the project
+	SessionSave string `json:"session_save,required"`
+	// Replay the user messages of an exported conversation into a new session
+	SessionImport string `json:"session_import,required"`
 	// Interrupt current session
 	SessionInterrupt string `json:"session_interrupt,required"`
 	// List all sessions
 	SessionList string `json:"session_list,required"`
 	// Create a new session
 	SessionNew string `json:"session_new,required"`
+	// Add the input as a note to the conversation, or a separator when empty
+	SessionNote string `json:"session_note,required"`
+	// Return to the parent session
+	SessionParent string `json:"session_parent,required"`
 	// Share current session
 	SessionShare string `json:"session_share,required"`
 	// Unshare current session
 	SessionUnshare string `json:"session_unshare,required"`
+	// Open the shared session or server dashboard in a browser
+	SessionWeb string `json:"session_web,required"`
 	// Next mode
 	SwitchMode string `json:"switch_mode,required"`
 	// Previous Mode
 	SwitchModeReverse string `json:"switch_mode_reverse,required"`
 	// List available themes
 	ThemeList string `json:"theme_list,required"`
+	// Expand the newest truncated notification
+	ToastExpand string `json:"toast_expand,required"`
 	// Toggle tool details
-	ToolDetails string             `json:"tool_details,required"`
-	JSON        keybindsConfigJSON `json:"-"`
+	ToolDetails string `json:"tool_details,required"`
+	// Collapse the details of every tool call
+	ToolDetailsCollapse string `json:"tool_details_collapse,required"`
+	// Expand the details of every tool call
+	ToolDetailsExpand string `json:"tool_details_expand,required"`
+	// Scroll tool output left
+	ToolOutputLeft string `json:"tool_output_left,required"`
+	// Scroll tool output right
+	ToolOutputRight string `json:"tool_output_right,required"`
+	// Toggle tool output wrap
+	ToolOutputWrap string             `json:"tool_output_wrap,required"`
+	JSON           keybindsConfigJSON `json:"-"`
 }
 
 // keybindsConfigJSON contains the JSON metadata for the struct [KeybindsConfig]
 type keybindsConfigJSON struct {
-	AppExit              apijson.Field
-	AppHelp              apijson.Field
-	EditorOpen           apijson.Field
-	FileClose            apijson.Field
-	FileDiffToggle       apijson.Field
-	FileList             apijson.Field
-	FileSearch           apijson.Field
-	InputClear           apijson.Field
-	InputNewline         apijson.Field
-	InputPaste           apijson.Field
-	InputSubmit          apijson.Field
-	Leader               apijson.Field
-	MessagesCopy         apijson.Field
-	MessagesFirst        apijson.Field
-	MessagesHalfPageDown apijson.Field
-	MessagesHalfPageUp   apijson.Field
-	MessagesLast         apijson.Field
-	MessagesLayoutToggle apijson.Field
-	MessagesNext         apijson.Field
-	MessagesPageDown     apijson.Field
-	MessagesPageUp       apijson.Field
-	MessagesPrevious     apijson.Field
-	MessagesRevert       apijson.Field
-	ModelList            apijson.Field
-	ProjectInit          apijson.Field
-	SessionCompact       apijson.Field
-	SessionExport        apijson.Field
-	SessionInterrupt     apijson.Field
-	SessionList          apijson.Field
-	SessionNew           apijson.Field
-	SessionShare         apijson.Field
-	SessionUnshare       apijson.Field
-	SwitchMode           apijson.Field
-	SwitchModeReverse    apijson.Field
-	ThemeList            apijson.Field
-	ToolDetails          apijson.Field
-	raw                  string
-	ExtraFields          map[string]apijson.Field
+	AppExit               apijson.Field
+	AppHelp               apijson.Field
+	DebugClearCaches      apijson.Field
+	EditorOpen            apijson.Field
+	FileAttach            apijson.Field
+	FileChangeNext        apijson.Field
+	FileChangePrevious    apijson.Field
+	FileClose             apijson.Field
+	FileCopyHunk          apijson.Field
+	FileDiffToggle        apijson.Field
+	FileEdited            apijson.Field
+	FileGotoEdit          apijson.Field
+	FileOpenEdited        apijson.Field
+	FileViewerGrow        apijson.Field
+	FileViewerShrink      apijson.Field
+	LogFilter             apijson.Field
+	EventInspector        apijson.Field
+	ThemeBackground       apijson.Field
+	ThemeReload           apijson.Field
+	LayoutPadding         apijson.Field
+	MessagesToolOutput    apijson.Field
+	MessagesCopyToolInput apijson.Field
+	MessagesCopyCode      apijson.Field
+	MessagesCompare       apijson.Field
+	MessagesSynthetic     apijson.Field
+	MessagesShortPaths    apijson.Field
+	FileList              apijson.Field
+	FileNext              apijson.Field
+	FilePrevious          apijson.Field
+	FileSearch            apijson.Field
+	FocusToggle           apijson.Field
+	FocusAuto             apijson.Field
+	MessagesLastError     apijson.Field
+	HintsToggle           apijson.Field
+	InputClear            apijson.Field
+	InputClearAttachments apijson.Field
+	InputCwd              apijson.Field
+	InputDate             apijson.Field
+	InputFence            apijson.Field
+	InputGitContext       apijson.Field
+	InputFileCompletion   apijson.Field
+	InputFileInsert       apijson.Field
+	InputLineNumbers      apijson.Field
+	InputNewline          apijson.Field
+	InputPaste            apijson.Field
+	InputSubmit           apijson.Field
+	InputTime             apijson.Field
+	Leader                apijson.Field
+	MessagesCopy          apijson.Field
+	MessagesDensity       apijson.Field
+	MessagesFirst         apijson.Field
+	MessagesHalfPageDown  apijson.Field
+	MessagesHalfPageUp    apijson.Field
+	MessagesLast          apijson.Field
+	MessagesLayoutToggle  apijson.Field
+	MessagesLineDown      apijson.Field
+	MessagesLineUp        apijson.Field
+	MessagesModelBadges   apijson.Field
+	MessagesNext          apijson.Field
+	MessagesPageDown      apijson.Field
+	MessagesPageUp        apijson.Field
+	MessagesPrevious      apijson.Field
+	MessagesRaw           apijson.Field
+	MessagesRevert        apijson.Field
+	MessagesToc           apijson.Field
+	ModalCloseAll         apijson.Field
+	ModalCycle            apijson.Field
+	ModelList             apijson.Field
+	PermissionList        apijson.Field
+	ProjectInit           apijson.Field
+	PromptCancel          apijson.Field
+	PromptNewSession      apijson.Field
+	ProviderList          apijson.Field
+	SessionAbortAll       apijson.Field
+	SessionChild          apijson.Field
+	SessionCompact        apijson.Field
+	SessionCopyID         apijson.Field
+	SessionCurl           apijson.Field
+	SessionExport         apijson.Field
+	SessionSave           apijson.Field
+	SessionImport         apijson.Field
+	SessionInterrupt      apijson.Field
+	SessionList           apijson.Field
+	SessionNew            apijson.Field
+	SessionNote           apijson.Field
+	SessionParent         apijson.Field
+	SessionShare          apijson.Field
+	SessionUnshare        apijson.Field
+	SessionWeb            apijson.Field
+	SwitchMode            apijson.Field
+	SwitchModeReverse     apijson.Field
+	ThemeList             apijson.Field
+	ToastExpand           apijson.Field
+	ToolDetails           apijson.Field
+	ToolDetailsCollapse   apijson.Field
+	ToolDetailsExpand     apijson.Field
+	ToolOutputLeft        apijson.Field
+	ToolOutputRight       apijson.Field
+	ToolOutputWrap        apijson.Field
+	raw                   string
+	ExtraFields           map[string]apijson.Field
 }
 
 func (r *KeybindsConfig) UnmarshalJSON(data []byte) (err error) {
@@ -112,6 +112,34 @@ func (r *SessionService) Messages(ctx context.Context, id string, opts ...option
 	return
 }
 
+// Add a note to a session without prompting the model
+func (r *SessionService) Note(ctx context.Context, id string, body SessionNoteParams, opts ...option.RequestOption) (res *UserMessage, err error) {
+	opts = append(r.Options[:], opts...)
+	if id == "" {
+		err = errors.New("missing required id parameter")
+		return
+	}
+	path := fmt.Sprintf("session/%s/note", id)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
+// Respond to a permission request
+func (r *SessionService) RespondPermission(ctx context.Context, id string, permissionID string, body SessionRespondPermissionParams, opts ...option.RequestOption) (res *bool, err error) {
+	opts = append(r.Options[:], opts...)
+	if id == "" {
+		err = errors.New("missing required id parameter")
+		return
+	}
+	if permissionID == "" {
+		err = errors.New("missing required permissionID parameter")
+		return
+	}
+	path := fmt.Sprintf("session/%s/permissions/%s", id, permissionID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
 // Share a session
 func (r *SessionService) Share(ctx context.Context, id string, opts ...option.RequestOption) (res *Session, err error) {
 	opts = append(r.Options[:], opts...)
@@ -712,6 +740,7 @@ type Message struct {
 	// This field can have the runtime type of [AssistantMessageError].
 	Error   interface{} `json:"error"`
 	ModelID string      `json:"modelID"`
+	Note    bool        `json:"note"`
 	// This field can have the runtime type of [AssistantMessagePath].
 	Path       interface{} `json:"path"`
 	ProviderID string      `json:"providerID"`
@@ -733,6 +762,7 @@ type messageJSON struct {
 	Cost        apijson.Field
 	Error       apijson.Field
 	ModelID     apijson.Field
+	Note        apijson.Field
 	Path        apijson.Field
 	ProviderID  apijson.Field
 	Summary     apijson.Field
@@ -1942,6 +1972,7 @@ type UserMessage struct {
 	Role      UserMessageRole `json:"role,required"`
 	SessionID string          `json:"sessionID,required"`
 	Time      UserMessageTime `json:"time,required"`
+	Note      bool            `json:"note"`
 	JSON      userMessageJSON `json:"-"`
 }
 
@@ -1951,6 +1982,7 @@ type userMessageJSON struct {
 	Role        apijson.Field
 	SessionID   apijson.Field
 	Time        apijson.Field
+	Note        apijson.Field
 	raw         string
 	ExtraFields map[string]apijson.Field
 }
@@ -2083,6 +2115,38 @@ func (r SessionInitParams) MarshalJSON() (data []byte, err error) {
 	return apijson.MarshalRoot(r)
 }
 
+type SessionNoteParams struct {
+	Text param.Field[string] `json:"text,required"`
+}
+
+func (r SessionNoteParams) MarshalJSON() (data []byte, err error) {
+	return apijson.MarshalRoot(r)
+}
+
+type SessionRespondPermissionParams struct {
+	Response param.Field[SessionRespondPermissionParamsResponse] `json:"response,required"`
+}
+
+func (r SessionRespondPermissionParams) MarshalJSON() (data []byte, err error) {
+	return apijson.MarshalRoot(r)
+}
+
+type SessionRespondPermissionParamsResponse string
+
+const (
+	SessionRespondPermissionParamsResponseOnce   SessionRespondPermissionParamsResponse = "once"
+	SessionRespondPermissionParamsResponseAlways SessionRespondPermissionParamsResponse = "always"
+	SessionRespondPermissionParamsResponseReject SessionRespondPermissionParamsResponse = "reject"
+)
+
+func (r SessionRespondPermissionParamsResponse) IsKnown() bool {
+	switch r {
+	case SessionRespondPermissionParamsResponseOnce, SessionRespondPermissionParamsResponseAlways, SessionRespondPermissionParamsResponseReject:
+		return true
+	}
+	return false
+}
+
 type SessionSummarizeParams struct {
 	ModelID    param.Field[string] `json:"modelID,required"`
 	ProviderID param.Field[string] `json:"providerID,required"`